@@ -0,0 +1,106 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	ackmetadata "github.com/aws-controllers-k8s/code-generator/pkg/metadata"
+)
+
+const (
+	graphFormatDOT     = "dot"
+	graphFormatMermaid = "mermaid"
+	graphFormatJSON    = "json"
+)
+
+var optGraphFormat string
+
+// graphCmd is the command that outputs the graph of the references between
+// the resources of a service
+var graphCmd = &cobra.Command{
+	Use:   "graph <service>",
+	Short: "Output the graph of the references between the resources of an AWS service API",
+	Long: `Builds the graph of the references between the resources of an AWS
+service API, and the resources of other services or Kubernetes objects they
+reference, from the references configuration of their fields, and outputs it
+in the DOT, Mermaid or JSON format.
+
+Exits non-zero if the graph contains a reference cycle in which not every
+reference sets skip_resource_state_validations, as the resources of such a
+cycle never sync.`,
+	RunE: generateGraph,
+}
+
+func init() {
+	graphCmd.PersistentFlags().StringVar(
+		&optGraphFormat, "format", graphFormatDOT, "the output format of the graph: dot, mermaid or json",
+	)
+	rootCmd.AddCommand(graphCmd)
+}
+
+// generateGraph outputs the reference graph of the resources in the AWS
+// service API and returns an error reporting its reference cycles, if any.
+func generateGraph(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("please specify the service alias for the AWS service API to graph")
+	}
+	svcAlias := strings.ToLower(args[0])
+	if optOutputPath == "" {
+		optOutputPath = filepath.Join(optServicesDir, svcAlias)
+	}
+	switch optGraphFormat {
+	case graphFormatDOT, graphFormatMermaid, graphFormatJSON:
+	default:
+		return fmt.Errorf(
+			"unsupported graph format %q, must be one of dot, mermaid or json",
+			optGraphFormat,
+		)
+	}
+
+	cfg, err := setupGenerator(svcAlias)
+	if err != nil {
+		return err
+	}
+	metadata, err := ackmetadata.NewServiceMetadata(optMetadataConfigPath)
+	if err != nil {
+		return err
+	}
+	m, err := loadModelWithLatestAPIVersion(svcAlias, metadata, cfg)
+	if err != nil {
+		return err
+	}
+	g, err := m.GetReferenceGraph()
+	if err != nil {
+		return err
+	}
+
+	switch optGraphFormat {
+	case graphFormatDOT:
+		fmt.Print(g.DOT())
+	case graphFormatMermaid:
+		fmt.Print(g.Mermaid())
+	case graphFormatJSON:
+		out, err := g.JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	}
+	return g.ValidateCycles()
+}
@@ -17,6 +17,14 @@ type ErrorInfo struct {
 	Type           string
 	Code           string
 	HTTPStatusCode int
+
+	// Fault is either "client" or "server", from the smithy.api#error trait
+	Fault string `json:"-"`
+	// Retryable is true if the shape has the smithy.api#retryable trait
+	Retryable bool `json:"-"`
+	// Throttling is true if the smithy.api#retryable trait marks the error
+	// as a throttling error
+	Throttling bool `json:"-"`
 }
 
 // A XMLInfo defines URL and prefix for Shapes when rendered as XML
@@ -99,6 +99,9 @@ func buildAPI(shapes map[string]Shape) (*awssdkmodel.API, string, error) {
 	}
 
 	var serviceAlias string
+	// The pagination settings declared on the service shape are the defaults
+	// of the pagination settings of every paginated operation
+	var servicePaginated map[string]interface{}
 
 	for shapeName, shape := range shapes {
 		name, err := removeShapeNamePrefix(shapeName)
@@ -139,6 +142,7 @@ func buildAPI(shapes map[string]Shape) (*awssdkmodel.API, string, error) {
 			// using AppendDocstring allows us to convert the documentation that is
 			// provided to us in html format into a golang comment style format
 			newApi.Documentation = awssdkmodel.AppendDocstring("", doc.(string))
+			servicePaginated, _ = shape.Traits["smithy.api#paginated"].(map[string]interface{})
 		case "operation":
 			newApi.Operations[name] = createApiOperation(shape, name, serviceAlias)
 		case "structure":
@@ -166,6 +170,10 @@ func buildAPI(shapes map[string]Shape) (*awssdkmodel.API, string, error) {
 
 	}
 
+	for _, op := range newApi.Operations {
+		setPaginatorDefaults(op, servicePaginated)
+	}
+
 	return &newApi, serviceAlias, nil
 }
 
@@ -199,9 +207,37 @@ func createApiOperation(shape Shape, name, serviceAlias string) *awssdkmodel.Ope
 		})
 	}
 
+	if paginated, ok := shape.Traits["smithy.api#paginated"].(map[string]interface{}); ok {
+		newOperation.Paginator = &awssdkmodel.Paginator{}
+		setPaginatorDefaults(newOperation, paginated)
+	}
+
 	return newOperation
 }
 
+// setPaginatorDefaults sets the input token, output token and page size
+// members of a paginated operation that are not set yet from the supplied
+// `smithy.api#paginated` trait.
+func setPaginatorDefaults(op *awssdkmodel.Operation, paginated map[string]interface{}) {
+	p := op.Paginator
+	if p == nil || paginated == nil {
+		return
+	}
+	if p.InputTokens == nil {
+		if token, ok := paginated["inputToken"].(string); ok {
+			p.InputTokens = []string{token}
+		}
+	}
+	if p.OutputTokens == nil {
+		if token, ok := paginated["outputToken"].(string); ok {
+			p.OutputTokens = []string{token}
+		}
+	}
+	if p.LimitKey == "" {
+		p.LimitKey, _ = paginated["pageSize"].(string)
+	}
+}
+
 // createApiShape creates a shape of awssdkmodel.Shape type
 // from the apiv2 Shape.
 func createApiShape(shape Shape) (*awssdkmodel.Shape, error) {
@@ -236,6 +272,14 @@ func createApiShape(shape Shape) (*awssdkmodel.Shape, error) {
 		if ok {
 			apiShape.ErrorInfo.Code, _ = errorTrait.(map[string]interface{})["code"].(string)
 		}
+		apiShape.ErrorInfo.Fault, _ = shape.Traits["smithy.api#error"].(string)
+		retryableTrait, ok := shape.Traits["smithy.api#retryable"]
+		if ok {
+			apiShape.ErrorInfo.Retryable = true
+			if retryable, ok := retryableTrait.(map[string]interface{}); ok {
+				apiShape.ErrorInfo.Throttling, _ = retryable["throttling"].(bool)
+			}
+		}
 
 	}
 
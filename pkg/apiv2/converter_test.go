@@ -150,3 +150,35 @@ func TestCreateApiShape_Integer(t *testing.T) {
 	assert.Equal("", apiShape.DefaultValue)
 	assert.False(apiShape.HasDefaultValue())
 }
+
+// TestCreateApiShape_ErrorTraits verifies the Smithy error traits of an
+// exception shape are recorded in its ErrorInfo.
+func TestCreateApiShape_ErrorTraits(t *testing.T) {
+	assert := assert.New(t)
+
+	apiShape, err := createApiShape(Shape{
+		Type: "structure",
+		Traits: map[string]interface{}{
+			"smithy.api#error":     "client",
+			"smithy.api#httpError": float64(429),
+			"smithy.api#retryable": map[string]interface{}{"throttling": true},
+		},
+	})
+	assert.NoError(err)
+	assert.True(apiShape.Exception)
+	assert.Equal("client", apiShape.ErrorInfo.Fault)
+	assert.Equal(429, apiShape.ErrorInfo.HTTPStatusCode)
+	assert.True(apiShape.ErrorInfo.Retryable)
+	assert.True(apiShape.ErrorInfo.Throttling)
+
+	apiShape, err = createApiShape(Shape{
+		Type: "structure",
+		Traits: map[string]interface{}{
+			"smithy.api#error": "server",
+		},
+	})
+	assert.NoError(err)
+	assert.Equal("server", apiShape.ErrorInfo.Fault)
+	assert.False(apiShape.ErrorInfo.Retryable)
+	assert.False(apiShape.ErrorInfo.Throttling)
+}
@@ -41,6 +41,11 @@ type Config struct {
 	// SetManyOutput function fails with NotFound error.
 	// Default is "return nil, ackerr.NotFound"
 	SetManyOutputNotFoundErrReturn string `json:"set_many_output_notfound_err_return,omitempty"`
+	// InferExceptionCodes lets you specify whether the terminal and retryable
+	// exception codes of the resources are inferred from the error traits of
+	// the API model when they are not set in the resources' exceptions
+	// config. Default is false.
+	InferExceptionCodes bool `json:"infer_exception_codes,omitempty"`
 	// SDKNames lets you specify SDK object names. This configuration field was
 	// introduces when we learned that the EventBridgePipes service renamed, not
 	// only the service model name, but also the iface name to `PipesAPI`. See
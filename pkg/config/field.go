@@ -15,6 +15,7 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 )
 
@@ -273,6 +274,211 @@ type CompareFieldConfig struct {
 	// PreDeleteInclude indicates the field should be included in the
 	// pre-delete delta comparison even if IsIgnored is true
 	PreDeleteInclude bool `json:"pre_delete_include"`
+	// ListSemantics instructs the code generator how the elements of a list
+	// field should be matched against each other when comparing two
+	// resources. Many AWS APIs return lists (security group IDs, subnets,
+	// rules) in a different order than they were submitted in, which, when
+	// compared positionally, results in a never-ending update loop.
+	//
+	// Options:
+	//   - ordered: elements are compared positionally.
+	//   - set: elements are compared regardless of their order.
+	//   - keyed: elements are structs matched by the value of their KeyMember,
+	//     which is marked required in the CRD schema. The first element that
+	//     changed is reported in the delta at the path of the list followed by
+	//     its key, e.g. `Spec.IngressRules.<RuleID>`, and the list is only
+	//     reported once however many of its elements changed.
+	//
+	// When empty, lists of strings are compared regardless of their order and
+	// all other lists are compared positionally.
+	//
+	// The same semantics are emitted on the CRD as `+listType=set` or
+	// `+listType=map` and `+listMapKey` markers.
+	//
+	// resources:
+	//
+	//	SecurityGroup:
+	//	  fields:
+	//	    IngressRules:
+	//	      compare:
+	//	        list_semantics: keyed
+	//	        key_member: RuleID
+	ListSemantics string `json:"list_semantics,omitempty"`
+	// KeyMember is the name of the member of the list element struct whose
+	// value uniquely identifies an element. Only used, and required, when
+	// ListSemantics is "keyed".
+	KeyMember string `json:"key_member,omitempty"`
+	// Normalize is the list of built-in normalizers applied, in order, to both
+	// sides of a comparison before the values are compared. AWS frequently
+	// normalizes the values it is sent (lowercasing identifiers, stripping
+	// trailing dots from DNS names, expanding short names into ARNs,
+	// reordering JSON keys), which would otherwise show up as a difference on
+	// every reconciliation.
+	//
+	// Normalizers can only be applied to string fields, lists of strings and
+	// maps with string values. Normalization only affects the comparison: the
+	// values stored in the delta are the original, non-normalized values.
+	//
+	// Options:
+	//   - lowercase: lowercases the value.
+	//   - trim: removes leading and trailing whitespace.
+	//   - trim_suffix: removes the supplied suffix, e.g. `trim_suffix: "."`.
+	//   - json_canonical: re-marshals a JSON document, sorting its keys and
+	//     removing insignificant whitespace.
+	//   - arn_canonical: reduces the ARN of a resource in the same account and
+	//     region as the compared resource to its final resource identifier, so
+	//     that the ARN and the short name it was expanded from compare equal.
+	//     ARNs in other accounts or regions are compared in full.
+	//   - numeric_string: formats a numeric value in its shortest form, so
+	//     that "1.0" and "1" compare equal.
+	//   - duration: formats a Go duration, or a number of seconds, in its
+	//     canonical form, so that "60m", "1h" and "3600" compare equal.
+	//
+	// Values that cannot be parsed by json_canonical, numeric_string or
+	// duration are compared as-is.
+	//
+	// resources:
+	//
+	//	RecordSet:
+	//	  fields:
+	//	    Name:
+	//	      compare:
+	//	        normalize:
+	//	          - lowercase
+	//	          - trim_suffix: "."
+	Normalize []NormalizerConfig `json:"normalize,omitempty"`
+}
+
+const (
+	// NormalizerLowercase lowercases a string value.
+	NormalizerLowercase = "lowercase"
+	// NormalizerTrim removes leading and trailing whitespace from a string
+	// value.
+	NormalizerTrim = "trim"
+	// NormalizerTrimSuffix removes a suffix from a string value.
+	NormalizerTrimSuffix = "trim_suffix"
+	// NormalizerJSONCanonical re-marshals a JSON document in canonical form.
+	NormalizerJSONCanonical = "json_canonical"
+	// NormalizerARNCanonical reduces an ARN in the compared resource's account
+	// and region to its final resource identifier.
+	NormalizerARNCanonical = "arn_canonical"
+	// NormalizerNumericString formats a numeric string in its shortest form.
+	NormalizerNumericString = "numeric_string"
+	// NormalizerDuration formats a duration string in canonical form.
+	NormalizerDuration = "duration"
+)
+
+// NormalizerConfig describes a single built-in normalizer applied to a value
+// before it is compared. It can be unmarshalled either from the bare name of
+// a normalizer (`lowercase`) or from a single-key map of the normalizer name
+// to its argument (`trim_suffix: "."`).
+type NormalizerConfig struct {
+	// Name is the name of the normalizer, e.g. "lowercase"
+	Name string
+	// Argument is the argument supplied to the normalizer, if any. Only
+	// trim_suffix takes an argument.
+	Argument *string
+}
+
+// UnmarshalJSON unmarshals a NormalizerConfig from a YAML/JSON byte slice.
+func (n *NormalizerConfig) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		n.Name = name
+		return nil
+	}
+	var withArg map[string]string
+	if err := json.Unmarshal(b, &withArg); err != nil {
+		return err
+	}
+	if len(withArg) != 1 {
+		return fmt.Errorf(
+			"normalizer must be a name or a single-key map of name to argument, got %d keys",
+			len(withArg),
+		)
+	}
+	for name, arg := range withArg {
+		n.Name = name
+		n.Argument = &arg
+	}
+	return nil
+}
+
+const (
+	// ListSemanticsOrdered compares the elements of a list positionally.
+	ListSemanticsOrdered = "ordered"
+	// ListSemanticsSet compares the elements of a list regardless of their
+	// order.
+	ListSemanticsSet = "set"
+	// ListSemanticsKeyed matches the struct elements of a list by the value
+	// of a key member before comparing them.
+	ListSemanticsKeyed = "keyed"
+)
+
+// ChildCollectionConfig instructs the code generator that a top-level Spec
+// list field is a collection of children that are attached to, or registered
+// with, the resource through dedicated add and remove SDK operations, for
+// example `AttachRolePolicy`/`DetachRolePolicy` or
+// `RegisterTargets`/`DeregisterTargets`.
+//
+// The code generator emits the `sync<Field>` method that a `custom_sync` field
+// otherwise requires the controller author to hand-write. The method computes
+// the elements that are only in the desired resource and the elements that are
+// only in the latest resource, and calls the remove operation and then the add
+// operation for them. When ReadOperation is set, the collection is also read
+// back after the resource is found, so that children added or removed outside
+// of the controller are detected.
+//
+// When the InputMember of the add and remove operations' Input shapes is a
+// list, the operations are treated as `OpTypeAddChildren` and
+// `OpTypeRemoveChildren` and are called with batches of up to BatchSize
+// children. Otherwise, they are treated as `OpTypeAddChild` and
+// `OpTypeRemoveChild` and are called once per child, in which case the
+// field must be a list of strings.
+//
+// The other members of the operations' Input shapes (typically the resource's
+// identifier) are populated from the resource the same way as the Input shape
+// of the resource's Update operation, so they can be renamed with
+// `renames.operations`.
+//
+// resources:
+//
+//	Role:
+//	  fields:
+//	    Policies:
+//	      type: "[]*string"
+//	      child_collection:
+//	        add_operation: AttachRolePolicy
+//	        remove_operation: DetachRolePolicy
+//	        input_member: PolicyArn
+//	        read_operation: ListAttachedRolePolicies
+//	        read_output_path: AttachedPolicies.PolicyArn
+type ChildCollectionConfig struct {
+	// AddOperation is the name of the SDK operation that adds children to the
+	// resource, e.g. "AttachRolePolicy"
+	AddOperation string `json:"add_operation"`
+	// RemoveOperation is the name of the SDK operation that removes children
+	// from the resource, e.g. "DetachRolePolicy"
+	RemoveOperation string `json:"remove_operation"`
+	// InputMember is the name of the member of the add and remove operations'
+	// Input shapes that receives the children. Defaults to the name of the
+	// field.
+	InputMember string `json:"input_member,omitempty"`
+	// BatchSize is the maximum number of children passed to a single call of
+	// the add or remove operation when InputMember is a list. Defaults to
+	// passing all the children in a single call.
+	BatchSize int `json:"batch_size,omitempty"`
+	// ReadOperation is the name of the SDK operation that returns the
+	// children of the resource, e.g. "ListAttachedRolePolicies". Every page
+	// of a paginated operation is read.
+	ReadOperation string `json:"read_operation,omitempty"`
+	// ReadOutputPath is the path, within the ReadOperation's Output shape, of
+	// the list of children. It is either the name of a list member, whose
+	// elements have the same type as the field's elements, or the name of a
+	// list-of-structs member followed by the name of a string member of the
+	// struct, e.g. "AttachedPolicies.PolicyArn". Required when ReadOperation
+	// is set.
+	ReadOutputPath string `json:"read_output_path,omitempty"`
 }
 
 // CustomSyncConfig instructs the code generator that the field is not
@@ -396,6 +602,21 @@ type SkipIncompleteLateInitializeCheckConfig struct {
 // When 'APIRef' field is present in custom resource manifest, reconciler will
 // read the referred 'API' resource and copy the value from 'Status.APIID' in
 // 'Integration' resource's 'APIID' field
+//
+// References can also target Kubernetes objects that are not ACK resources,
+// like ConfigMaps, Services, ServiceAccounts, Namespaces or objects of any CRD, by setting the object's group, version and kind and a JSONPath
+// extracting the value from it:
+// ```
+// Key:
+//
+//	fields:
+//	  Description:
+//	    references:
+//	      version: v1
+//	      kind: ConfigMap
+//	      json_path: "{.data.description}"
+//
+// ```
 type ReferencesConfig struct {
 	// ServiceName mentions the AWS service name where "Resource" exists.
 	// This field is used to generate the API Group for the "Resource".
@@ -429,6 +650,37 @@ type ReferencesConfig struct {
 	// Path refers to the the path of field which should be copied
 	// to resolve the reference
 	Path string `json:"path"`
+	// Group is the API group of the referenced Kubernetes object, empty for
+	// core objects like ConfigMaps or ServiceAccounts. Only used with Kind.
+	Group string `json:"group,omitempty"`
+	// Version is the API version of the referenced Kubernetes object, e.g.
+	// "v1". Only used with Kind.
+	Version string `json:"version,omitempty"`
+	// Kind is the Kind of the referenced Kubernetes object. When set, the
+	// reference reads the Kubernetes object instead of an ACK resource named
+	// by Resource, and does not expect the object to have ACK conditions.
+	Kind string `json:"kind,omitempty"`
+	// JSONPath is the JSONPath expression extracting the value of the field
+	// from the referenced Kubernetes object, e.g. "{.data.description}" or
+	// "{.metadata.annotations.eks\.amazonaws\.com/role-arn}". Only used
+	// with Kind.
+	//
+	// Secrets cannot be referenced: the extracted value is copied into the
+	// resource's Spec, which would store the secret data in plain text. Use
+	// a field with `is_secret` instead.
+	JSONPath string `json:"json_path,omitempty"`
+	// AllowCrossNamespace allows the reference to read a Kubernetes object
+	// in another namespace than the referencing resource's, if the
+	// controller is also started with cross-namespace references enabled.
+	// Only used with Kind.
+	AllowCrossNamespace bool `json:"allow_cross_namespace,omitempty"`
+}
+
+// IsKubernetesObjectReference returns true if the reference targets a
+// Kubernetes object identified by its group, version and kind instead of an
+// ACK resource
+func (c *ReferencesConfig) IsKubernetesObjectReference() bool {
+	return c != nil && c.Kind != ""
 }
 
 // FieldConfig contains instructions to the code generator about how
@@ -454,6 +706,12 @@ type FieldConfig struct {
 	// override the default behaviour of considering a field called "Name" or
 	// "{Resource}Name" or "{Resource}Id" as the "name field" for the resource.
 	IsPrimaryKey bool `json:"is_primary_key"`
+	// PrimaryKeyPosition is the 1-based position of the field in the
+	// composite primary key of the resource, formed when several of its fields
+	// are marked with `is_primary_key`. The identifier of a resource with a
+	// composite primary key joins the values of these fields, in this order,
+	// with the resource's `primary_key_separator`.
+	PrimaryKeyPosition int `json:"primary_key_position,omitempty"`
 	// IsOwnerAccountID indicates the field contains the AWS Account ID
 	// that owns the resource. This is a special field that we direct to
 	// storage in the common `Status.ACKResourceMetadata.OwnerAccountID` field.
@@ -508,6 +766,11 @@ type FieldConfig struct {
 	// operation, and that the boilerplate invoking that function should be
 	// generated into sdkUpdate.
 	CustomSync *CustomSyncConfig `json:"custom_sync,omitempty"`
+	// ChildCollection instructs the code generator that this list field is a
+	// collection of children that are added to and removed from the resource
+	// using dedicated SDK operations, and that the code syncing the
+	// collection should be generated.
+	ChildCollection *ChildCollectionConfig `json:"child_collection,omitempty"`
 	// Type *overrides* the inferred Go type of the field. This is required for
 	// custom fields that are not inferred either as a Create Input/Output
 	// shape or via the SourceFieldConfig attribute.
@@ -14,6 +14,8 @@
 package config
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	awssdkmodel "github.com/aws-controllers-k8s/code-generator/pkg/api"
@@ -50,6 +52,33 @@ type ResourceConfig struct {
 	// current status. If the resource is not in an allowed state, the delete
 	// operation is requeued.
 	Deletable *DeletableConfig `json:"deletable,omitempty"`
+	// AsyncOperations is a map, keyed by the type of the operation (Create,
+	// Update or Delete), of instructions for the code generator to track
+	// operations that complete asynchronously. The response of such an
+	// operation contains the ID of an operation, or change, that must be
+	// polled with a separate API until it completes, e.g. the ChangeInfo
+	// returned by Route 53 and polled with GetChange.
+	//
+	// The generated code stores the operation ID in a Status field, polls the
+	// status of the operation when reading the resource, and only reports the
+	// resource as synced, and updates or deletes it, once the operation has
+	// completed. The Create and Update operations of an upsert resource are
+	// its Replace operation. For a resource configured with
+	// `update_operation.operations`, the ID is read from the response of each
+	// of those operations whose Output shape has a member at `id_path`.
+	//
+	// ```
+	// async_operations:
+	//   Create:
+	//     id_path: ChangeInfo.Id
+	//     status_field: ChangeID
+	//     status_operation: GetChange
+	//     status_id_member: Id
+	//     status_path: ChangeInfo.Status
+	//     completed_when:
+	//       - INSYNC
+	// ```
+	AsyncOperations map[string]*AsyncOperationConfig `json:"async_operations,omitempty"`
 	// Renames identifies fields in Operations that should be renamed.
 	Renames *RenamesConfig `json:"renames,omitempty"`
 	// ListOperation contains instructions for the code generator to generate
@@ -123,9 +152,29 @@ type ResourceConfig struct {
 	// Print contains instructions for the code generator to generate kubebuilder printcolumns
 	// marker comments.
 	Print *PrintConfig `json:"print,omitempty"`
+	// PutOperationIsReplace instructs the code generator to classify the
+	// resource's `Put*` operation, e.g. ECR's PutLifecyclePolicy for a
+	// LifecyclePolicy resource, the same way it classifies `CreateOrUpdate*`
+	// operations: as a single operation that both creates and updates the
+	// resource. A resource that has such an operation but no Create operation
+	// is generated as an upsert resource, whose sdkCreate and sdkUpdate both
+	// call the operation. Unset, `Put*` operations are only classified through
+	// `operations:` config.
+	PutOperationIsReplace bool `json:"put_operation_is_replace,omitempty"`
 	// IsARNPrimaryKey determines whether the CRD uses the ARN as the primary
 	// identifier in the ReadOne operations.
 	IsARNPrimaryKey bool `json:"is_arn_primary_key"`
+	// PrimaryKeySeparator is the separator of the values of the fields of a
+	// composite primary key in the identifier of the resource, e.g. the
+	// `NameOrID` of an AdoptedResource. Defaults to "/".
+	PrimaryKeySeparator string `json:"primary_key_separator,omitempty"`
+	// ARNTemplate is the template of the ARN of a resource whose API returns
+	// none, used to set `Status.ACKResourceMetadata.ARN` once the resource is
+	// created or read when it is not set. Placeholders within braces are
+	// replaced by the `partition`, `region` and `account` of the resource or
+	// by the value of a string field, e.g.
+	// `arn:{partition}:logs:{region}:{account}:log-group:{Spec.Name}`.
+	ARNTemplate string `json:"arn_template,omitempty"`
 	// TagConfig contains instructions for the code generator to generate
 	// custom code for ensuring tags
 	TagConfig *TagConfig `json:"tags,omitempty"`
@@ -135,6 +184,129 @@ type ResourceConfig struct {
 	// SDK implementation details that are auto-filled by the SDK middleware
 	// when nil and should not be exposed in the CRD.
 	IgnoreIdempotencyToken bool `json:"ignore_idempotency_token,omitempty"`
+	// ObserveOnly instructs the code generator to generate a read-only
+	// resource kind, for AWS resources that are managed outside of Kubernetes
+	// but that should be visible in (and referenced from) the cluster. An
+	// observe-only resource only needs a ReadOne, GetAttributes or ReadMany
+	// operation. Its Spec only contains the fields identifying the AWS
+	// resource (the required members of the read operation's Input shape) and
+	// all other fields are placed in its Status. The controller never creates,
+	// updates or deletes the AWS resource: sdkCreate, sdkUpdate and sdkDelete
+	// return a terminal error and deleting the custom resource leaves the AWS
+	// resource untouched.
+	//
+	// Observe-only resources are not adoptable, since creating the custom
+	// resource with the identifying fields is all that is needed to observe
+	// an existing AWS resource.
+	ObserveOnly bool `json:"observe_only,omitempty"`
+	// Singleton instructs the code generator to generate a resource for
+	// account-level or regional settings that always exist and have no Create
+	// or Delete operation, only operations reading, writing and optionally
+	// resetting them.
+	Singleton *SingletonConfig `json:"singleton,omitempty"`
+	// Scope is the scope of the generated CRD, either "Namespaced" (the
+	// default) or "Cluster". Account-wide or regional singletons, like an
+	// account alias or default encryption settings, make more sense as
+	// cluster-scoped objects.
+	Scope string `json:"scope,omitempty"`
+	// APIGroup overrides the Kubernetes API group of the generated CRD. By
+	// default every CRD of a service is placed in the service's API group,
+	// e.g. "ec2.services.k8s.aws", which can be used to split a large service
+	// into several API groups:
+	//
+	// resources:
+	//
+	//	Vpc:
+	//	  api_group: vpc.ec2.services.k8s.aws
+	//
+	// CRDs in a non-default API group are generated in their own Go package
+	// under the `apis/{version}` directory, named after the first DNS label of
+	// the API group (`apis/v1alpha1/vpc` in the example above).
+	APIGroup string `json:"api_group,omitempty"`
+	// Names overrides the Kubernetes names of the generated CRD, which are
+	// otherwise derived from the resource name in the AWS API model.
+	Names *ResourceNamesConfig `json:"names,omitempty"`
+}
+
+// ResourceNamesConfig overrides the Kind, plural and singular names of a CRD
+//
+// resources:
+//
+//	DBClusterParameterGroup:
+//	  names:
+//	    kind: ClusterParameterGroup
+//	    plural: clusterparametergroups
+type ResourceNamesConfig struct {
+	// Kind is the Kind of the CRD and the name of its Go type. References to
+	// the resource from other resources use this Kind.
+	Kind string `json:"kind,omitempty"`
+	// Plural is the lowercased plural name of the CRD, used in its
+	// Kubernetes API path. Defaults to the pluralized Kind.
+	Plural string `json:"plural,omitempty"`
+	// Singular is the lowercased singular name of the CRD. Defaults to the
+	// lowercased Kind.
+	Singular string `json:"singular,omitempty"`
+}
+
+const (
+	// ResourceScopeNamespaced generates a namespaced CRD
+	ResourceScopeNamespaced = "Namespaced"
+	// ResourceScopeCluster generates a cluster-scoped CRD
+	ResourceScopeCluster = "Cluster"
+)
+
+// SingletonConfig names the SDK operations of a singleton resource, like
+// account settings or a default encryption configuration, that always exists
+// in the AWS account and region.
+//
+// The write operation is used as the resource's Replace operation: it creates
+// (the first write) and updates the resource. The resource is considered not
+// created until it was first written by the controller. When the custom
+// resource is deleted, the delete policy either calls the reset operation to
+// restore the AWS defaults or leaves the settings as they are.
+//
+// resources:
+//
+//	AccountSettings:
+//	  scope: Cluster
+//	  singleton:
+//	    read_operation: GetAccountSettings
+//	    write_operation: PutAccountSettings
+//	    reset_operation: ResetAccountSettings
+type SingletonConfig struct {
+	// ReadOperation is the name of the operation reading the settings
+	ReadOperation string `json:"read_operation"`
+	// WriteOperation is the name of the operation writing the settings
+	WriteOperation string `json:"write_operation"`
+	// ResetOperation is the optional name of the operation resetting the
+	// settings to their defaults
+	ResetOperation string `json:"reset_operation,omitempty"`
+	// DeletePolicy is either "reset", calling the reset operation when the
+	// custom resource is deleted, or "retain", leaving the settings as they
+	// are. Defaults to "reset" when a reset operation is configured and to
+	// "retain" otherwise.
+	DeletePolicy string `json:"delete_policy,omitempty"`
+}
+
+const (
+	// SingletonDeletePolicyReset resets a singleton resource to its defaults
+	// when the custom resource is deleted.
+	SingletonDeletePolicyReset = "reset"
+	// SingletonDeletePolicyRetain leaves a singleton resource as it is when
+	// the custom resource is deleted.
+	SingletonDeletePolicyRetain = "retain"
+)
+
+// GetDeletePolicy returns the delete policy of the singleton resource,
+// defaulting to "reset" if there is a reset operation and "retain" otherwise.
+func (c *SingletonConfig) GetDeletePolicy() string {
+	if c.DeletePolicy != "" {
+		return c.DeletePolicy
+	}
+	if c.ResetOperation != "" {
+		return SingletonDeletePolicyReset
+	}
+	return SingletonDeletePolicyRetain
 }
 
 // TagConfig instructs the code  generator on how to generate functions that
@@ -154,6 +326,55 @@ type TagConfig struct {
 	// tag struct. This is only used for tag fields with shape as list of struct,
 	// where the struct represents a single tag.
 	ValueMemberName *string `json:"value_name,omitempty"`
+	// SyncOperations instructs the code generator to reconcile the tag field
+	// with dedicated tagging operations of the AWS service API, rather than
+	// with the resource's Update operation.
+	SyncOperations *TagSyncOperationsConfig `json:"sync_operations,omitempty"`
+}
+
+// TagSyncOperationsConfig names the SDK operations adding tags to, removing
+// tags from and listing the tags of an AWS resource, for the many services
+// whose Create operation accepts tags but whose Update operation does not.
+//
+// When configured, the code generator emits a `syncTags` method, invoked from
+// sdkUpdate when the tag field differs, that computes the added, changed and
+// removed tags and calls the untag and tag operations. When a list operation
+// is configured, the tag field is also populated from it in sdkFind.
+//
+// resources:
+//
+//	Repository:
+//	  tags:
+//	    sync_operations:
+//	      tag_operation: TagResource
+//	      untag_operation: UntagResource
+//	      list_operation: ListTagsForResource
+type TagSyncOperationsConfig struct {
+	// TagOperation is the name of the SDK operation adding or overwriting
+	// tags of the resource, e.g. "TagResource"
+	TagOperation string `json:"tag_operation"`
+	// UntagOperation is the name of the SDK operation removing tags from the
+	// resource, e.g. "UntagResource"
+	UntagOperation string `json:"untag_operation"`
+	// ListOperation is the name of the SDK operation returning the tags of
+	// the resource, e.g. "ListTagsForResource". Optional.
+	ListOperation string `json:"list_operation,omitempty"`
+	// ARNMember is the name of the member of the operations' Input shapes
+	// receiving the resource's ARN. Defaults to "ResourceArn".
+	ARNMember string `json:"arn_member,omitempty"`
+	// TagsMember is the name of the member of the tag operation's Input shape
+	// receiving the tags. Defaults to "Tags".
+	TagsMember string `json:"tags_member,omitempty"`
+	// TagKeysMember is the name of the member of the untag operation's Input
+	// shape receiving the keys of the tags to remove. Defaults to "TagKeys".
+	TagKeysMember string `json:"tag_keys_member,omitempty"`
+	// ListTagsMember is the name of the member of the list operation's Output
+	// shape containing the tags. Defaults to "Tags".
+	ListTagsMember string `json:"list_tags_member,omitempty"`
+	// BatchSize is the maximum number of tags, or tag keys, passed to a
+	// single call of the tag or untag operation. Zero means all of them are
+	// passed in a single call.
+	BatchSize int `json:"batch_size,omitempty"`
 }
 
 // SyncedConfig instructs the code generator on how to generate functions that checks
@@ -167,22 +388,38 @@ type SyncedConfig struct {
 // SyncedCondition represent one of the unique condition that should be fulfilled in
 // order to assert whether a resource is synced.
 type SyncedCondition struct {
-	// Path of the field. e.g Status.Processing
+	// Path of the field. e.g Status.Processing. Elements of lists and maps
+	// are addressed with index, key and wildcard selectors, e.g.
+	// `Status.Endpoints[*].Status`, in which case every selected element
+	// should be equal to one of the values in `In`.
 	Path *string `json:"path"`
 	// In contains a list of possible values `Path` should be equal to.
 	In []string `json:"in"`
+	// Expr is a boolean expression over the resource's fields that should be
+	// true, used instead of `Path` and `In`, e.g.
+	// `Status.ProgressPercent == 100 && empty(Status.PendingModifiedValues)`.
+	// See pkg/expression for the syntax of expressions.
+	Expr *string `json:"expr,omitempty"`
 }
 
 // StatusCondition represents a single field condition for updateable/deletable
 // guards. It uses the same path+in pattern as SyncedCondition but is a
 // separate type to allow future divergence (e.g. requeue_after_seconds).
 type StatusCondition struct {
-	// Path of the field. e.g. Status.Status
+	// Path of the field. e.g. Status.Status. Elements of lists and maps are
+	// addressed with index, key and wildcard selectors, e.g.
+	// `Status.Endpoints[*].Status`, in which case every selected element
+	// must be IN the list.
 	Path *string `json:"path"`
 	// In contains the list of values the field must be IN for the operation
 	// to proceed. If the field value is NOT in this list, the operation is
 	// requeued.
 	In []string `json:"in"`
+	// Expr is a boolean expression over the resource's fields that must be
+	// true for the operation to proceed, used instead of `Path` and `In`,
+	// e.g. `all(Status.Replicas[*].ReplicaStatus == "ACTIVE")`. See
+	// pkg/expression for the syntax of expressions.
+	Expr *string `json:"expr,omitempty"`
 }
 
 // UpdateableConfig instructs the code generator on how to generate guard code
@@ -197,6 +434,37 @@ type UpdateableConfig struct {
 	RequeueAfterSeconds *int `json:"requeue_after_seconds,omitempty"`
 }
 
+// AsyncOperationConfig instructs the code generator on how to track an
+// operation that completes asynchronously.
+type AsyncOperationConfig struct {
+	// IDPath is the path, in the output shape of the operation, of the member
+	// containing the ID of the asynchronous operation, e.g. ChangeInfo.Id
+	IDPath string `json:"id_path"`
+	// StatusField is the name of the Status field of the resource in which
+	// the operation ID is stored until the operation completes. The field
+	// must be a string field, typically declared with `is_read_only: true`
+	// and `type: string`.
+	StatusField string `json:"status_field"`
+	// StatusOperation is the name of the API operation polled for the status
+	// of the asynchronous operation, e.g. GetChange
+	StatusOperation string `json:"status_operation"`
+	// StatusIDMember is the name of the member of the input shape of the
+	// StatusOperation that receives the operation ID, e.g. Id
+	StatusIDMember string `json:"status_id_member"`
+	// StatusPath is the path, in the output shape of the StatusOperation, of
+	// the member containing the status of the operation, e.g.
+	// ChangeInfo.Status
+	StatusPath string `json:"status_path"`
+	// CompletedWhen is the list of values of the status of the operation
+	// indicating that the operation has completed successfully.
+	CompletedWhen []string `json:"completed_when"`
+	// FailedWhen is the list of values of the status of the operation
+	// indicating that the operation has failed. The ID of a failed operation
+	// is cleared from the Status and the resource gets a Terminal condition
+	// until the next reconciliation, which attempts the operation again.
+	FailedWhen []string `json:"failed_when,omitempty"`
+}
+
 // DeletableConfig instructs the code generator on how to generate guard code
 // that checks whether a resource can be deleted based on its current status.
 type DeletableConfig struct {
@@ -245,8 +513,16 @@ type HooksConfig struct {
 // CompareConfig informs instruct the code generator on how to compare two different
 // two objects of the same type
 type CompareConfig struct {
-	// Ignore is a list of field paths to ignore when comparing two objects
+	// Ignore is a list of field paths to ignore when comparing two objects,
+	// e.g. `Spec.Tags["aws:cloudformation:stack-name"]`. The selected values
+	// are cleared in copies of both objects before the copies are compared,
+	// if Apply is true.
 	Ignore []string `json:"ignore"`
+	// Apply makes the generated comparison clear the Ignore field paths.
+	// Earlier versions of the code generator validated but never applied the
+	// Ignore field paths, so applying them is opt-in, leaving the deltas of
+	// existing generator configs unchanged.
+	Apply bool `json:"apply,omitempty"`
 }
 
 // UnpackAttributesMapConfig informs the code generator that the API follows a
@@ -322,12 +598,25 @@ type MemberConstructorConfig struct {
 // information about the HTTP status codes a particular exception has (or, like
 // the EC2 API, where the API model has no information at all about error
 // responses for any operation)
+//
+// When inference is enabled with the service-wide `infer_exception_codes` or
+// with InferCodes, the terminal and retryable exception codes are inferred
+// from the error traits of the resource's operations' error shapes in the API
+// model, and TerminalCodes and RetryableCodes, when set, replace the inferred
+// codes.
 type ExceptionsConfig struct {
 	// Errors is a map of HTTP status code to information about the Exception
 	// that corresponds to that HTTP status code for this resource
 	Errors map[int]ErrorConfig `json:"errors"`
 	// Set of aws exception codes that are terminal exceptions for this resource
 	TerminalCodes []string `json:"terminal_codes"`
+	// Set of aws exception codes that are retryable exceptions for this
+	// resource. The resource is requeued with backoff on these exceptions.
+	RetryableCodes []string `json:"retryable_codes,omitempty"`
+	// InferCodes indicates whether the terminal and retryable exception codes
+	// are inferred from the API model when they are not set explicitly.
+	// Defaults to the service-wide `infer_exception_codes`.
+	InferCodes *bool `json:"infer_codes,omitempty"`
 }
 
 // ErrorConfig contains instructions to the code generator about the exception
@@ -400,6 +689,50 @@ type UpdateOperationConfig struct {
 	// field had changed or not before setting it's value to that returned by the Update AWS API's
 	// response. Defaults to false.
 	OnlySetChangedFields bool `json:"only_set_unchanged_fields"`
+	// Operations is a list of SDK operations that together update the
+	// resource, along with the Spec field paths each of them owns. When set,
+	// the generated sdkUpdate invokes, in the configured order, only the
+	// operations that own a field path that differs in the delta. The input
+	// of each operation is built from the resource, and the resource is set
+	// from the output of each operation, the same way as for the resource's
+	// Update operation. Every Spec field must be owned by an operation unless
+	// it is immutable, ignored in comparisons, or synced by other means.
+	//
+	// An error returned by an operation stops the update: the resource, set
+	// from the outputs of the operations that succeeded, is returned along
+	// with the error, and the remaining operations are not invoked.
+	// Operations are not retried individually. The next reconciliation
+	// invokes again every operation owning a field path that still differs
+	// from the latest observed state of the resource.
+	//
+	// Each operation has its own hook points, named after the operation, e.g.
+	// `sdk_update_put_bucket_policy_pre_build_request`,
+	// `sdk_update_put_bucket_policy_post_build_request` and
+	// `sdk_update_put_bucket_policy_post_request`.
+	//
+	// resources:
+	//
+	//	Repository:
+	//	  update_operation:
+	//	    operations:
+	//	      - name: PutImageTagMutability
+	//	        fields:
+	//	          - ImageTagMutability
+	//	      - name: PutImageScanningConfiguration
+	//	        fields:
+	//	          - ImageScanningConfiguration
+	Operations []*UpdateSDKOperationConfig `json:"operations,omitempty"`
+}
+
+// UpdateSDKOperationConfig describes one of the SDK operations used to update
+// a resource and the Spec fields it is responsible for.
+type UpdateSDKOperationConfig struct {
+	// Name is the name of the SDK operation, e.g. "PutBucketPolicy"
+	Name string `json:"name"`
+	// Fields is the list of Spec field paths updated by the operation. The
+	// operation is only invoked when the delta contains a difference at, or
+	// below, one of these paths.
+	Fields []string `json:"fields"`
 }
 
 // ReadOperationsConfig contains instructions for the code generator to handle
@@ -418,6 +751,61 @@ type DeleteOperationsConfig struct {
 	// CustomMethodName is a string for the method name to replace the
 	// sdkDelete() method implementation for this resource
 	CustomMethodName string `json:"custom_method_name"`
+	// PreDeleteUpdates is a map, keyed by the name of a top-level Spec field,
+	// of values set through the update operation of the resource before the
+	// Delete operation is issued, e.g. to disable deletion protection. Values
+	// are interpreted according to the type of the field.
+	//
+	// After the update, the resource is requeued, and the Delete operation is
+	// only issued once the resource is read with all the values applied. The
+	// fields must therefore be set from the output of the read operation.
+	//
+	// Because the updates may lift a protection the user relies on, they are
+	// only made when the resource has the
+	// `services.k8s.aws/allow-pre-delete-updates: "true"` annotation.
+	//
+	// ```
+	// delete_operation:
+	//   pre_delete_updates:
+	//     DeletionProtection: false
+	// ```
+	PreDeleteUpdates map[string]string `json:"pre_delete_updates,omitempty"`
+	// InputFields is a map, keyed by the name of a member of the Delete
+	// operation's Input shape, of instructions for how to set members that
+	// are not fields of the resource, e.g. `SkipFinalSnapshot`. Each member
+	// is set from the first of its annotation, Spec field and static value
+	// that is present on the resource.
+	//
+	// ```
+	// delete_operation:
+	//   input_fields:
+	//     SkipFinalSnapshot:
+	//       annotation: rds.services.k8s.aws/skip-final-snapshot
+	//       value: "false"
+	//     FinalDBSnapshotIdentifier:
+	//       annotation: rds.services.k8s.aws/final-snapshot-identifier
+	//       value: "{{ .Name }}-final-{{ .Timestamp }}"
+	// ```
+	InputFields map[string]*DeleteInputFieldConfig `json:"input_fields,omitempty"`
+}
+
+// DeleteInputFieldConfig instructs the code generator how to set a member of
+// the Delete operation's Input shape. Sources are tried in the order
+// Annotation, SpecField, Value and the member is left unset if none of them
+// is present.
+type DeleteInputFieldConfig struct {
+	// Annotation is the key of an annotation of the resource whose value,
+	// parsed according to the type of the member, sets the member.
+	Annotation string `json:"annotation,omitempty"`
+	// SpecField is the name of a top-level Spec field whose value sets the
+	// member.
+	SpecField string `json:"spec_field,omitempty"`
+	// Value is the static value of the member. For string members, it is a
+	// Go template that may refer to the `.Name` and `.Namespace` of the
+	// resource and to the `.Timestamp` of the deletion, the resource's
+	// DeletionTimestamp formatted as `20060102150405`, which stays the same
+	// when the Delete operation is retried.
+	Value string `json:"value,omitempty"`
 }
 
 // AdditionalColumnConfig can be used to specify additional printer columns to be included
@@ -427,6 +815,10 @@ type AdditionalColumnConfig struct {
 	Name string `json:"name"`
 	// JSONPath defines the source of the output.
 	JSONPath string `json:"json_path"`
+	// Path is the field path of the source of the output, e.g.
+	// `Status.Endpoints[*].Address`, used instead of JSONPath. The JSONPath
+	// of the column is derived from it using the JSON names of the fields.
+	Path string `json:"path,omitempty"`
 	// Type is the OpenAPI type of the output.
 	// c.f., https://github.com/OAI/OpenAPI-Specification/blob/master/versions/2.0.md#data-types
 	Type string `json:"type"`
@@ -472,6 +864,76 @@ type ReconcileConfig struct {
 	// causing ACK controllers to refresh the status views of all watched resources, but this
 	// behaviour is expensive and may be turned off in future ACK runtime options.
 	RequeueOnSuccessSeconds int `json:"requeue_on_success_seconds,omitempty"`
+	// RequeueWhen is a list of rules mapping the values of a resource's
+	// fields, typically the transitional statuses of long-running AWS
+	// operations, to the delay after which to requeue the resource. Once the
+	// resource has been created or updated, a resource matching a rule is
+	// marked as not synced and requeued after the delay of the first matching
+	// rule. Rules with a maximum delay back off from the time at which the
+	// resource started matching the rules, which is kept in an annotation.
+	//
+	// ```
+	// reconcile:
+	//   requeue_when:
+	//     - path: Status.Status
+	//       in: [creating]
+	//       requeue_after_seconds: 60
+	//       max_requeue_after_seconds: 600
+	//     - path: Status.Status
+	//       in: [modifying]
+	//       requeue_after_seconds: 10
+	// ```
+	RequeueWhen []RequeueRule `json:"requeue_when,omitempty"`
+	// ReadAfterCreateGraceSeconds is the number of seconds after a successful
+	// Create during which a resource that cannot be found is requeued instead
+	// of being created again. This is useful for APIs that are eventually
+	// consistent and return NotFound from their Describe operation for a
+	// little while after the resource has been created, which would otherwise
+	// cause ACK to attempt a second Create.
+	//
+	// Right after the Create, the ACK runtime itself retries the read
+	// operation while it returns NotFound. If the resource is still not
+	// visible when the runtime gives up, the next reconciliation finds the
+	// time of creation in ReadAfterCreateGraceStatusField, and the resource
+	// manager's sdkCreate requeues the resource instead of calling the Create
+	// operation until the resource becomes visible or the window expires.
+	//
+	// ```
+	// reconcile:
+	//   read_after_create_grace_seconds: 30
+	//   read_after_create_grace_status_field: CreatedAt
+	//   read_after_create_grace_error_codes:
+	//     - InvalidParameterValue
+	// ```
+	ReadAfterCreateGraceSeconds int `json:"read_after_create_grace_seconds,omitempty"`
+	// ReadAfterCreateGraceStatusField is the name of the Status field in which
+	// the time of creation is stored. The field must be a string field,
+	// typically declared with `is_read_only: true` and `type: string`. Unlike
+	// annotations, the Status is saved by the ACK runtime even when the
+	// resource cannot be read back after the Create. Required along with
+	// ReadAfterCreateGraceSeconds.
+	ReadAfterCreateGraceStatusField string `json:"read_after_create_grace_status_field,omitempty"`
+	// ReadAfterCreateGraceErrorCodes is a list of additional AWS error codes
+	// returned by the read operation that are treated like NotFound during
+	// the ReadAfterCreateGraceSeconds window.
+	ReadAfterCreateGraceErrorCodes []string `json:"read_after_create_grace_error_codes,omitempty"`
+}
+
+// RequeueRule maps the values of a field of a resource to the delay after which
+// to requeue the resource.
+type RequeueRule struct {
+	// Path of the field, e.g. Status.Status, matched against In the same way
+	// as SyncedCondition.Path.
+	Path *string `json:"path"`
+	// In contains the list of values of the field for which the rule applies.
+	In []string `json:"in"`
+	// RequeueAfterSeconds is the delay in seconds before the requeue.
+	RequeueAfterSeconds int `json:"requeue_after_seconds"`
+	// MaxRequeueAfterSeconds enables exponential backoff when set: the delay
+	// grows from RequeueAfterSeconds with the time elapsed since the resource
+	// started matching the rules, doubling the interval between successive
+	// requeues, up to MaxRequeueAfterSeconds.
+	MaxRequeueAfterSeconds int `json:"max_requeue_after_seconds,omitempty"`
 }
 
 // PreDeleteSyncConfig contains instructions for the code generator about
@@ -497,7 +959,8 @@ func (c *Config) ResourceIsIgnored(resourceName string) bool {
 }
 
 // ResourceIsAdoptable returns true if resource name is configured to be adoptable.
-// Default behavior is every resource can be adopted using AdoptionReconciler
+// Default behavior is every resource except observe-only resources can be
+// adopted using AdoptionReconciler
 func (c *Config) ResourceIsAdoptable(resourceName string) bool {
 	if c == nil {
 		return true
@@ -507,11 +970,148 @@ func (c *Config) ResourceIsAdoptable(resourceName string) bool {
 		return true
 	}
 	if rConfig.IsAdoptable == nil {
-		return true
+		return !rConfig.ObserveOnly
 	}
 	return *rConfig.IsAdoptable
 }
 
+// PutOperationIsReplace returns true if the resource's `Put*` operation is
+// configured to both create and update the resource
+func (c *Config) PutOperationIsReplace(resourceName string) bool {
+	if c == nil {
+		return false
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return false
+	}
+	return rConfig.PutOperationIsReplace
+}
+
+// ResourceIsObserveOnly returns true if the resource is configured to be
+// observe-only, meaning the controller never creates, updates or deletes the
+// AWS resource
+func (c *Config) ResourceIsObserveOnly(resourceName string) bool {
+	if c == nil {
+		return false
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return false
+	}
+	return rConfig.ObserveOnly
+}
+
+// ResourceIsClusterScoped returns true if the resource is configured to have
+// a cluster-scoped CRD
+func (c *Config) ResourceIsClusterScoped(resourceName string) bool {
+	if c == nil {
+		return false
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return false
+	}
+	return rConfig.Scope == ResourceScopeCluster
+}
+
+// GetResourceAPIGroup returns the API group override of the resource, or an
+// empty string if the resource is placed in the service's API group
+func (c *Config) GetResourceAPIGroup(resourceName string) string {
+	if c == nil {
+		return ""
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return ""
+	}
+	return rConfig.APIGroup
+}
+
+// GetResourceNamesConfig returns the ResourceNamesConfig of the resource, or
+// nil if the resource's names are not overridden
+func (c *Config) GetResourceNamesConfig(resourceName string) *ResourceNamesConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return nil
+	}
+	return rConfig.Names
+}
+
+// GetResourceNameForKind returns the name of the resource whose CRD has the
+// supplied Kind, or an empty string if no resource is configured for it.
+// Resource configs are keyed by the resource name in the AWS API model (e.g.
+// "Api") while Kinds are normalized (e.g. "API") or overridden with
+// `names.kind`, so names without a Kind override are compared
+// case-insensitively.
+func (c *Config) GetResourceNameForKind(kind string) string {
+	if c == nil {
+		return ""
+	}
+	for resName, rConfig := range c.Resources {
+		if rConfig.Names != nil && rConfig.Names.Kind == kind {
+			return resName
+		}
+	}
+	for resName, rConfig := range c.Resources {
+		if rConfig.Names != nil && rConfig.Names.Kind != "" {
+			continue
+		}
+		if strings.EqualFold(resName, kind) {
+			return resName
+		}
+	}
+	return ""
+}
+
+// GetSingletonConfig returns the SingletonConfig of the resource, or nil if
+// the resource is not a singleton
+func (c *Config) GetSingletonConfig(resourceName string) *SingletonConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return nil
+	}
+	return rConfig.Singleton
+}
+
+// GetSingletonResourceNames returns the sorted names of the resources that
+// are configured to be singletons
+func (c *Config) GetSingletonResourceNames() []string {
+	if c == nil {
+		return nil
+	}
+	res := []string{}
+	for resName, rConfig := range c.Resources {
+		if rConfig.Singleton != nil {
+			res = append(res, resName)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// GetObserveOnlyResourceNames returns the sorted names of the resources that
+// are configured to be observe-only
+func (c *Config) GetObserveOnlyResourceNames() []string {
+	if c == nil {
+		return nil
+	}
+	res := []string{}
+	for resName, rConfig := range c.Resources {
+		if rConfig.ObserveOnly {
+			res = append(res, resName)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
 // ResourceContainsAttributesMap returns true if the underlying API has
 // Get{Resource}Attributes/Set{Resource}Attributes API calls that map real,
 // schema'd fields to a raw `map[string]*string` for a given resource name (see SNS and
@@ -598,8 +1198,64 @@ func (c *Config) GetResourceConfig(resourceName string) *ResourceConfig {
 	return nil
 }
 
+// ARNTemplateMetadataPlaceholders are the placeholders of an ARN template
+// replaced by the resource's metadata rather than by one of its fields.
+var ARNTemplateMetadataPlaceholders = []string{"partition", "region", "account"}
+
+// ParseARNTemplate splits the supplied ARN template into its literal parts,
+// at even indexes, and the names of its placeholders, at odd indexes. An error
+// is returned if a brace is not closed or nested, or if a placeholder is
+// neither a metadata placeholder nor a `Spec.` or `Status.` field path.
+func ParseARNTemplate(arnTemplate string) ([]string, error) {
+	parts := []string{}
+	rest := arnTemplate
+	for {
+		start := strings.IndexAny(rest, "{}")
+		if start == -1 {
+			return append(parts, rest), nil
+		}
+		if rest[start] == '}' {
+			return nil, fmt.Errorf("arn_template %q: unexpected '}'", arnTemplate)
+		}
+		end := strings.IndexAny(rest[start+1:], "{}")
+		if end == -1 || rest[start+1+end] == '{' {
+			return nil, fmt.Errorf("arn_template %q: unclosed '{'", arnTemplate)
+		}
+		placeholder := rest[start+1 : start+1+end]
+		if !util.InStrings(placeholder, ARNTemplateMetadataPlaceholders) &&
+			!strings.HasPrefix(placeholder, "Spec.") &&
+			!strings.HasPrefix(placeholder, "Status.") {
+			return nil, fmt.Errorf(
+				"arn_template %q: unknown placeholder %q, must be one of %s or a Spec. or Status. field path",
+				arnTemplate, placeholder,
+				strings.Join(ARNTemplateMetadataPlaceholders, ", "),
+			)
+		}
+		parts = append(parts, rest[:start], placeholder)
+		rest = rest[start+1+end+1:]
+	}
+}
+
+// GetAsyncOperation returns the instructions for tracking the asynchronous
+// operation of the supplied type (Create, Update or Delete) of the custom
+// resource, if specified in generator config.
+func (c *Config) GetAsyncOperation(
+	resourceName string,
+	opType string,
+) *AsyncOperationConfig {
+	if c == nil {
+		return nil
+	}
+	resGenConfig, found := c.Resources[resourceName]
+	if !found {
+		return nil
+	}
+	return resGenConfig.AsyncOperations[opType]
+}
+
 // GetCompareIgnoredFieldPaths returns the list of field paths to ignore when
-// comparing two different objects
+// comparing two different objects, or nil if the resource's `compare.apply` is
+// not set
 func (c *Config) GetCompareIgnoredFieldPaths(resourceName string) []string {
 	if c == nil {
 		return nil
@@ -608,7 +1264,7 @@ func (c *Config) GetCompareIgnoredFieldPaths(resourceName string) []string {
 	if !ok {
 		return nil
 	}
-	if rConfig.Compare == nil {
+	if rConfig.Compare == nil || !rConfig.Compare.Apply {
 		return nil
 	}
 	return rConfig.Compare.Ignore
@@ -739,6 +1395,62 @@ func (c *Config) GetReconcileRequeueOnSuccessSeconds(resourceName string) int {
 	return 0
 }
 
+// GetReconcileRequeueWhen returns the rules mapping the values of the custom
+// resource's fields to the delay after which to requeue it, if specified in
+// generator config.
+func (c *Config) GetReconcileRequeueWhen(resourceName string) []RequeueRule {
+	if c == nil {
+		return nil
+	}
+	resGenConfig, found := c.Resources[resourceName]
+	if !found || resGenConfig.Reconcile == nil {
+		return nil
+	}
+	return resGenConfig.Reconcile.RequeueWhen
+}
+
+// GetReconcileReadAfterCreateGraceSeconds returns the number of seconds after
+// creation during which a custom resource that cannot be found is requeued
+// instead of being created again, if specified in generator config.
+func (c *Config) GetReconcileReadAfterCreateGraceSeconds(resourceName string) int {
+	if c == nil {
+		return 0
+	}
+	resGenConfig, found := c.Resources[resourceName]
+	if !found || resGenConfig.Reconcile == nil {
+		return 0
+	}
+	return resGenConfig.Reconcile.ReadAfterCreateGraceSeconds
+}
+
+// GetReconcileReadAfterCreateGraceStatusField returns the name of the Status
+// field storing the time of creation of the custom resource, if specified in
+// generator config.
+func (c *Config) GetReconcileReadAfterCreateGraceStatusField(resourceName string) string {
+	if c == nil {
+		return ""
+	}
+	resGenConfig, found := c.Resources[resourceName]
+	if !found || resGenConfig.Reconcile == nil {
+		return ""
+	}
+	return resGenConfig.Reconcile.ReadAfterCreateGraceStatusField
+}
+
+// GetReconcileReadAfterCreateGraceErrorCodes returns the additional error codes
+// treated like NotFound during the read-after-create grace window of the
+// custom resource, if specified in generator config.
+func (c *Config) GetReconcileReadAfterCreateGraceErrorCodes(resourceName string) []string {
+	if c == nil {
+		return nil
+	}
+	resGenConfig, found := c.Resources[resourceName]
+	if !found || resGenConfig.Reconcile == nil {
+		return nil
+	}
+	return resGenConfig.Reconcile.ReadAfterCreateGraceErrorCodes
+}
+
 // GetCustomUpdateMethodName returns the name of the custom resourceManager method
 // for updating the resource state, if any has been specified in the generator
 // config
@@ -755,6 +1467,21 @@ func (c *Config) GetCustomUpdateMethodName(resourceName string) string {
 	return ""
 }
 
+// GetUpdateOperations returns the list of SDK operations used to update the
+// resource, if any has been specified in the generator config
+func (c *Config) GetUpdateOperations(resourceName string) []*UpdateSDKOperationConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resourceName]
+	if found {
+		if rConfig.UpdateOperation != nil {
+			return rConfig.UpdateOperation.Operations
+		}
+	}
+	return nil
+}
+
 func (c *Config) GetCustomFindMethodName(resourceName string) string {
 	if c == nil {
 		return ""
@@ -781,6 +1508,34 @@ func (c *Config) GetCustomDeleteMethodName(resourceName string) string {
 	return ""
 }
 
+// GetPreDeleteUpdates returns the values, keyed by Spec field name, set
+// through the update operation of the custom resource before it is deleted,
+// if specified in generator config.
+func (c *Config) GetPreDeleteUpdates(resourceName string) map[string]string {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resourceName]
+	if !found || rConfig.DeleteOperation == nil {
+		return nil
+	}
+	return rConfig.DeleteOperation.PreDeleteUpdates
+}
+
+// GetDeleteInputFields returns the instructions for setting the members of
+// the Delete operation's Input shape of the resource with the supplied name,
+// keyed by member name, or nil if there are none.
+func (c *Config) GetDeleteInputFields(resourceName string) map[string]*DeleteInputFieldConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resourceName]
+	if !found || rConfig.DeleteOperation == nil {
+		return nil
+	}
+	return rConfig.DeleteOperation.InputFields
+}
+
 // GetAllRenames returns all of the CRD's field renames observed in the generator config
 // for a given map of operations.
 func (c *Config) GetAllRenames(
@@ -829,6 +1584,34 @@ func (c *Config) GetTerminalExceptionCodes(resourceName string) []string {
 	return nil
 }
 
+// GetRetryableExceptionCodes returns retryable exception codes as
+// []string for custom resource, if specified in generator config
+func (c *Config) GetRetryableExceptionCodes(resourceName string) []string {
+	if c == nil {
+		return nil
+	}
+	resGenConfig, found := c.Resources[resourceName]
+	if found && resGenConfig.Exceptions != nil {
+		return resGenConfig.Exceptions.RetryableCodes
+	}
+	return nil
+}
+
+// ExceptionCodesAreInferred returns true if the terminal and retryable
+// exception codes of the custom resource are inferred from the API model when
+// they are not specified in generator config
+func (c *Config) ExceptionCodesAreInferred(resourceName string) bool {
+	if c == nil {
+		return false
+	}
+	resGenConfig, found := c.Resources[resourceName]
+	if found && resGenConfig.Exceptions != nil &&
+		resGenConfig.Exceptions.InferCodes != nil {
+		return *resGenConfig.Exceptions.InferCodes
+	}
+	return c.InferExceptionCodes
+}
+
 // GetListOpMatchFieldNames returns a slice of strings representing the field
 // names in the List operation's Output shape's element Shape that we should
 // check a corresponding value in the target Spec exists.
@@ -850,9 +1633,13 @@ func (c *Config) GetListOpMatchFieldNames(
 }
 
 // TagsAreIgnored returns whether ensuring controller tags should be ignored
-// for a resource or not.
+// for a resource or not. Tags are always ignored for observe-only resources,
+// since the controller never writes to them.
 func (c *Config) TagsAreIgnored(resName string) bool {
 	if rConfig, found := c.Resources[resName]; found {
+		if rConfig.ObserveOnly {
+			return true
+		}
 		if tagConfig := rConfig.TagConfig; tagConfig != nil {
 			return tagConfig.Ignore
 		}
@@ -15,8 +15,14 @@ package config
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
+	"text/template"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/expression"
+	"github.com/aws-controllers-k8s/code-generator/pkg/fieldpath"
+	"github.com/aws-controllers-k8s/code-generator/pkg/util"
 )
 
 // ValidateConfig checks that generator.yaml references to SDK operations are
@@ -25,6 +31,37 @@ import (
 // Validates:
 //   - Operation names in resources[R].renames.operations (must exist in SDK)
 //   - Operation names in ignore.operations (must exist in SDK)
+//   - Operation names in resources[R].update_operation.operations (must exist
+//     in SDK and own at least one field)
+//   - Operation names in resources[R].fields[F].child_collection (must exist
+//     in SDK)
+//   - Operation names in resources[R].tags.sync_operations (must exist in SDK)
+//   - resources[R].observe_only is not combined with write instructions
+//   - Operation names and delete policy in resources[R].singleton (must
+//     exist in SDK)
+//   - resources[R].scope
+//   - resources[R].api_group and resources[R].names
+//   - references of field configs to Kubernetes objects
+//   - compare.list_semantics and compare.key_member of field configs
+//   - compare.normalize names and arguments of field configs
+//   - Field paths in resources[R].synced.when, resources[R].updateable.when,
+//     resources[R].deletable.when, resources[R].compare.ignore and
+//     resources[R].print.additional_columns
+//   - Syntax of the expr of the conditions in resources[R].synced.when,
+//     resources[R].updateable.when and resources[R].deletable.when, which
+//     cannot be combined with path and in
+//   - resources[R].reconcile.requeue_when rules (path, in and delays)
+//   - resources[R].reconcile.read_after_create_grace_seconds and
+//     resources[R].reconcile.read_after_create_grace_error_codes
+//   - resources[R].async_operations operation types, required keys and
+//     status operations
+//   - resources[R].delete_operation.pre_delete_updates, which cannot be
+//     combined with a custom delete method
+//   - resources[R].delete_operation.input_fields sources and value
+//     templates, which cannot be combined with a custom delete method
+//   - Positions of the fields of composite primary keys and
+//     resources[R].primary_key_separator
+//   - Syntax and placeholders of resources[R].arn_template
 //
 // Does NOT validate:
 //   - Resource names: controllers define resources with custom names
@@ -44,10 +81,139 @@ func ValidateConfig(
 
 	errs = append(errs, validateRenameOperations(cfg, sdkOperations)...)
 	errs = append(errs, validateIgnoredOperations(cfg, sdkOperations)...)
+	errs = append(errs, validateUpdateOperations(cfg, sdkOperations)...)
+	errs = append(errs, validateChildCollectionOperations(cfg, sdkOperations)...)
+	errs = append(errs, validateTagSyncOperations(cfg, sdkOperations)...)
+	errs = append(errs, validateObserveOnly(cfg)...)
+	errs = append(errs, validateSingletonOperations(cfg, sdkOperations)...)
+	errs = append(errs, validateResourceScopes(cfg)...)
+	errs = append(errs, validateResourceAPIGroupsAndNames(cfg)...)
+	errs = append(errs, validateKubernetesObjectReferences(cfg)...)
+	errs = append(errs, validateCompareListSemantics(cfg)...)
+	errs = append(errs, validateCompareNormalizers(cfg)...)
+	errs = append(errs, validateFieldPaths(cfg)...)
+	errs = append(errs, validateConditionExprs(cfg)...)
+	errs = append(errs, validateRequeueRules(cfg)...)
+	errs = append(errs, validateReadAfterCreateGrace(cfg)...)
+	errs = append(errs, validateAsyncOperations(cfg, sdkOperations)...)
+	errs = append(errs, validatePreDeleteUpdates(cfg)...)
+	errs = append(errs, validateDeleteInputFields(cfg)...)
+	errs = append(errs, validateCompositePrimaryKeys(cfg)...)
+	errs = append(errs, validateARNTemplates(cfg)...)
+
+	return errs
+}
+
+// validateCompareListSemantics checks that compare.list_semantics is one of
+// the supported values and that compare.key_member is set if, and only if,
+// the list semantics are "keyed".
+func validateCompareListSemantics(cfg *Config) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		resCfg := cfg.Resources[resName]
+		for _, fieldPath := range sortedFieldConfigPaths(resCfg.Fields) {
+			compareCfg := resCfg.Fields[fieldPath].Compare
+			if compareCfg == nil {
+				continue
+			}
+			switch compareCfg.ListSemantics {
+			case "", ListSemanticsOrdered, ListSemanticsSet:
+				if compareCfg.KeyMember != "" {
+					errs = append(errs, fmt.Errorf(
+						"resources.%s.fields.%s.compare.key_member: only supported when list_semantics is %q",
+						resName, fieldPath, ListSemanticsKeyed,
+					))
+				}
+			case ListSemanticsKeyed:
+				if compareCfg.KeyMember == "" {
+					errs = append(errs, fmt.Errorf(
+						"resources.%s.fields.%s.compare.key_member: required when list_semantics is %q",
+						resName, fieldPath, ListSemanticsKeyed,
+					))
+				}
+			default:
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.fields.%s.compare.list_semantics: unknown value %q. available: %s",
+					resName, fieldPath, compareCfg.ListSemantics,
+					strings.Join([]string{ListSemanticsOrdered, ListSemanticsSet, ListSemanticsKeyed}, ", "),
+				))
+			}
+		}
+	}
+	return errs
+}
 
+// validateCompareNormalizers checks that every normalizer in
+// compare.normalize is a known built-in normalizer and that an argument is
+// supplied if, and only if, the normalizer takes one.
+func validateCompareNormalizers(cfg *Config) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		resCfg := cfg.Resources[resName]
+		for _, fieldPath := range sortedFieldConfigPaths(resCfg.Fields) {
+			compareCfg := resCfg.Fields[fieldPath].Compare
+			if compareCfg == nil {
+				continue
+			}
+			for i, normalizer := range compareCfg.Normalize {
+				switch normalizer.Name {
+				case NormalizerTrimSuffix:
+					if normalizer.Argument == nil || *normalizer.Argument == "" {
+						errs = append(errs, fmt.Errorf(
+							"resources.%s.fields.%s.compare.normalize[%d]: %q requires a non-empty argument",
+							resName, fieldPath, i, normalizer.Name,
+						))
+					}
+				case NormalizerLowercase, NormalizerTrim, NormalizerJSONCanonical,
+					NormalizerARNCanonical, NormalizerNumericString, NormalizerDuration:
+					if normalizer.Argument != nil {
+						errs = append(errs, fmt.Errorf(
+							"resources.%s.fields.%s.compare.normalize[%d]: %q does not take an argument",
+							resName, fieldPath, i, normalizer.Name,
+						))
+					}
+				default:
+					errs = append(errs, fmt.Errorf(
+						"resources.%s.fields.%s.compare.normalize[%d]: unknown normalizer %q. available: %s",
+						resName, fieldPath, i, normalizer.Name,
+						strings.Join([]string{
+							NormalizerLowercase, NormalizerTrim, NormalizerTrimSuffix,
+							NormalizerJSONCanonical, NormalizerARNCanonical,
+							NormalizerNumericString, NormalizerDuration,
+						}, ", "),
+					))
+				}
+			}
+		}
+	}
 	return errs
 }
 
+// sortedResourceNames returns the names of the configured resources in
+// deterministic order.
+func sortedResourceNames(cfg *Config) []string {
+	resNames := make([]string, 0, len(cfg.Resources))
+	for resName := range cfg.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	return resNames
+}
+
+// sortedFieldConfigPaths returns the field paths of the supplied field
+// configs in deterministic order.
+func sortedFieldConfigPaths(fields map[string]*FieldConfig) []string {
+	fieldPaths := make([]string, 0, len(fields))
+	for fieldPath, fieldCfg := range fields {
+		if fieldCfg == nil {
+			continue
+		}
+		fieldPaths = append(fieldPaths, fieldPath)
+	}
+	sort.Strings(fieldPaths)
+	return fieldPaths
+}
+
 // validateRenameOperations checks that operation names referenced in
 // resources[R].renames.operations[OpName] exist in the SDK.
 func validateRenameOperations(
@@ -89,6 +255,794 @@ func validateIgnoredOperations(
 	return errs
 }
 
+// validateUpdateOperations checks that operation names referenced in
+// resources[R].update_operation.operations exist in the SDK and that each of
+// them owns at least one field.
+func validateUpdateOperations(
+	cfg *Config,
+	sdkOperations map[string]struct{},
+) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		for i, opCfg := range cfg.GetUpdateOperations(resName) {
+			if opCfg == nil {
+				continue
+			}
+			if _, ok := sdkOperations[opCfg.Name]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.update_operation.operations[%d]: operation %q not found in SDK. available: %s",
+					resName, i, opCfg.Name, formatAvailableTruncated(sortedKeys(sdkOperations), 10),
+				))
+			}
+			if len(opCfg.Fields) == 0 {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.update_operation.operations[%d]: operation %q must list at least one field",
+					resName, i, opCfg.Name,
+				))
+			}
+		}
+	}
+	return errs
+}
+
+// validateChildCollectionOperations checks that the operation names
+// referenced in resources[R].fields[F].child_collection exist in the SDK.
+func validateChildCollectionOperations(
+	cfg *Config,
+	sdkOperations map[string]struct{},
+) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		resCfg := cfg.Resources[resName]
+		for _, fieldPath := range sortedFieldConfigPaths(resCfg.Fields) {
+			ccCfg := resCfg.Fields[fieldPath].ChildCollection
+			if ccCfg == nil {
+				continue
+			}
+			opNames := []struct {
+				key    string
+				opName string
+			}{
+				{"add_operation", ccCfg.AddOperation},
+				{"remove_operation", ccCfg.RemoveOperation},
+				{"read_operation", ccCfg.ReadOperation},
+			}
+			for _, op := range opNames {
+				if op.opName == "" {
+					if op.key != "read_operation" {
+						errs = append(errs, fmt.Errorf(
+							"resources.%s.fields.%s.child_collection.%s: required",
+							resName, fieldPath, op.key,
+						))
+					}
+					continue
+				}
+				if _, ok := sdkOperations[op.opName]; !ok {
+					errs = append(errs, fmt.Errorf(
+						"resources.%s.fields.%s.child_collection.%s: operation %q not found in SDK. available: %s",
+						resName, fieldPath, op.key, op.opName,
+						formatAvailableTruncated(sortedKeys(sdkOperations), 10),
+					))
+				}
+			}
+			if (ccCfg.ReadOperation == "") != (ccCfg.ReadOutputPath == "") {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.fields.%s.child_collection: read_operation and read_output_path must be set together",
+					resName, fieldPath,
+				))
+			}
+			if ccCfg.BatchSize < 0 {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.fields.%s.child_collection.batch_size: must not be negative",
+					resName, fieldPath,
+				))
+			}
+		}
+	}
+	return errs
+}
+
+func validateTagSyncOperations(
+	cfg *Config,
+	sdkOperations map[string]struct{},
+) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		tagCfg := cfg.Resources[resName].TagConfig
+		if tagCfg == nil || tagCfg.SyncOperations == nil {
+			continue
+		}
+		syncCfg := tagCfg.SyncOperations
+		if tagCfg.Ignore {
+			errs = append(errs, fmt.Errorf(
+				"resources.%s.tags.sync_operations: cannot be combined with tags.ignore",
+				resName,
+			))
+		}
+		opNames := []struct {
+			key    string
+			opName string
+		}{
+			{"tag_operation", syncCfg.TagOperation},
+			{"untag_operation", syncCfg.UntagOperation},
+			{"list_operation", syncCfg.ListOperation},
+		}
+		for _, op := range opNames {
+			if op.opName == "" {
+				if op.key != "list_operation" {
+					errs = append(errs, fmt.Errorf(
+						"resources.%s.tags.sync_operations.%s: required",
+						resName, op.key,
+					))
+				}
+				continue
+			}
+			if _, ok := sdkOperations[op.opName]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.tags.sync_operations.%s: operation %q not found in SDK. available: %s",
+					resName, op.key, op.opName,
+					formatAvailableTruncated(sortedKeys(sdkOperations), 10),
+				))
+			}
+		}
+		if syncCfg.BatchSize < 0 {
+			errs = append(errs, fmt.Errorf(
+				"resources.%s.tags.sync_operations.batch_size: must not be negative",
+				resName,
+			))
+		}
+	}
+	return errs
+}
+
+// validateObserveOnly checks that observe-only resources are not configured
+// with instructions that only make sense for resources the controller writes
+// to.
+func validateObserveOnly(cfg *Config) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		resCfg := cfg.Resources[resName]
+		if !resCfg.ObserveOnly {
+			continue
+		}
+		if resCfg.IsAdoptable != nil && *resCfg.IsAdoptable {
+			errs = append(errs, fmt.Errorf(
+				"resources.%s.observe_only: cannot be combined with is_adoptable",
+				resName,
+			))
+		}
+		if resCfg.UpdateOperation != nil {
+			errs = append(errs, fmt.Errorf(
+				"resources.%s.observe_only: cannot be combined with update_operation",
+				resName,
+			))
+		}
+		if resCfg.Singleton != nil {
+			errs = append(errs, fmt.Errorf(
+				"resources.%s.observe_only: cannot be combined with singleton",
+				resName,
+			))
+		}
+		if resCfg.TagConfig != nil && resCfg.TagConfig.SyncOperations != nil {
+			errs = append(errs, fmt.Errorf(
+				"resources.%s.observe_only: cannot be combined with tags.sync_operations",
+				resName,
+			))
+		}
+		for _, fieldPath := range sortedFieldConfigPaths(resCfg.Fields) {
+			if resCfg.Fields[fieldPath].ChildCollection != nil {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.observe_only: cannot be combined with fields.%s.child_collection",
+					resName, fieldPath,
+				))
+			}
+		}
+	}
+	return errs
+}
+
+// validateSingletonOperations checks that the operation names referenced in
+// resources[R].singleton exist in the SDK and that the delete policy is
+// supported.
+func validateSingletonOperations(
+	cfg *Config,
+	sdkOperations map[string]struct{},
+) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		singletonCfg := cfg.Resources[resName].Singleton
+		if singletonCfg == nil {
+			continue
+		}
+		opNames := []struct {
+			key    string
+			opName string
+		}{
+			{"read_operation", singletonCfg.ReadOperation},
+			{"write_operation", singletonCfg.WriteOperation},
+			{"reset_operation", singletonCfg.ResetOperation},
+		}
+		for _, op := range opNames {
+			if op.opName == "" {
+				if op.key != "reset_operation" {
+					errs = append(errs, fmt.Errorf(
+						"resources.%s.singleton.%s: required",
+						resName, op.key,
+					))
+				}
+				continue
+			}
+			if _, ok := sdkOperations[op.opName]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.singleton.%s: operation %q not found in SDK. available: %s",
+					resName, op.key, op.opName,
+					formatAvailableTruncated(sortedKeys(sdkOperations), 10),
+				))
+			}
+		}
+		switch singletonCfg.DeletePolicy {
+		case "", SingletonDeletePolicyRetain:
+		case SingletonDeletePolicyReset:
+			if singletonCfg.ResetOperation == "" {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.singleton.delete_policy: %q requires reset_operation",
+					resName, SingletonDeletePolicyReset,
+				))
+			}
+		default:
+			errs = append(errs, fmt.Errorf(
+				"resources.%s.singleton.delete_policy: unsupported value %q, must be %q or %q",
+				resName, singletonCfg.DeletePolicy,
+				SingletonDeletePolicyReset, SingletonDeletePolicyRetain,
+			))
+		}
+	}
+	return errs
+}
+
+// validateResourceScopes checks that resources[R].scope is one of the
+// supported CRD scopes.
+func validateResourceScopes(cfg *Config) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		switch scope := cfg.Resources[resName].Scope; scope {
+		case "", ResourceScopeNamespaced, ResourceScopeCluster:
+		default:
+			errs = append(errs, fmt.Errorf(
+				"resources.%s.scope: unsupported value %q, must be %q or %q",
+				resName, scope, ResourceScopeNamespaced, ResourceScopeCluster,
+			))
+		}
+	}
+	return errs
+}
+
+var (
+	// apiGroupRegexp matches a lowercase DNS subdomain with at least two
+	// labels, e.g. "vpc.ec2.services.k8s.aws"
+	apiGroupRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)+$`)
+	// kindRegexp matches an exported Go identifier, which a Kind is used as
+	kindRegexp = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	// lowercaseNameRegexp matches the plural and singular names of a CRD
+	lowercaseNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+)
+
+// validateResourceAPIGroupsAndNames checks that resources[R].api_group is a
+// valid API group, that API groups do not share their first DNS label (which
+// names the Go package of their types) and that resources[R].names overrides
+// are valid and unique.
+func validateResourceAPIGroupsAndNames(cfg *Config) []error {
+	var errs []error
+	apiGroupsByLabel := map[string]string{}
+	resourcesByKind := map[string]string{}
+	for _, resName := range sortedResourceNames(cfg) {
+		resCfg := cfg.Resources[resName]
+		if apiGroup := resCfg.APIGroup; apiGroup != "" {
+			label, _, _ := strings.Cut(apiGroup, ".")
+			if !apiGroupRegexp.MatchString(apiGroup) {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.api_group: %q is not a valid API group, "+
+						"must be a lowercase DNS subdomain like \"vpc.ec2.services.k8s.aws\"",
+					resName, apiGroup,
+				))
+			} else if other, ok := apiGroupsByLabel[label]; ok && other != apiGroup {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.api_group: %q and %q share the first label %q, "+
+						"which names the Go package of the API group's types",
+					resName, apiGroup, other, label,
+				))
+			} else {
+				apiGroupsByLabel[label] = apiGroup
+			}
+		}
+		namesCfg := resCfg.Names
+		if namesCfg == nil {
+			continue
+		}
+		if kind := namesCfg.Kind; kind != "" {
+			if !kindRegexp.MatchString(kind) {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.names.kind: %q is not a valid Kind, "+
+						"must be an UpperCamelCase identifier",
+					resName, kind,
+				))
+			} else if other, ok := resourcesByKind[kind]; ok {
+				errs = append(errs, fmt.Errorf(
+					"resources.%s.names.kind: Kind %q is already used by resource %q",
+					resName, kind, other,
+				))
+			} else {
+				resourcesByKind[kind] = resName
+			}
+		}
+		if plural := namesCfg.Plural; plural != "" && !lowercaseNameRegexp.MatchString(plural) {
+			errs = append(errs, fmt.Errorf(
+				"resources.%s.names.plural: %q must be lowercase alphanumeric",
+				resName, plural,
+			))
+		}
+		if singular := namesCfg.Singular; singular != "" && !lowercaseNameRegexp.MatchString(singular) {
+			errs = append(errs, fmt.Errorf(
+				"resources.%s.names.singular: %q must be lowercase alphanumeric",
+				resName, singular,
+			))
+		}
+	}
+	return errs
+}
+
+// validateKubernetesObjectReferences checks that references to Kubernetes
+// objects (with a kind) set a version and a JSONPath but no ACK resource and
+// do not target Secrets, and that references to ACK resources do not set any of the Kubernetes object
+// reference options.
+func validateKubernetesObjectReferences(cfg *Config) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		resCfg := cfg.Resources[resName]
+		for _, fieldPath := range sortedFieldConfigPaths(resCfg.Fields) {
+			refCfg := resCfg.Fields[fieldPath].References
+			if refCfg == nil {
+				continue
+			}
+			prefix := fmt.Sprintf("resources.%s.fields.%s.references", resName, fieldPath)
+			if !refCfg.IsKubernetesObjectReference() {
+				if refCfg.Group != "" || refCfg.Version != "" || refCfg.JSONPath != "" || refCfg.AllowCrossNamespace {
+					errs = append(errs, fmt.Errorf(
+						"%s: group, version, json_path and allow_cross_namespace "+
+							"require kind to be set", prefix,
+					))
+				}
+				continue
+			}
+			if refCfg.Resource != "" || refCfg.Path != "" || refCfg.ServiceName != "" {
+				errs = append(errs, fmt.Errorf(
+					"%s: kind cannot be combined with resource, path or service_name",
+					prefix,
+				))
+			}
+			if refCfg.Version == "" {
+				errs = append(errs, fmt.Errorf("%s: kind requires version to be set", prefix))
+			}
+			if refCfg.Group == "" && refCfg.Kind == "Secret" {
+				errs = append(errs, fmt.Errorf(
+					"%s: Secrets cannot be referenced because the referenced value "+
+						"is copied into the resource's Spec; use is_secret instead",
+					prefix,
+				))
+			}
+			if !strings.HasPrefix(refCfg.JSONPath, "{.") || !strings.HasSuffix(refCfg.JSONPath, "}") {
+				errs = append(errs, fmt.Errorf(
+					"%s.json_path: %q must be a JSONPath template like \"{.data.key}\"",
+					prefix, refCfg.JSONPath,
+				))
+			}
+		}
+	}
+	return errs
+}
+
+// validateFieldPaths checks the syntax of the field paths of the resource
+// conditions, compare ignore lists and printer columns, and that printer
+// columns set exactly one of path and json_path.
+func validateFieldPaths(cfg *Config) []error {
+	var errs []error
+	validatePath := func(prefix string, path string) {
+		// Empty condition paths are reported by the code generator
+		if path == "" {
+			return
+		}
+		fp, err := fieldpath.Parse(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", prefix, err))
+			return
+		}
+		if fieldpath.UsesLegacyNotation(path) {
+			util.Warnf(
+				"%s: field path %q uses the deprecated `..` notation, use %q instead\n",
+				prefix, path, fp.String(),
+			)
+		}
+	}
+	for _, resName := range sortedResourceNames(cfg) {
+		resCfg := cfg.Resources[resName]
+		if resCfg.Synced != nil {
+			for i, cond := range resCfg.Synced.When {
+				if cond.Path != nil {
+					validatePath(fmt.Sprintf("resources.%s.synced.when[%d].path", resName, i), *cond.Path)
+				}
+			}
+		}
+		if resCfg.Updateable != nil {
+			for i, cond := range resCfg.Updateable.When {
+				if cond.Path != nil {
+					validatePath(fmt.Sprintf("resources.%s.updateable.when[%d].path", resName, i), *cond.Path)
+				}
+			}
+		}
+		if resCfg.Deletable != nil {
+			for i, cond := range resCfg.Deletable.When {
+				if cond.Path != nil {
+					validatePath(fmt.Sprintf("resources.%s.deletable.when[%d].path", resName, i), *cond.Path)
+				}
+			}
+		}
+		if resCfg.Compare != nil {
+			for i, path := range resCfg.Compare.Ignore {
+				validatePath(fmt.Sprintf("resources.%s.compare.ignore[%d]", resName, i), path)
+			}
+		}
+		if resCfg.Print != nil {
+			for i, col := range resCfg.Print.AdditionalColumns {
+				prefix := fmt.Sprintf("resources.%s.print.additional_columns[%d]", resName, i)
+				if (col.Path == "") == (col.JSONPath == "") {
+					errs = append(errs, fmt.Errorf("%s: exactly one of path and json_path must be set", prefix))
+					continue
+				}
+				if col.Path != "" {
+					validatePath(prefix+".path", col.Path)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// validateConditionExprs checks the syntax of the expr of the synced,
+// updateable and deletable conditions and that it is not combined with a path
+// and a list of values. Expressions are type-checked against the fields of
+// the resource by the code generator.
+func validateConditionExprs(cfg *Config) []error {
+	var errs []error
+	validateCondition := func(prefix string, path *string, in []string, expr *string) {
+		if expr == nil {
+			return
+		}
+		if path != nil || len(in) > 0 {
+			errs = append(errs, fmt.Errorf("%s: expr cannot be combined with path and in", prefix))
+		}
+		if _, err := expression.Parse(*expr); err != nil {
+			errs = append(errs, fmt.Errorf("%s.expr: %w", prefix, err))
+		}
+	}
+	for _, resName := range sortedResourceNames(cfg) {
+		resCfg := cfg.Resources[resName]
+		if resCfg.Synced != nil {
+			for i, cond := range resCfg.Synced.When {
+				prefix := fmt.Sprintf("resources.%s.synced.when[%d]", resName, i)
+				validateCondition(prefix, cond.Path, cond.In, cond.Expr)
+			}
+		}
+		if resCfg.Updateable != nil {
+			for i, cond := range resCfg.Updateable.When {
+				prefix := fmt.Sprintf("resources.%s.updateable.when[%d]", resName, i)
+				validateCondition(prefix, cond.Path, cond.In, cond.Expr)
+			}
+		}
+		if resCfg.Deletable != nil {
+			for i, cond := range resCfg.Deletable.When {
+				prefix := fmt.Sprintf("resources.%s.deletable.when[%d]", resName, i)
+				validateCondition(prefix, cond.Path, cond.In, cond.Expr)
+			}
+		}
+	}
+	return errs
+}
+
+// validateRequeueRules checks that the reconcile.requeue_when rules have a
+// valid field path, at least one value and a positive delay, and that their
+// maximum delay, if any, is not lower than their delay.
+func validateRequeueRules(cfg *Config) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		resCfg := cfg.Resources[resName]
+		if resCfg.Reconcile == nil {
+			continue
+		}
+		for i, rule := range resCfg.Reconcile.RequeueWhen {
+			prefix := fmt.Sprintf("resources.%s.reconcile.requeue_when[%d]", resName, i)
+			if rule.Path == nil || *rule.Path == "" {
+				errs = append(errs, fmt.Errorf("%s: path must be set", prefix))
+			} else if _, err := fieldpath.Parse(*rule.Path); err != nil {
+				errs = append(errs, fmt.Errorf("%s.path: %w", prefix, err))
+			}
+			if len(rule.In) == 0 {
+				errs = append(errs, fmt.Errorf("%s: in must contain at least one value", prefix))
+			}
+			if rule.RequeueAfterSeconds <= 0 {
+				errs = append(errs, fmt.Errorf(
+					"%s: requeue_after_seconds must be positive, got %d",
+					prefix, rule.RequeueAfterSeconds,
+				))
+			}
+			if rule.MaxRequeueAfterSeconds != 0 && rule.MaxRequeueAfterSeconds < rule.RequeueAfterSeconds {
+				errs = append(errs, fmt.Errorf(
+					"%s: max_requeue_after_seconds (%d) must not be lower than requeue_after_seconds (%d)",
+					prefix, rule.MaxRequeueAfterSeconds, rule.RequeueAfterSeconds,
+				))
+			}
+		}
+	}
+	return errs
+}
+
+// validateReadAfterCreateGrace checks that the read-after-create grace window
+// of a resource is not negative, that it names the Status field storing the
+// time of creation, and that the grace Status field and error codes are only
+// set along with a grace window.
+func validateReadAfterCreateGrace(cfg *Config) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		resCfg := cfg.Resources[resName]
+		if resCfg.Reconcile == nil {
+			continue
+		}
+		prefix := fmt.Sprintf("resources.%s.reconcile", resName)
+		graceSeconds := resCfg.Reconcile.ReadAfterCreateGraceSeconds
+		if graceSeconds < 0 {
+			errs = append(errs, fmt.Errorf(
+				"%s.read_after_create_grace_seconds: must not be negative, got %d",
+				prefix, graceSeconds,
+			))
+		}
+		statusField := resCfg.Reconcile.ReadAfterCreateGraceStatusField
+		if graceSeconds > 0 && statusField == "" {
+			errs = append(errs, fmt.Errorf(
+				"%s.read_after_create_grace_status_field: required along with read_after_create_grace_seconds",
+				prefix,
+			))
+		}
+		if statusField != "" && graceSeconds <= 0 {
+			errs = append(errs, fmt.Errorf(
+				"%s.read_after_create_grace_status_field: requires read_after_create_grace_seconds",
+				prefix,
+			))
+		}
+		if len(resCfg.Reconcile.ReadAfterCreateGraceErrorCodes) > 0 && graceSeconds <= 0 {
+			errs = append(errs, fmt.Errorf(
+				"%s.read_after_create_grace_error_codes: requires read_after_create_grace_seconds",
+				prefix,
+			))
+		}
+	}
+	return errs
+}
+
+// validateAsyncOperations checks that resources[R].async_operations are keyed
+// by Create, Update or Delete, set all their required keys and poll a status
+// operation that exists in the SDK.
+func validateAsyncOperations(
+	cfg *Config,
+	sdkOperations map[string]struct{},
+) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		asyncOps := cfg.Resources[resName].AsyncOperations
+		opTypes := make([]string, 0, len(asyncOps))
+		for opType := range asyncOps {
+			opTypes = append(opTypes, opType)
+		}
+		sort.Strings(opTypes)
+		for _, opType := range opTypes {
+			prefix := fmt.Sprintf("resources.%s.async_operations.%s", resName, opType)
+			switch opType {
+			case "Create", "Update", "Delete":
+			default:
+				errs = append(errs, fmt.Errorf(
+					"%s: unknown operation type. available: Create, Update, Delete",
+					prefix,
+				))
+				continue
+			}
+			asyncOp := asyncOps[opType]
+			if asyncOp == nil {
+				errs = append(errs, fmt.Errorf("%s: must not be empty", prefix))
+				continue
+			}
+			required := []struct {
+				key   string
+				value string
+			}{
+				{"id_path", asyncOp.IDPath},
+				{"status_field", asyncOp.StatusField},
+				{"status_operation", asyncOp.StatusOperation},
+				{"status_id_member", asyncOp.StatusIDMember},
+				{"status_path", asyncOp.StatusPath},
+			}
+			for _, req := range required {
+				if req.value == "" {
+					errs = append(errs, fmt.Errorf("%s.%s: required", prefix, req.key))
+				}
+			}
+			if len(asyncOp.CompletedWhen) == 0 {
+				errs = append(errs, fmt.Errorf(
+					"%s.completed_when: must contain at least one value", prefix,
+				))
+			}
+			if asyncOp.StatusOperation == "" {
+				continue
+			}
+			if _, ok := sdkOperations[asyncOp.StatusOperation]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"%s.status_operation: operation %q not found in SDK. available: %s",
+					prefix, asyncOp.StatusOperation,
+					formatAvailableTruncated(sortedKeys(sdkOperations), 10),
+				))
+			}
+		}
+	}
+	return errs
+}
+
+// validatePreDeleteUpdates checks that pre-delete updates name their fields
+// and are not combined with a custom delete method, which replaces the
+// generated code applying them.
+func validatePreDeleteUpdates(cfg *Config) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		deleteCfg := cfg.Resources[resName].DeleteOperation
+		if deleteCfg == nil || len(deleteCfg.PreDeleteUpdates) == 0 {
+			continue
+		}
+		prefix := fmt.Sprintf("resources.%s.delete_operation", resName)
+		if deleteCfg.CustomMethodName != "" {
+			errs = append(errs, fmt.Errorf(
+				"%s.pre_delete_updates: cannot be combined with custom_method_name",
+				prefix,
+			))
+		}
+		if _, found := deleteCfg.PreDeleteUpdates[""]; found {
+			errs = append(errs, fmt.Errorf(
+				"%s.pre_delete_updates: field name must not be empty", prefix,
+			))
+		}
+	}
+	return errs
+}
+
+// validateDeleteInputFields checks that the Delete input members in
+// resources[R].delete_operation.input_fields have at least one source, that
+// their values are valid templates, and that they are not combined with a
+// custom delete method, which replaces the generated code setting them. The
+// members and their types are checked against the Delete operation's Input
+// shape when the code is generated.
+func validateDeleteInputFields(cfg *Config) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		deleteCfg := cfg.Resources[resName].DeleteOperation
+		if deleteCfg == nil || len(deleteCfg.InputFields) == 0 {
+			continue
+		}
+		prefix := fmt.Sprintf("resources.%s.delete_operation.input_fields", resName)
+		if deleteCfg.CustomMethodName != "" {
+			errs = append(errs, fmt.Errorf(
+				"%s: cannot be combined with custom_method_name", prefix,
+			))
+		}
+		memberNames := make([]string, 0, len(deleteCfg.InputFields))
+		for memberName := range deleteCfg.InputFields {
+			memberNames = append(memberNames, memberName)
+		}
+		sort.Strings(memberNames)
+		for _, memberName := range memberNames {
+			fieldCfg := deleteCfg.InputFields[memberName]
+			if memberName == "" {
+				errs = append(errs, fmt.Errorf("%s: member name must not be empty", prefix))
+				continue
+			}
+			if fieldCfg == nil || (fieldCfg.Annotation == "" &&
+				fieldCfg.SpecField == "" && fieldCfg.Value == "") {
+				errs = append(errs, fmt.Errorf(
+					"%s.%s: one of annotation, spec_field or value is required",
+					prefix, memberName,
+				))
+				continue
+			}
+			if _, err := template.New(memberName).Parse(fieldCfg.Value); err != nil {
+				errs = append(errs, fmt.Errorf(
+					"%s.%s.value: invalid template: %w", prefix, memberName, err,
+				))
+			}
+		}
+	}
+	return errs
+}
+
+// validateCompositePrimaryKeys checks that the fields marked with
+// is_primary_key of resources with a composite primary key have distinct
+// primary_key_position from 1 to N, that primary_key_position is only set on
+// such fields, and that primary_key_separator is only set on such resources.
+func validateCompositePrimaryKeys(cfg *Config) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		resCfg := cfg.Resources[resName]
+		prefix := fmt.Sprintf("resources.%s", resName)
+		primaryKeyPaths := []string{}
+		for _, fieldPath := range sortedFieldConfigPaths(resCfg.Fields) {
+			fieldCfg := resCfg.Fields[fieldPath]
+			if fieldCfg.IsPrimaryKey {
+				primaryKeyPaths = append(primaryKeyPaths, fieldPath)
+			} else if fieldCfg.PrimaryKeyPosition != 0 {
+				errs = append(errs, fmt.Errorf(
+					"%s.fields.%s.primary_key_position: requires is_primary_key",
+					prefix, fieldPath,
+				))
+			}
+		}
+		if len(primaryKeyPaths) < 2 {
+			if resCfg.PrimaryKeySeparator != "" {
+				errs = append(errs, fmt.Errorf(
+					"%s.primary_key_separator: requires several fields marked with is_primary_key",
+					prefix,
+				))
+			}
+			continue
+		}
+		if resCfg.IsARNPrimaryKey {
+			errs = append(errs, fmt.Errorf(
+				"%s.is_arn_primary_key: cannot be combined with a composite primary key",
+				prefix,
+			))
+		}
+		seen := map[int]string{}
+		for _, fieldPath := range primaryKeyPaths {
+			position := resCfg.Fields[fieldPath].PrimaryKeyPosition
+			if position < 1 || position > len(primaryKeyPaths) {
+				errs = append(errs, fmt.Errorf(
+					"%s.fields.%s.primary_key_position: must be from 1 to %d in a composite primary key",
+					prefix, fieldPath, len(primaryKeyPaths),
+				))
+				continue
+			}
+			if other, found := seen[position]; found {
+				errs = append(errs, fmt.Errorf(
+					"%s.fields.%s.primary_key_position: %d is already the position of %s",
+					prefix, fieldPath, position, other,
+				))
+				continue
+			}
+			seen[position] = fieldPath
+		}
+	}
+	return errs
+}
+
+// validateARNTemplates checks the syntax and the placeholders of
+// resources[R].arn_template. The field paths of the placeholders are checked
+// against the fields of the resource when the model is loaded.
+func validateARNTemplates(cfg *Config) []error {
+	var errs []error
+	for _, resName := range sortedResourceNames(cfg) {
+		arnTemplate := cfg.Resources[resName].ARNTemplate
+		if arnTemplate == "" {
+			continue
+		}
+		if _, err := ParseARNTemplate(arnTemplate); err != nil {
+			errs = append(errs, fmt.Errorf("resources.%s.%w", resName, err))
+		}
+	}
+	return errs
+}
+
 // sortedKeys returns sorted keys from a map[string]struct{}.
 func sortedKeys(m map[string]struct{}) []string {
 	keys := make([]string, 0, len(m))
@@ -250,3 +250,1456 @@ func TestFormatAvailableTruncated(t *testing.T) {
 		t.Errorf("should not truncate when maxItems > len: %s", got)
 	}
 }
+
+func TestValidateCompareListSemantics(t *testing.T) {
+	tests := []struct {
+		name            string
+		compare         *CompareFieldConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name:         "no list semantics",
+			compare:      &CompareFieldConfig{IsIgnored: true},
+			wantErrCount: 0,
+		},
+		{
+			name:         "set list semantics",
+			compare:      &CompareFieldConfig{ListSemantics: ListSemanticsSet},
+			wantErrCount: 0,
+		},
+		{
+			name:         "keyed list semantics with key member",
+			compare:      &CompareFieldConfig{ListSemantics: ListSemanticsKeyed, KeyMember: "Name"},
+			wantErrCount: 0,
+		},
+		{
+			name:            "keyed list semantics without key member",
+			compare:         &CompareFieldConfig{ListSemantics: ListSemanticsKeyed},
+			wantErrCount:    1,
+			wantErrContains: "compare.key_member: required",
+		},
+		{
+			name:            "key member without keyed list semantics",
+			compare:         &CompareFieldConfig{ListSemantics: ListSemanticsSet, KeyMember: "Name"},
+			wantErrCount:    1,
+			wantErrContains: "compare.key_member: only supported",
+		},
+		{
+			name:            "unknown list semantics",
+			compare:         &CompareFieldConfig{ListSemantics: "sorted"},
+			wantErrCount:    1,
+			wantErrContains: "unknown value \"sorted\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"SecurityGroup": {
+						Fields: map[string]*FieldConfig{
+							"IngressRules": {Compare: tt.compare},
+						},
+					},
+				},
+			}
+			errs := validateCompareListSemantics(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCompareNormalizers(t *testing.T) {
+	dot := "."
+	tests := []struct {
+		name            string
+		normalize       []NormalizerConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name:         "no normalizers",
+			normalize:    nil,
+			wantErrCount: 0,
+		},
+		{
+			name: "known normalizers",
+			normalize: []NormalizerConfig{
+				{Name: NormalizerLowercase},
+				{Name: NormalizerTrimSuffix, Argument: &dot},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name:            "trim_suffix without argument",
+			normalize:       []NormalizerConfig{{Name: NormalizerTrimSuffix}},
+			wantErrCount:    1,
+			wantErrContains: "compare.normalize[0]: \"trim_suffix\" requires a non-empty argument",
+		},
+		{
+			name:            "argument to normalizer without argument",
+			normalize:       []NormalizerConfig{{Name: NormalizerLowercase, Argument: &dot}},
+			wantErrCount:    1,
+			wantErrContains: "does not take an argument",
+		},
+		{
+			name: "unknown normalizer",
+			normalize: []NormalizerConfig{
+				{Name: NormalizerTrim},
+				{Name: "uppercase"},
+			},
+			wantErrCount:    1,
+			wantErrContains: "compare.normalize[1]: unknown normalizer \"uppercase\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"RecordSet": {
+						Fields: map[string]*FieldConfig{
+							"Name": {Compare: &CompareFieldConfig{Normalize: tt.normalize}},
+						},
+					},
+				},
+			}
+			errs := validateCompareNormalizers(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateUpdateOperations(t *testing.T) {
+	sdkOps := map[string]struct{}{
+		"PutBucketPolicy":     {},
+		"PutBucketVersioning": {},
+	}
+
+	tests := []struct {
+		name            string
+		operations      []*UpdateSDKOperationConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name: "valid update operations",
+			operations: []*UpdateSDKOperationConfig{
+				{Name: "PutBucketPolicy", Fields: []string{"Policy"}},
+				{Name: "PutBucketVersioning", Fields: []string{"Versioning"}},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "unknown update operation",
+			operations: []*UpdateSDKOperationConfig{
+				{Name: "PutBucketPolicyy", Fields: []string{"Policy"}},
+			},
+			wantErrCount:    1,
+			wantErrContains: "operations[0]: operation \"PutBucketPolicyy\" not found in SDK",
+		},
+		{
+			name: "update operation without fields",
+			operations: []*UpdateSDKOperationConfig{
+				{Name: "PutBucketPolicy", Fields: []string{"Policy"}},
+				{Name: "PutBucketVersioning"},
+			},
+			wantErrCount:    1,
+			wantErrContains: "operations[1]: operation \"PutBucketVersioning\" must list at least one field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"Bucket": {
+						UpdateOperation: &UpdateOperationConfig{Operations: tt.operations},
+					},
+				},
+			}
+			errs := validateUpdateOperations(cfg, sdkOps)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateChildCollectionOperations(t *testing.T) {
+	sdkOps := map[string]struct{}{
+		"AttachRolePolicy":         {},
+		"DetachRolePolicy":         {},
+		"ListAttachedRolePolicies": {},
+	}
+
+	tests := []struct {
+		name            string
+		childCollection *ChildCollectionConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name: "valid child collection",
+			childCollection: &ChildCollectionConfig{
+				AddOperation:    "AttachRolePolicy",
+				RemoveOperation: "DetachRolePolicy",
+				InputMember:     "PolicyArn",
+				ReadOperation:   "ListAttachedRolePolicies",
+				ReadOutputPath:  "AttachedPolicies.PolicyArn",
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "missing remove operation",
+			childCollection: &ChildCollectionConfig{
+				AddOperation: "AttachRolePolicy",
+			},
+			wantErrCount:    1,
+			wantErrContains: "child_collection.remove_operation: required",
+		},
+		{
+			name: "unknown add operation",
+			childCollection: &ChildCollectionConfig{
+				AddOperation:    "AttachRolePolicyy",
+				RemoveOperation: "DetachRolePolicy",
+			},
+			wantErrCount:    1,
+			wantErrContains: "child_collection.add_operation: operation \"AttachRolePolicyy\" not found in SDK",
+		},
+		{
+			name: "read operation without output path",
+			childCollection: &ChildCollectionConfig{
+				AddOperation:    "AttachRolePolicy",
+				RemoveOperation: "DetachRolePolicy",
+				ReadOperation:   "ListAttachedRolePolicies",
+			},
+			wantErrCount:    1,
+			wantErrContains: "read_operation and read_output_path must be set together",
+		},
+		{
+			name: "negative batch size",
+			childCollection: &ChildCollectionConfig{
+				AddOperation:    "AttachRolePolicy",
+				RemoveOperation: "DetachRolePolicy",
+				BatchSize:       -1,
+			},
+			wantErrCount:    1,
+			wantErrContains: "child_collection.batch_size: must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"Role": {
+						Fields: map[string]*FieldConfig{
+							"Policies": {ChildCollection: tt.childCollection},
+						},
+					},
+				},
+			}
+			errs := validateChildCollectionOperations(cfg, sdkOps)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTagSyncOperations(t *testing.T) {
+	sdkOps := map[string]struct{}{
+		"TagResource":         {},
+		"UntagResource":       {},
+		"ListTagsForResource": {},
+	}
+
+	tests := []struct {
+		name            string
+		tagConfig       *TagConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name: "valid sync operations",
+			tagConfig: &TagConfig{
+				SyncOperations: &TagSyncOperationsConfig{
+					TagOperation:   "TagResource",
+					UntagOperation: "UntagResource",
+					ListOperation:  "ListTagsForResource",
+				},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name:         "no sync operations",
+			tagConfig:    &TagConfig{},
+			wantErrCount: 0,
+		},
+		{
+			name: "missing untag operation",
+			tagConfig: &TagConfig{
+				SyncOperations: &TagSyncOperationsConfig{
+					TagOperation: "TagResource",
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "tags.sync_operations.untag_operation: required",
+		},
+		{
+			name: "unknown list operation",
+			tagConfig: &TagConfig{
+				SyncOperations: &TagSyncOperationsConfig{
+					TagOperation:   "TagResource",
+					UntagOperation: "UntagResource",
+					ListOperation:  "ListTags",
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "tags.sync_operations.list_operation: operation \"ListTags\" not found in SDK",
+		},
+		{
+			name: "combined with ignore",
+			tagConfig: &TagConfig{
+				Ignore: true,
+				SyncOperations: &TagSyncOperationsConfig{
+					TagOperation:   "TagResource",
+					UntagOperation: "UntagResource",
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "cannot be combined with tags.ignore",
+		},
+		{
+			name: "negative batch size",
+			tagConfig: &TagConfig{
+				SyncOperations: &TagSyncOperationsConfig{
+					TagOperation:   "TagResource",
+					UntagOperation: "UntagResource",
+					BatchSize:      -1,
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "tags.sync_operations.batch_size: must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"Repository": {TagConfig: tt.tagConfig},
+				},
+			}
+			errs := validateTagSyncOperations(cfg, sdkOps)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateObserveOnly(t *testing.T) {
+	adoptable := true
+
+	tests := []struct {
+		name            string
+		resConfig       ResourceConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name:         "observe only",
+			resConfig:    ResourceConfig{ObserveOnly: true},
+			wantErrCount: 0,
+		},
+		{
+			name: "not observe only",
+			resConfig: ResourceConfig{
+				IsAdoptable:     &adoptable,
+				UpdateOperation: &UpdateOperationConfig{},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "combined with is_adoptable",
+			resConfig: ResourceConfig{
+				ObserveOnly: true,
+				IsAdoptable: &adoptable,
+			},
+			wantErrCount:    1,
+			wantErrContains: "observe_only: cannot be combined with is_adoptable",
+		},
+		{
+			name: "combined with tag sync and child collection",
+			resConfig: ResourceConfig{
+				ObserveOnly: true,
+				TagConfig: &TagConfig{
+					SyncOperations: &TagSyncOperationsConfig{},
+				},
+				Fields: map[string]*FieldConfig{
+					"Policies": {ChildCollection: &ChildCollectionConfig{}},
+				},
+			},
+			wantErrCount:    2,
+			wantErrContains: "observe_only: cannot be combined with tags.sync_operations",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"Repository": tt.resConfig,
+				},
+			}
+			errs := validateObserveOnly(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSingletonOperations(t *testing.T) {
+	sdkOps := map[string]struct{}{
+		"GetRegistryPolicy":    {},
+		"PutRegistryPolicy":    {},
+		"DeleteRegistryPolicy": {},
+	}
+
+	tests := []struct {
+		name            string
+		singletonConfig *SingletonConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name: "valid singleton",
+			singletonConfig: &SingletonConfig{
+				ReadOperation:  "GetRegistryPolicy",
+				WriteOperation: "PutRegistryPolicy",
+				ResetOperation: "DeleteRegistryPolicy",
+			},
+			wantErrCount: 0,
+		},
+		{
+			name:         "not a singleton",
+			wantErrCount: 0,
+		},
+		{
+			name: "missing write operation",
+			singletonConfig: &SingletonConfig{
+				ReadOperation: "GetRegistryPolicy",
+			},
+			wantErrCount:    1,
+			wantErrContains: "singleton.write_operation: required",
+		},
+		{
+			name: "unknown reset operation",
+			singletonConfig: &SingletonConfig{
+				ReadOperation:  "GetRegistryPolicy",
+				WriteOperation: "PutRegistryPolicy",
+				ResetOperation: "ResetRegistryPolicy",
+			},
+			wantErrCount:    1,
+			wantErrContains: "singleton.reset_operation: operation \"ResetRegistryPolicy\" not found in SDK",
+		},
+		{
+			name: "reset policy without reset operation",
+			singletonConfig: &SingletonConfig{
+				ReadOperation:  "GetRegistryPolicy",
+				WriteOperation: "PutRegistryPolicy",
+				DeletePolicy:   SingletonDeletePolicyReset,
+			},
+			wantErrCount:    1,
+			wantErrContains: "singleton.delete_policy: \"reset\" requires reset_operation",
+		},
+		{
+			name: "unsupported delete policy",
+			singletonConfig: &SingletonConfig{
+				ReadOperation:  "GetRegistryPolicy",
+				WriteOperation: "PutRegistryPolicy",
+				DeletePolicy:   "delete",
+			},
+			wantErrCount:    1,
+			wantErrContains: "singleton.delete_policy: unsupported value \"delete\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"RegistryPolicy": {Singleton: tt.singletonConfig},
+				},
+			}
+			errs := validateSingletonOperations(cfg, sdkOps)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateResourceScopes(t *testing.T) {
+	tests := []struct {
+		name            string
+		scope           string
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name:         "default scope",
+			scope:        "",
+			wantErrCount: 0,
+		},
+		{
+			name:         "namespaced",
+			scope:        ResourceScopeNamespaced,
+			wantErrCount: 0,
+		},
+		{
+			name:         "cluster",
+			scope:        ResourceScopeCluster,
+			wantErrCount: 0,
+		},
+		{
+			name:            "unsupported scope",
+			scope:           "cluster",
+			wantErrCount:    1,
+			wantErrContains: `resources.Registry.scope: unsupported value "cluster"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"Registry": {Scope: tt.scope},
+				},
+			}
+			errs := validateResourceScopes(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateResourceAPIGroupsAndNames(t *testing.T) {
+	tests := []struct {
+		name            string
+		resources       map[string]ResourceConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name: "valid api group and names",
+			resources: map[string]ResourceConfig{
+				"Vpc": {
+					APIGroup: "vpc.ec2.services.k8s.aws",
+					Names: &ResourceNamesConfig{
+						Kind:     "VirtualPrivateCloud",
+						Plural:   "virtualprivateclouds",
+						Singular: "virtualprivatecloud",
+					},
+				},
+				"Subnet": {APIGroup: "vpc.ec2.services.k8s.aws"},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "invalid api group",
+			resources: map[string]ResourceConfig{
+				"Vpc": {APIGroup: "VPC"},
+			},
+			wantErrCount:    1,
+			wantErrContains: `resources.Vpc.api_group: "VPC" is not a valid API group`,
+		},
+		{
+			name: "api groups sharing their first label",
+			resources: map[string]ResourceConfig{
+				"Subnet": {APIGroup: "vpc.ec2.services.k8s.aws"},
+				"Vpc":    {APIGroup: "vpc.example.com"},
+			},
+			wantErrCount:    1,
+			wantErrContains: `share the first label "vpc"`,
+		},
+		{
+			name: "invalid kind",
+			resources: map[string]ResourceConfig{
+				"Vpc": {Names: &ResourceNamesConfig{Kind: "virtual-private-cloud"}},
+			},
+			wantErrCount:    1,
+			wantErrContains: `resources.Vpc.names.kind: "virtual-private-cloud" is not a valid Kind`,
+		},
+		{
+			name: "duplicate kind",
+			resources: map[string]ResourceConfig{
+				"Subnet": {Names: &ResourceNamesConfig{Kind: "Network"}},
+				"Vpc":    {Names: &ResourceNamesConfig{Kind: "Network"}},
+			},
+			wantErrCount:    1,
+			wantErrContains: `Kind "Network" is already used by resource "Subnet"`,
+		},
+		{
+			name: "invalid plural and singular",
+			resources: map[string]ResourceConfig{
+				"Vpc": {Names: &ResourceNamesConfig{Plural: "VPCs", Singular: "v_p_c"}},
+			},
+			wantErrCount:    2,
+			wantErrContains: `resources.Vpc.names.plural: "VPCs" must be lowercase alphanumeric`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Resources: tt.resources}
+			errs := validateResourceAPIGroupsAndNames(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateKubernetesObjectReferences(t *testing.T) {
+	tests := []struct {
+		name            string
+		references      *ReferencesConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name: "valid reference to ConfigMap data key",
+			references: &ReferencesConfig{
+				Version:  "v1",
+				Kind:     "ConfigMap",
+				JSONPath: "{.data.description}",
+			},
+			wantErrCount: 0,
+		},
+		{
+			name:         "valid reference to ACK resource",
+			references:   &ReferencesConfig{Resource: "Api", Path: "Status.APIID"},
+			wantErrCount: 0,
+		},
+		{
+			name:            "object reference options without kind",
+			references:      &ReferencesConfig{Resource: "Api", Path: "Status.APIID", JSONPath: "{.data.id}"},
+			wantErrCount:    1,
+			wantErrContains: "group, version, json_path and allow_cross_namespace require kind to be set",
+		},
+		{
+			name: "kind combined with resource",
+			references: &ReferencesConfig{
+				Resource: "Api",
+				Version:  "v1",
+				Kind:     "ConfigMap",
+				JSONPath: "{.data.id}",
+			},
+			wantErrCount:    1,
+			wantErrContains: "kind cannot be combined with resource, path or service_name",
+		},
+		{
+			name:            "kind without version and json_path",
+			references:      &ReferencesConfig{Kind: "ConfigMap"},
+			wantErrCount:    2,
+			wantErrContains: "kind requires version to be set",
+		},
+		{
+			name: "invalid json_path",
+			references: &ReferencesConfig{
+				Version:  "v1",
+				Kind:     "ConfigMap",
+				JSONPath: ".data.description",
+			},
+			wantErrCount:    1,
+			wantErrContains: `json_path: ".data.description" must be a JSONPath template`,
+		},
+		{
+			name: "reference to Secret",
+			references: &ReferencesConfig{
+				Version:  "v1",
+				Kind:     "Secret",
+				JSONPath: "{.data.password}",
+			},
+			wantErrCount:    1,
+			wantErrContains: "Secrets cannot be referenced",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"Integration": {
+						Fields: map[string]*FieldConfig{
+							"Description": {References: tt.references},
+						},
+					},
+				},
+			}
+			errs := validateKubernetesObjectReferences(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateFieldPaths(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name            string
+		resource        ResourceConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name: "valid field paths",
+			resource: ResourceConfig{
+				Synced: &SyncedConfig{When: []SyncedCondition{
+					{Path: strPtr("Status.Endpoints[*].Status"), In: []string{"AVAILABLE"}},
+				}},
+				Updateable: &UpdateableConfig{When: []StatusCondition{
+					{Path: strPtr("Status.Rules[0].State"), In: []string{"ACTIVE"}},
+				}},
+				Deletable: &DeletableConfig{When: []StatusCondition{
+					{Path: strPtr(`Status.States["primary"]`), In: []string{"ACTIVE"}},
+				}},
+				Compare: &CompareConfig{Ignore: []string{`Spec.Tags["env"]`, "Spec.Name"}},
+				Print: &PrintConfig{AdditionalColumns: []*AdditionalColumnConfig{
+					{Name: "ADDRESS", Path: "Status.Endpoints[*].Address", Type: "string"},
+					{Name: "ID", JSONPath: ".status.id", Type: "string"},
+				}},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "deprecated legacy double dot in synced condition",
+			resource: ResourceConfig{
+				Synced: &SyncedConfig{When: []SyncedCondition{
+					{Path: strPtr("Status.Endpoints..Status"), In: []string{"AVAILABLE"}},
+				}},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "legacy double dot after selector in synced condition",
+			resource: ResourceConfig{
+				Synced: &SyncedConfig{When: []SyncedCondition{
+					{Path: strPtr("Status.Endpoints[0]..Status"), In: []string{"AVAILABLE"}},
+				}},
+			},
+			wantErrCount:    1,
+			wantErrContains: `resources.Broker.synced.when[0].path: invalid field path "Status.Endpoints[0]..Status" at offset 20`,
+		},
+		{
+			name: "unterminated selector in deletable condition",
+			resource: ResourceConfig{
+				Deletable: &DeletableConfig{When: []StatusCondition{
+					{Path: strPtr("Status.Rules[0"), In: []string{"ACTIVE"}},
+				}},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.Broker.deletable.when[0].path",
+		},
+		{
+			name: "unquoted key in compare ignore",
+			resource: ResourceConfig{
+				Compare: &CompareConfig{Ignore: []string{"Spec.Name", "Spec.Tags[env]"}},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.Broker.compare.ignore[1]",
+		},
+		{
+			name: "printer column with path and json_path",
+			resource: ResourceConfig{
+				Print: &PrintConfig{AdditionalColumns: []*AdditionalColumnConfig{
+					{Name: "ID", Path: "Status.ID", JSONPath: ".status.id", Type: "string"},
+				}},
+			},
+			wantErrCount:    1,
+			wantErrContains: "exactly one of path and json_path must be set",
+		},
+		{
+			name: "printer column with invalid path",
+			resource: ResourceConfig{
+				Print: &PrintConfig{AdditionalColumns: []*AdditionalColumnConfig{
+					{Name: "ID", Path: "Status.IDs[]", Type: "string"},
+				}},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.Broker.print.additional_columns[0].path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"Broker": tt.resource,
+				},
+			}
+			errs := validateFieldPaths(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConditionExprs(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name            string
+		resource        ResourceConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name: "valid expressions",
+			resource: ResourceConfig{
+				Synced: &SyncedConfig{When: []SyncedCondition{
+					{Expr: strPtr(`Status.ProgressPercent == 100 && empty(Status.PendingModifiedValues)`)},
+					{Path: strPtr("Status.Status"), In: []string{"available"}},
+				}},
+				Updateable: &UpdateableConfig{When: []StatusCondition{
+					{Expr: strPtr(`all(Status.Replicas[*].ReplicaStatus == "ACTIVE")`)},
+				}},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "expression combined with path",
+			resource: ResourceConfig{
+				Synced: &SyncedConfig{When: []SyncedCondition{
+					{Path: strPtr("Status.Status"), Expr: strPtr(`Status.Status == "available"`)},
+				}},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.Broker.synced.when[0]: expr cannot be combined with path and in",
+		},
+		{
+			name: "invalid expression syntax",
+			resource: ResourceConfig{
+				Deletable: &DeletableConfig{When: []StatusCondition{
+					{Expr: strPtr(`Status.Status = "available"`)},
+				}},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.Broker.deletable.when[0].expr: invalid expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"Broker": tt.resource,
+				},
+			}
+			errs := validateConditionExprs(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateRequeueRules(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name            string
+		reconcile       *ReconcileConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name:         "no reconcile config",
+			reconcile:    nil,
+			wantErrCount: 0,
+		},
+		{
+			name: "valid rules",
+			reconcile: &ReconcileConfig{RequeueWhen: []RequeueRule{
+				{Path: strPtr("Status.Status"), In: []string{"creating"}, RequeueAfterSeconds: 60, MaxRequeueAfterSeconds: 600},
+				{Path: strPtr("Status.Replicas[*].Status"), In: []string{"modifying"}, RequeueAfterSeconds: 10},
+			}},
+			wantErrCount: 0,
+		},
+		{
+			name: "missing path",
+			reconcile: &ReconcileConfig{RequeueWhen: []RequeueRule{
+				{In: []string{"creating"}, RequeueAfterSeconds: 60},
+			}},
+			wantErrCount:    1,
+			wantErrContains: "resources.Broker.reconcile.requeue_when[0]: path must be set",
+		},
+		{
+			name: "invalid path",
+			reconcile: &ReconcileConfig{RequeueWhen: []RequeueRule{
+				{Path: strPtr("Status.Conditions[]"), In: []string{"creating"}, RequeueAfterSeconds: 60},
+			}},
+			wantErrCount:    1,
+			wantErrContains: "resources.Broker.reconcile.requeue_when[0].path: invalid field path",
+		},
+		{
+			name: "missing values and delay",
+			reconcile: &ReconcileConfig{RequeueWhen: []RequeueRule{
+				{Path: strPtr("Status.Status")},
+			}},
+			wantErrCount:    2,
+			wantErrContains: "in must contain at least one value",
+		},
+		{
+			name: "maximum delay lower than delay",
+			reconcile: &ReconcileConfig{RequeueWhen: []RequeueRule{
+				{Path: strPtr("Status.Status"), In: []string{"creating"}, RequeueAfterSeconds: 60, MaxRequeueAfterSeconds: 30},
+			}},
+			wantErrCount:    1,
+			wantErrContains: "max_requeue_after_seconds (30) must not be lower than requeue_after_seconds (60)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"Broker": {Reconcile: tt.reconcile},
+				},
+			}
+			errs := validateRequeueRules(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateReadAfterCreateGrace(t *testing.T) {
+	tests := []struct {
+		name            string
+		reconcile       *ReconcileConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name:         "no reconcile config",
+			reconcile:    nil,
+			wantErrCount: 0,
+		},
+		{
+			name: "valid grace window with error codes",
+			reconcile: &ReconcileConfig{
+				ReadAfterCreateGraceSeconds:     30,
+				ReadAfterCreateGraceStatusField: "CreatedAt",
+				ReadAfterCreateGraceErrorCodes:  []string{"InvalidParameterValue"},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name:            "grace window without status field",
+			reconcile:       &ReconcileConfig{ReadAfterCreateGraceSeconds: 30},
+			wantErrCount:    1,
+			wantErrContains: "resources.Broker.reconcile.read_after_create_grace_status_field: required along with read_after_create_grace_seconds",
+		},
+		{
+			name:            "status field without grace window",
+			reconcile:       &ReconcileConfig{ReadAfterCreateGraceStatusField: "CreatedAt"},
+			wantErrCount:    1,
+			wantErrContains: "resources.Broker.reconcile.read_after_create_grace_status_field: requires read_after_create_grace_seconds",
+		},
+		{
+			name:            "negative grace window",
+			reconcile:       &ReconcileConfig{ReadAfterCreateGraceSeconds: -1},
+			wantErrCount:    1,
+			wantErrContains: "resources.Broker.reconcile.read_after_create_grace_seconds: must not be negative, got -1",
+		},
+		{
+			name: "error codes without grace window",
+			reconcile: &ReconcileConfig{
+				ReadAfterCreateGraceErrorCodes: []string{"InvalidParameterValue"},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.Broker.reconcile.read_after_create_grace_error_codes: requires read_after_create_grace_seconds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"Broker": {Reconcile: tt.reconcile},
+				},
+			}
+			errs := validateReadAfterCreateGrace(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAsyncOperations(t *testing.T) {
+	sdkOps := map[string]struct{}{
+		"GetChange": {},
+	}
+	validOp := func() *AsyncOperationConfig {
+		return &AsyncOperationConfig{
+			IDPath:          "ChangeInfo.Id",
+			StatusField:     "ChangeID",
+			StatusOperation: "GetChange",
+			StatusIDMember:  "Id",
+			StatusPath:      "ChangeInfo.Status",
+			CompletedWhen:   []string{"INSYNC"},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		asyncOps        map[string]*AsyncOperationConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name:         "no async operations",
+			asyncOps:     nil,
+			wantErrCount: 0,
+		},
+		{
+			name: "valid async operations",
+			asyncOps: map[string]*AsyncOperationConfig{
+				"Create": validOp(),
+				"Delete": validOp(),
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "unknown operation type",
+			asyncOps: map[string]*AsyncOperationConfig{
+				"ReadOne": validOp(),
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.RecordSet.async_operations.ReadOne: unknown operation type",
+		},
+		{
+			name: "missing keys",
+			asyncOps: map[string]*AsyncOperationConfig{
+				"Create": {IDPath: "ChangeInfo.Id"},
+			},
+			wantErrCount:    5,
+			wantErrContains: "resources.RecordSet.async_operations.Create.status_field: required",
+		},
+		{
+			name: "unknown status operation",
+			asyncOps: map[string]*AsyncOperationConfig{
+				"Update": func() *AsyncOperationConfig {
+					op := validOp()
+					op.StatusOperation = "DescribeChange"
+					return op
+				}(),
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.RecordSet.async_operations.Update.status_operation: operation \"DescribeChange\" not found in SDK",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"RecordSet": {AsyncOperations: tt.asyncOps},
+				},
+			}
+			errs := validateAsyncOperations(cfg, sdkOps)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidatePreDeleteUpdates(t *testing.T) {
+	tests := []struct {
+		name            string
+		deleteOp        *DeleteOperationsConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name:         "no delete operation config",
+			deleteOp:     nil,
+			wantErrCount: 0,
+		},
+		{
+			name: "valid pre-delete updates",
+			deleteOp: &DeleteOperationsConfig{
+				PreDeleteUpdates: map[string]string{"DeletionProtection": "false"},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "combined with custom delete method",
+			deleteOp: &DeleteOperationsConfig{
+				CustomMethodName: "customDelete",
+				PreDeleteUpdates: map[string]string{"DeletionProtection": "false"},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.DBCluster.delete_operation.pre_delete_updates: cannot be combined with custom_method_name",
+		},
+		{
+			name: "empty field name",
+			deleteOp: &DeleteOperationsConfig{
+				PreDeleteUpdates: map[string]string{"": "false"},
+			},
+			wantErrCount:    1,
+			wantErrContains: "field name must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"DBCluster": {DeleteOperation: tt.deleteOp},
+				},
+			}
+			errs := validatePreDeleteUpdates(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateDeleteInputFields(t *testing.T) {
+	tests := []struct {
+		name            string
+		deleteOp        *DeleteOperationsConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name:         "no delete operation config",
+			deleteOp:     nil,
+			wantErrCount: 0,
+		},
+		{
+			name: "valid input fields",
+			deleteOp: &DeleteOperationsConfig{
+				InputFields: map[string]*DeleteInputFieldConfig{
+					"SkipFinalSnapshot": {
+						Annotation: "rds.services.k8s.aws/skip-final-snapshot",
+						Value:      "false",
+					},
+					"FinalDBSnapshotIdentifier": {
+						Value: "{{ .Name }}-final-{{ .Timestamp }}",
+					},
+				},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "combined with custom delete method",
+			deleteOp: &DeleteOperationsConfig{
+				CustomMethodName: "customDelete",
+				InputFields: map[string]*DeleteInputFieldConfig{
+					"SkipFinalSnapshot": {Value: "true"},
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.DBCluster.delete_operation.input_fields: cannot be combined with custom_method_name",
+		},
+		{
+			name: "no source",
+			deleteOp: &DeleteOperationsConfig{
+				InputFields: map[string]*DeleteInputFieldConfig{
+					"SkipFinalSnapshot": {},
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "input_fields.SkipFinalSnapshot: one of annotation, spec_field or value is required",
+		},
+		{
+			name: "invalid value template",
+			deleteOp: &DeleteOperationsConfig{
+				InputFields: map[string]*DeleteInputFieldConfig{
+					"FinalDBSnapshotIdentifier": {Value: "{{ .Name "},
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "input_fields.FinalDBSnapshotIdentifier.value: invalid template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"DBCluster": {DeleteOperation: tt.deleteOp},
+				},
+			}
+			errs := validateDeleteInputFields(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCompositePrimaryKeys(t *testing.T) {
+	tests := []struct {
+		name            string
+		resource        ResourceConfig
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name: "single primary key",
+			resource: ResourceConfig{
+				Fields: map[string]*FieldConfig{
+					"DomainName": {IsPrimaryKey: true},
+				},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "valid composite primary key",
+			resource: ResourceConfig{
+				PrimaryKeySeparator: ":",
+				Fields: map[string]*FieldConfig{
+					"DomainName":   {IsPrimaryKey: true, PrimaryKeyPosition: 1},
+					"ApiMappingId": {IsPrimaryKey: true, PrimaryKeyPosition: 2},
+				},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "missing position",
+			resource: ResourceConfig{
+				Fields: map[string]*FieldConfig{
+					"DomainName":   {IsPrimaryKey: true, PrimaryKeyPosition: 1},
+					"ApiMappingId": {IsPrimaryKey: true},
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.ApiMapping.fields.ApiMappingId.primary_key_position: must be from 1 to 2",
+		},
+		{
+			name: "duplicate position",
+			resource: ResourceConfig{
+				Fields: map[string]*FieldConfig{
+					"DomainName":   {IsPrimaryKey: true, PrimaryKeyPosition: 1},
+					"ApiMappingId": {IsPrimaryKey: true, PrimaryKeyPosition: 1},
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.ApiMapping.fields.DomainName.primary_key_position: 1 is already the position of ApiMappingId",
+		},
+		{
+			name: "position without primary key",
+			resource: ResourceConfig{
+				Fields: map[string]*FieldConfig{
+					"DomainName": {PrimaryKeyPosition: 1},
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.ApiMapping.fields.DomainName.primary_key_position: requires is_primary_key",
+		},
+		{
+			name: "separator without composite primary key",
+			resource: ResourceConfig{
+				PrimaryKeySeparator: ":",
+				Fields: map[string]*FieldConfig{
+					"DomainName": {IsPrimaryKey: true},
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.ApiMapping.primary_key_separator: requires several fields",
+		},
+		{
+			name: "combined with ARN primary key",
+			resource: ResourceConfig{
+				IsARNPrimaryKey: true,
+				Fields: map[string]*FieldConfig{
+					"DomainName":   {IsPrimaryKey: true, PrimaryKeyPosition: 1},
+					"ApiMappingId": {IsPrimaryKey: true, PrimaryKeyPosition: 2},
+				},
+			},
+			wantErrCount:    1,
+			wantErrContains: "resources.ApiMapping.is_arn_primary_key: cannot be combined with a composite primary key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"ApiMapping": tt.resource,
+				},
+			}
+			errs := validateCompositePrimaryKeys(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateARNTemplates(t *testing.T) {
+	tests := []struct {
+		name            string
+		arnTemplate     string
+		wantErrCount    int
+		wantErrContains string
+	}{
+		{
+			name:         "no template",
+			wantErrCount: 0,
+		},
+		{
+			name:         "valid template",
+			arnTemplate:  "arn:{partition}:apigateway:{region}::/domainnames/{Spec.DomainName}/apimappings/{Status.ApiMappingId}",
+			wantErrCount: 0,
+		},
+		{
+			name:            "unclosed placeholder",
+			arnTemplate:     "arn:{partition}:apigateway:{region",
+			wantErrCount:    1,
+			wantErrContains: "resources.ApiMapping.arn_template \"arn:{partition}:apigateway:{region\": unclosed '{'",
+		},
+		{
+			name:            "unexpected closing brace",
+			arnTemplate:     "arn:partition}:apigateway",
+			wantErrCount:    1,
+			wantErrContains: "unexpected '}'",
+		},
+		{
+			name:            "unknown placeholder",
+			arnTemplate:     "arn:{partition}:apigateway:{region}::/domainnames/{DomainName}",
+			wantErrCount:    1,
+			wantErrContains: "unknown placeholder \"DomainName\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Resources: map[string]ResourceConfig{
+					"ApiMapping": {ARNTemplate: tt.arnTemplate},
+				},
+			}
+			errs := validateARNTemplates(cfg)
+			if len(errs) != tt.wantErrCount {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrCount, errs)
+			}
+			if tt.wantErrContains != "" && len(errs) > 0 {
+				if !strings.Contains(errs[0].Error(), tt.wantErrContains) {
+					t.Errorf("error %q does not contain %q", errs[0].Error(), tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
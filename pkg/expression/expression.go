@@ -0,0 +1,611 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package expression implements the small expression language of the `expr`
+// of resource conditions (e.g. `synced.when`), which the code generator
+// compiles into Go code.
+//
+// An expression is a boolean combination of comparisons of field values:
+//
+//	Status.Status == "available" && !empty(Status.PendingModifiedValues)
+//	Status.ProgressPercent >= 100 || Status.State in ["ACTIVE", "UPDATING"]
+//	all(Status.Replicas[*].ReplicaStatus == "ACTIVE")
+//
+// Operands are field paths (see pkg/fieldpath), string, number and boolean
+// literals and function calls. The supported operators are, by increasing
+// precedence, `||`, `&&`, `!` and the comparisons `==`, `!=`, `<`, `<=`, `>`,
+// `>=` and `in` (whose right operand is a list of literals). The supported
+// functions are:
+//
+//   - exists(path): the field is set
+//   - empty(path): the field is not set, or is an empty string, list or map
+//   - len(path): the length of a string, list or map field
+//   - all(expr) and any(expr): expr is true for every (resp. at least one)
+//     element selected by the [*] wildcard of the field paths in expr
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/fieldpath"
+)
+
+// Node is a node of the syntax tree of an expression
+type Node interface {
+	// Offset returns the zero-based offset of the node in the expression
+	Offset() int
+	// String returns the normalized source of the node
+	String() string
+}
+
+// Binary is a binary operation, either a boolean combination (`&&`, `||`) or
+// a comparison (`==`, `!=`, `<`, `<=`, `>`, `>=`, `in`)
+type Binary struct {
+	Op     string
+	Left   Node
+	Right  Node
+	offset int
+}
+
+// Offset implements Node
+func (n *Binary) Offset() int { return n.offset }
+
+// String implements Node
+func (n *Binary) String() string {
+	return fmt.Sprintf("(%s %s %s)", n.Left, n.Op, n.Right)
+}
+
+// IsComparison returns true if the operation compares two values
+func (n *Binary) IsComparison() bool {
+	return n.Op != "&&" && n.Op != "||"
+}
+
+// Not is the negation of a boolean expression
+type Not struct {
+	X      Node
+	offset int
+}
+
+// Offset implements Node
+func (n *Not) Offset() int { return n.offset }
+
+// String implements Node
+func (n *Not) String() string { return "!" + n.X.String() }
+
+// Path is a field path operand, e.g. `Status.Replicas[*].ReplicaStatus`
+type Path struct {
+	Path   *fieldpath.Path
+	offset int
+}
+
+// Offset implements Node
+func (n *Path) Offset() int { return n.offset }
+
+// String implements Node
+func (n *Path) String() string { return n.Path.String() }
+
+// LiteralKind is the type of a literal
+type LiteralKind string
+
+const (
+	LiteralString LiteralKind = "string"
+	LiteralInt    LiteralKind = "int"
+	LiteralFloat  LiteralKind = "float"
+	LiteralBool   LiteralKind = "bool"
+)
+
+// Literal is a string, number or boolean literal operand
+type Literal struct {
+	Kind LiteralKind
+	// Value is the Go source of the literal, e.g. `"ACTIVE"`, `100` or
+	// `true`
+	Value  string
+	offset int
+}
+
+// Offset implements Node
+func (n *Literal) Offset() int { return n.offset }
+
+// String implements Node
+func (n *Literal) String() string { return n.Value }
+
+// List is a list of literals, the right operand of `in`
+type List struct {
+	Elems  []*Literal
+	offset int
+}
+
+// Offset implements Node
+func (n *List) Offset() int { return n.offset }
+
+// String implements Node
+func (n *List) String() string {
+	elems := make([]string, len(n.Elems))
+	for i, elem := range n.Elems {
+		elems[i] = elem.String()
+	}
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+// Call is a function call
+type Call struct {
+	Func   string
+	Args   []Node
+	offset int
+}
+
+// Offset implements Node
+func (n *Call) Offset() int { return n.offset }
+
+// String implements Node
+func (n *Call) String() string {
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i] = arg.String()
+	}
+	return n.Func + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Functions of the expression language
+const (
+	FuncExists = "exists"
+	FuncEmpty  = "empty"
+	FuncLen    = "len"
+	FuncAll    = "all"
+	FuncAny    = "any"
+)
+
+// ParseError describes a syntax error in an expression
+type ParseError struct {
+	// Expr is the expression that failed to parse
+	Expr string
+	// Offset is the zero-based offset of the byte of Expr at which the error
+	// was found
+	Offset int
+	// Msg describes the error
+	Msg string
+}
+
+// Error implements the error interface
+func (e *ParseError) Error() string {
+	return fmt.Sprintf(
+		"invalid expression %q at offset %d: %s", e.Expr, e.Offset, e.Msg,
+	)
+}
+
+// Parse returns the syntax tree of the supplied expression. Type checking
+// against the fields of a resource is left to the code generator.
+func Parse(subject string) (Node, error) {
+	p := &parser{src: subject}
+	if err := p.lex(); err != nil {
+		return nil, err
+	}
+	if len(p.tokens) == 1 {
+		return nil, p.errAt(0, "empty expression")
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errAt(tok.offset, "unexpected %s", tok)
+	}
+	return node, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPath
+	tokIdent
+	tokString
+	tokInt
+	tokFloat
+	tokOp
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+// String returns the description of the token used in error messages
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+type parser struct {
+	src    string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) errAt(offset int, format string, args ...interface{}) error {
+	return &ParseError{
+		Expr:   p.src,
+		Offset: offset,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+// operators are the operator and punctuation tokens, longest first
+var operators = []string{
+	"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ",",
+}
+
+// lex splits the source of the expression into tokens
+func (p *parser) lex() error {
+	src := p.src
+	pos := 0
+	for pos < len(src) {
+		c := src[pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+		case isLetter(c):
+			start := pos
+			end, err := p.scanPath(start)
+			if err != nil {
+				return err
+			}
+			pos = end
+			text := src[start:end]
+			kind := tokPath
+			if !strings.ContainsAny(text, ".[") {
+				kind = tokIdent
+			}
+			p.tokens = append(p.tokens, token{kind: kind, text: text, offset: start})
+		case c >= '0' && c <= '9' || c == '-' && pos+1 < len(src) && src[pos+1] >= '0' && src[pos+1] <= '9':
+			start := pos
+			pos++
+			kind := tokInt
+			for pos < len(src) && (src[pos] >= '0' && src[pos] <= '9' || src[pos] == '.') {
+				if src[pos] == '.' {
+					if kind == tokFloat {
+						return p.errAt(pos, "invalid number %q", src[start:pos+1])
+					}
+					kind = tokFloat
+				}
+				pos++
+			}
+			if src[pos-1] == '.' {
+				return p.errAt(start, "invalid number %q", src[start:pos])
+			}
+			p.tokens = append(p.tokens, token{kind: kind, text: src[start:pos], offset: start})
+		case c == '"' || c == '\'':
+			start := pos
+			var s strings.Builder
+			pos++
+			for {
+				if pos >= len(src) {
+					return p.errAt(start, "unterminated string")
+				}
+				if src[pos] == c {
+					pos++
+					break
+				}
+				if src[pos] == '\\' && pos+1 < len(src) {
+					pos++
+				}
+				s.WriteByte(src[pos])
+				pos++
+			}
+			p.tokens = append(p.tokens, token{kind: tokString, text: s.String(), offset: start})
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(src[pos:], op) {
+					p.tokens = append(p.tokens, token{kind: tokOp, text: op, offset: pos})
+					pos += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return p.errAt(pos, "unexpected character %q", c)
+			}
+		}
+	}
+	p.tokens = append(p.tokens, token{kind: tokEOF, offset: len(src)})
+	return nil
+}
+
+// scanPath returns the offset following the field path (or identifier)
+// starting at the supplied offset, skipping over the quoted map keys of its
+// selectors
+func (p *parser) scanPath(start int) (int, error) {
+	src := p.src
+	pos := start
+	for pos < len(src) {
+		c := src[pos]
+		switch {
+		case isLetter(c) || c >= '0' && c <= '9' || c == '.':
+			pos++
+		case c == '[' && src[start:pos] == "in":
+			// The list of an `in` comparison
+			return pos, nil
+		case c == '[':
+			end := strings.IndexByte(src[pos:], ']')
+			if q := strings.IndexAny(src[pos:], `"'`); q >= 0 && (end < 0 || q < end) {
+				// Skip the quoted key, which may contain a ']'
+				quote := src[pos+q]
+				i := pos + q + 1
+				for i < len(src) && src[i] != quote {
+					if src[i] == '\\' {
+						i++
+					}
+					i++
+				}
+				if i >= len(src) {
+					return 0, p.errAt(pos+q, "unterminated map key")
+				}
+				end = strings.IndexByte(src[i:], ']')
+				if end >= 0 {
+					end += i - pos
+				}
+			}
+			if end < 0 {
+				return 0, p.errAt(pos, "unterminated '['")
+			}
+			pos += end + 1
+		default:
+			return pos, nil
+		}
+	}
+	return pos, nil
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is the supplied operator
+func (p *parser) accept(op string) bool {
+	if tok := p.peek(); tok.kind == tokOp && tok.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.accept(op) {
+		tok := p.peek()
+		return p.errAt(tok.offset, "expected %q, found %s", op, tok)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if !p.accept("||") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "||", Left: left, Right: right, offset: tok.offset}
+	}
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if !p.accept("&&") {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "&&", Left: left, Right: right, offset: tok.offset}
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	tok := p.peek()
+	if p.accept("!") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{X: x, offset: tok.offset}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	switch {
+	case tok.kind == tokOp && comparisonOps[tok.text]:
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &Binary{Op: tok.text, Left: left, Right: right, offset: tok.offset}, nil
+	case tok.kind == tokIdent && tok.text == "in":
+		p.next()
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &Binary{Op: "in", Left: left, Right: list, offset: tok.offset}, nil
+	}
+	return left, nil
+}
+
+// comparisonOps are the comparison operators besides `in`
+var comparisonOps = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+}
+
+// parseList parses a non-empty list of literals
+func (p *parser) parseList() (*List, error) {
+	tok := p.peek()
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	list := &List{offset: tok.offset}
+	for {
+		elemTok := p.peek()
+		elem, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		lit, ok := elem.(*Literal)
+		if !ok {
+			return nil, p.errAt(elemTok.offset, "the elements of a list must be literals")
+		}
+		list.Elems = append(list.Elems, lit)
+		if p.accept("]") {
+			return list, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// functionArities are the number of arguments of each function
+var functionArities = map[string]int{
+	FuncExists: 1,
+	FuncEmpty:  1,
+	FuncLen:    1,
+	FuncAll:    1,
+	FuncAny:    1,
+}
+
+func (p *parser) parseOperand() (Node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokString:
+		return &Literal{Kind: LiteralString, Value: strconv.Quote(tok.text), offset: tok.offset}, nil
+	case tokInt:
+		return &Literal{Kind: LiteralInt, Value: tok.text, offset: tok.offset}, nil
+	case tokFloat:
+		return &Literal{Kind: LiteralFloat, Value: tok.text, offset: tok.offset}, nil
+	case tokIdent:
+		switch tok.text {
+		case "true", "false":
+			return &Literal{Kind: LiteralBool, Value: tok.text, offset: tok.offset}, nil
+		}
+		if p.accept("(") {
+			return p.parseCall(tok)
+		}
+		return p.parsePath(tok)
+	case tokPath:
+		return p.parsePath(tok)
+	case tokOp:
+		if tok.text == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return node, nil
+		}
+	}
+	return nil, p.errAt(tok.offset, "unexpected %s", tok)
+}
+
+func (p *parser) parseCall(name token) (Node, error) {
+	arity, ok := functionArities[name.text]
+	if !ok {
+		return nil, p.errAt(name.offset, "unknown function %q", name.text)
+	}
+	call := &Call{Func: name.text, offset: name.offset}
+	if !p.accept(")") {
+		for {
+			var arg Node
+			var err error
+			if name.text == FuncAll || name.text == FuncAny {
+				arg, err = p.parseOr()
+			} else {
+				argTok := p.peek()
+				arg, err = p.parseOperand()
+				if err == nil {
+					if _, isPath := arg.(*Path); !isPath {
+						return nil, p.errAt(argTok.offset, "the argument of %s() must be a field path", name.text)
+					}
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+			call.Args = append(call.Args, arg)
+			if p.accept(")") {
+				break
+			}
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(call.Args) != arity {
+		return nil, p.errAt(name.offset, "%s() takes %d argument(s), got %d", name.text, arity, len(call.Args))
+	}
+	return call, nil
+}
+
+func (p *parser) parsePath(tok token) (Node, error) {
+	fp, err := fieldpath.Parse(tok.text)
+	if err != nil {
+		if perr, ok := err.(*fieldpath.ParseError); ok {
+			return nil, p.errAt(tok.offset+perr.Offset, "%s", perr.Msg)
+		}
+		return nil, p.errAt(tok.offset, "%s", err)
+	}
+	// The deprecated `Users..Password` notation is only accepted in the field
+	// paths of existing configuration options, not in expressions.
+	if fieldpath.UsesLegacyNotation(tok.text) {
+		offset := tok.offset + strings.Index(tok.text, "..") + 1
+		return nil, p.errAt(offset, "empty field name, use [*] to address the elements of a list")
+	}
+	return &Path{Path: fp, offset: tok.offset}, nil
+}
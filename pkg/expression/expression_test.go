@@ -0,0 +1,172 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/expression"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		subject    string
+		wantString string
+		wantOffset int
+		wantErr    string
+	}{
+		{
+			name:       "comparison",
+			subject:    `Status.Status == "available"`,
+			wantString: `(Status.Status == "available")`,
+		},
+		{
+			name:       "single-quoted string is normalized",
+			subject:    `Status.Status != 'it\'s'`,
+			wantString: `(Status.Status != "it's")`,
+		},
+		{
+			name:       "precedence of boolean operators",
+			subject:    "Status.A == 1 || Status.B >= 2.5 && !exists(Status.C)",
+			wantString: "((Status.A == 1) || ((Status.B >= 2.5) && !exists(Status.C)))",
+		},
+		{
+			name:       "parentheses",
+			subject:    "(Status.A == 1 || Status.B < -2) && Spec.Enabled",
+			wantString: "(((Status.A == 1) || (Status.B < -2)) && Spec.Enabled)",
+		},
+		{
+			name:       "in list",
+			subject:    `Status.State in ["ACTIVE", 'AVAILABLE']`,
+			wantString: `(Status.State in ["ACTIVE", "AVAILABLE"])`,
+		},
+		{
+			name:       "in list without space",
+			subject:    `Status.State in["ACTIVE"]`,
+			wantString: `(Status.State in ["ACTIVE"])`,
+		},
+		{
+			name:       "functions",
+			subject:    "empty(Status.PendingModifiedValues) && len(Spec.Tags) > 0",
+			wantString: "(empty(Status.PendingModifiedValues) && (len(Spec.Tags) > 0))",
+		},
+		{
+			name:       "quantifier over a wildcard",
+			subject:    `all(Status.Replicas[*].ReplicaStatus == "ACTIVE")`,
+			wantString: `all((Status.Replicas[*].ReplicaStatus == "ACTIVE"))`,
+		},
+		{
+			name:       "map key containing a bracket",
+			subject:    `Spec.Tags["a]b"] == "c"`,
+			wantString: `(Spec.Tags["a]b"] == "c")`,
+		},
+		{
+			name:       "boolean literal",
+			subject:    "Spec.Enabled == false",
+			wantString: "(Spec.Enabled == false)",
+		},
+		{
+			name:       "empty expression",
+			subject:    "  ",
+			wantOffset: 0,
+			wantErr:    "empty expression",
+		},
+		{
+			name:       "unknown function",
+			subject:    "size(Spec.Tags) > 1",
+			wantOffset: 0,
+			wantErr:    `unknown function "size"`,
+		},
+		{
+			name:       "function arity",
+			subject:    "exists(Spec.A, Spec.B)",
+			wantOffset: 0,
+			wantErr:    "exists() takes 1 argument(s), got 2",
+		},
+		{
+			name:       "function argument must be a path",
+			subject:    `len("abc") > 1`,
+			wantOffset: 4,
+			wantErr:    "the argument of len() must be a field path",
+		},
+		{
+			name:       "invalid field path",
+			subject:    "Status.Replicas..State == 1",
+			wantOffset: 16,
+			wantErr:    "empty field name",
+		},
+		{
+			name:       "missing operand",
+			subject:    "Status.A ==",
+			wantOffset: 11,
+			wantErr:    "unexpected end of expression",
+		},
+		{
+			name:       "missing closing parenthesis",
+			subject:    "(Status.A == 1",
+			wantOffset: 14,
+			wantErr:    `expected ")"`,
+		},
+		{
+			name:       "in requires a list",
+			subject:    `Status.A in "ACTIVE"`,
+			wantOffset: 12,
+			wantErr:    `expected "["`,
+		},
+		{
+			name:       "list of paths",
+			subject:    "Status.A in [Status.B]",
+			wantOffset: 13,
+			wantErr:    "the elements of a list must be literals",
+		},
+		{
+			name:       "unterminated string",
+			subject:    `Status.A == "ACTIVE`,
+			wantOffset: 12,
+			wantErr:    "unterminated string",
+		},
+		{
+			name:       "unexpected character",
+			subject:    "Status.A = 1",
+			wantOffset: 9,
+			wantErr:    `unexpected character '='`,
+		},
+		{
+			name:       "trailing tokens",
+			subject:    "Status.A == 1 2",
+			wantOffset: 14,
+			wantErr:    `unexpected "2"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			node, err := expression.Parse(tt.subject)
+			if tt.wantErr != "" {
+				require.Error(err)
+				require.Contains(err.Error(), tt.wantErr)
+				var parseErr *expression.ParseError
+				require.ErrorAs(err, &parseErr)
+				require.Equal(tt.wantOffset, parseErr.Offset)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.wantString, node.String())
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fieldpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Wildcard is the part of a Path addressing every element of a list or
+	// every value of a map, e.g. "Status.Endpoints[*].Address"
+	Wildcard = "[*]"
+)
+
+// ParseError describes a syntax error in a field path
+type ParseError struct {
+	// Path is the field path that failed to parse
+	Path string
+	// Offset is the zero-based offset of the byte of Path at which the error
+	// was found
+	Offset int
+	// Msg describes the error
+	Msg string
+}
+
+// Error implements the error interface
+func (e *ParseError) Error() string {
+	return fmt.Sprintf(
+		"invalid field path %q at offset %d: %s", e.Path, e.Offset, e.Msg,
+	)
+}
+
+// Parse returns a new Path from a field path string. Field paths are
+// dot-separated field names, each of which may be followed by any number of
+// element selectors in square brackets:
+//
+//   - an index selecting a list element, e.g. "Spec.Rules[0].Priority"
+//   - a double- or single-quoted key selecting a map value, e.g.
+//     `Spec.Tags["env"]`
+//   - a wildcard selecting every list element or map value, e.g.
+//     "Status.Endpoints[*].Address"
+//
+// Selectors are separate parts of the returned Path. Keys are normalized to
+// their double-quoted Go form so that a selector part is also a valid Go index
+// expression.
+//
+// The legacy `Users..Password` notation for the members of list elements or
+// map values is deprecated but still accepted, and parsed as
+// `Users[*].Password`. See UsesLegacyNotation.
+func Parse(subject string) (*Path, error) {
+	p, _, err := parse(subject)
+	return p, err
+}
+
+// UsesLegacyNotation returns true if the supplied field path is valid and
+// uses the deprecated `Users..Password` notation, which Parse translates to
+// `Users[*].Password`.
+func UsesLegacyNotation(subject string) bool {
+	_, legacy, err := parse(subject)
+	return err == nil && legacy
+}
+
+// parse returns a new Path from a field path string, and true if the field
+// path uses the legacy `Users..Password` notation.
+func parse(subject string) (*Path, bool, error) {
+	p := &Path{parts: []string{}}
+	legacy := false
+	errAt := func(offset int, format string, args ...interface{}) error {
+		return &ParseError{
+			Path:   subject,
+			Offset: offset,
+			Msg:    fmt.Sprintf(format, args...),
+		}
+	}
+	if subject == "" {
+		return nil, false, errAt(0, "empty field path")
+	}
+	pos := 0
+	// expectName is true at the start of the path and after a dot
+	expectName := true
+	for pos < len(subject) {
+		if expectName {
+			start := pos
+			for pos < len(subject) && isNameByte(subject[pos]) {
+				pos++
+			}
+			if pos == start {
+				if pos < len(subject) && subject[pos] == '.' {
+					if len(p.parts) > 0 && !IsSelector(p.parts[len(p.parts)-1]) {
+						// Legacy `Users..Password` notation
+						p.parts = append(p.parts, Wildcard)
+						legacy = true
+						pos++
+						if pos == len(subject) {
+							return nil, false, errAt(pos, "expected field name")
+						}
+						continue
+					}
+					return nil, false, errAt(pos, "empty field name, use [*] to address the elements of a list")
+				}
+				if pos < len(subject) {
+					return nil, false, errAt(pos, "expected field name, found %q", subject[pos])
+				}
+				return nil, false, errAt(pos, "expected field name")
+			}
+			p.parts = append(p.parts, subject[start:pos])
+			expectName = false
+			continue
+		}
+		switch subject[pos] {
+		case '.':
+			pos++
+			expectName = true
+			if pos == len(subject) {
+				return nil, false, errAt(pos, "expected field name")
+			}
+		case '[':
+			part, end, err := parseSelector(subject, pos)
+			if err != nil {
+				return nil, false, err
+			}
+			p.parts = append(p.parts, part)
+			pos = end
+		default:
+			return nil, false, errAt(pos, "expected '.' or '[', found %q", subject[pos])
+		}
+	}
+	return p, legacy, nil
+}
+
+// parseSelector parses the element selector starting with the '[' at the
+// supplied offset of the supplied field path. It returns the normalized
+// selector part and the offset following its closing ']'.
+func parseSelector(subject string, start int) (string, int, error) {
+	errAt := func(offset int, format string, args ...interface{}) error {
+		return &ParseError{
+			Path:   subject,
+			Offset: offset,
+			Msg:    fmt.Sprintf(format, args...),
+		}
+	}
+	pos := start + 1
+	if pos == len(subject) {
+		return "", 0, errAt(start, "unterminated '['")
+	}
+	var part string
+	switch c := subject[pos]; {
+	case c == '*':
+		part = Wildcard
+		pos++
+	case c >= '0' && c <= '9':
+		digits := pos
+		for pos < len(subject) && subject[pos] >= '0' && subject[pos] <= '9' {
+			pos++
+		}
+		index, err := strconv.Atoi(subject[digits:pos])
+		if err != nil {
+			return "", 0, errAt(digits, "invalid index %q", subject[digits:pos])
+		}
+		part = "[" + strconv.Itoa(index) + "]"
+	case c == '"' || c == '\'':
+		var key strings.Builder
+		pos++
+		for {
+			if pos >= len(subject) {
+				return "", 0, errAt(start+1, "unterminated map key")
+			}
+			if subject[pos] == c {
+				pos++
+				break
+			}
+			if subject[pos] == '\\' && pos+1 < len(subject) {
+				pos++
+			}
+			key.WriteByte(subject[pos])
+			pos++
+		}
+		part = "[" + strconv.Quote(key.String()) + "]"
+	case c == ']':
+		return "", 0, errAt(pos, "empty selector, expected an index, a quoted map key or *")
+	default:
+		return "", 0, errAt(pos, "expected an index, a quoted map key or *, found %q", c)
+	}
+	if pos == len(subject) || subject[pos] != ']' {
+		return "", 0, errAt(pos, "expected ']'")
+	}
+	return part, pos + 1, nil
+}
+
+// isNameByte returns true if the supplied byte may be part of a field name
+func isNameByte(c byte) bool {
+	return c == '_' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
+
+// IsSelector returns true if the supplied Path part is an element selector
+// (an index, a map key or a wildcard) rather than a field name
+func IsSelector(part string) bool {
+	return strings.HasPrefix(part, "[") && strings.HasSuffix(part, "]")
+}
+
+// IsWildcard returns true if the supplied Path part selects every list
+// element or map value
+func IsWildcard(part string) bool {
+	return part == Wildcard
+}
+
+// Index returns the list index selected by the supplied Path part and true, or
+// -1 and false if the part is not an index selector
+func Index(part string) (int, bool) {
+	if !IsSelector(part) {
+		return -1, false
+	}
+	index, err := strconv.Atoi(part[1 : len(part)-1])
+	if err != nil || index < 0 {
+		return -1, false
+	}
+	return index, true
+}
+
+// Key returns the map key selected by the supplied Path part and true, or an
+// empty string and false if the part is not a key selector
+func Key(part string) (string, bool) {
+	if !IsSelector(part) {
+		return "", false
+	}
+	key, err := strconv.Unquote(part[1 : len(part)-1])
+	if err != nil {
+		return "", false
+	}
+	return key, true
+}
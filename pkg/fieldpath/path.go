@@ -27,9 +27,18 @@ type Path struct {
 	parts []string
 }
 
-// String returns the dotted-notation representation of the Path
+// String returns the dotted-notation representation of the Path. Element
+// selectors are appended to the preceding part without a dot, e.g.
+// "Spec.Rules[0].Priority".
 func (p *Path) String() string {
-	return strings.Join(p.parts, ".")
+	var b strings.Builder
+	for idx, part := range p.parts {
+		if idx > 0 && !IsSelector(part) {
+			b.WriteString(".")
+		}
+		b.WriteString(part)
+	}
+	return b.String()
 }
 
 // MarshalJSON returns the JSON encoding of a Path object.
@@ -175,6 +184,9 @@ func (p *Path) Size() int {
 //	    Type: "string",
 //	  },
 //	},
+//
+// Element selector parts (e.g. "[0]", `["env"]` or "[*]") match the member
+// ShapeRef of a list shape or the value ShapeRef of a map shape.
 func (p *Path) ShapeRef(
 	subject *awssdkmodel.ShapeRef,
 ) *awssdkmodel.ShapeRef {
@@ -194,7 +206,12 @@ func (p *Path) ShapeRef(
 	// ShapeRef for a member ShapeRef matching each path element.
 	for !cp.Empty() {
 		cur = cp.PopFront()
-		if compare = memberShapeRef(compare, cur); compare == nil {
+		if IsSelector(cur) {
+			compare = elementShapeRef(compare, cur)
+		} else {
+			compare = memberShapeRef(compare, cur)
+		}
+		if compare == nil {
 			return nil
 		}
 	}
@@ -261,6 +278,31 @@ func memberShapeRef(
 	return nil
 }
 
+// elementShapeRef returns the ShapeRef of the list element or map value
+// selected by the supplied element selector part, or nil if the selector
+// does not apply to the supplied ShapeRef (e.g. an index into a map)
+func elementShapeRef(
+	shapeRef *awssdkmodel.ShapeRef,
+	selector string,
+) *awssdkmodel.ShapeRef {
+	if shapeRef.Shape == nil {
+		return nil
+	}
+	_, isIndex := Index(selector)
+	_, isKey := Key(selector)
+	switch shapeRef.Shape.Type {
+	case "list":
+		if isIndex || IsWildcard(selector) {
+			return &shapeRef.Shape.MemberRef
+		}
+	case "map":
+		if isKey || IsWildcard(selector) {
+			return &shapeRef.Shape.ValueRef
+		}
+	}
+	return nil
+}
+
 // HasPrefix returns true if the supplied string, delimited on ".", matches
 // p.parts up to the length of the supplied string.
 // e.g. if the Path p represents "A.B":
@@ -271,7 +313,7 @@ func memberShapeRef(
 //	subject "B" -> false
 //	subject "A.C" -> false
 func (p *Path) HasPrefix(subject string) bool {
-	subjectSplit := strings.Split(subject, ".")
+	subjectSplit := FromString(subject).parts
 
 	if len(subjectSplit) > len(p.parts) {
 		return false
@@ -288,7 +330,7 @@ func (p *Path) HasPrefix(subject string) bool {
 
 // HasPrefixFold is the same as HasPrefix but uses case-insensitive comparisons
 func (p *Path) HasPrefixFold(subject string) bool {
-	subjectSplit := strings.Split(subject, ".")
+	subjectSplit := FromString(subject).parts
 
 	if len(subjectSplit) > len(p.parts) {
 		return false
@@ -304,7 +346,14 @@ func (p *Path) HasPrefixFold(subject string) bool {
 }
 
 // FromString returns a new Path from a dotted-notation string, e.g.
-// "Author.Name".
+// "Author.Name" or "Author.Books[*].Title". See Parse for the syntax of field
+// paths.
+//
+// FromString never fails: strings that are not valid field paths, and the
+// legacy `Users..Password` notation, are split on "." instead.
 func FromString(dotted string) *Path {
+	if p, legacy, err := parse(dotted); err == nil && !legacy {
+		return p
+	}
 	return &Path{strings.Split(dotted, ".")}
 }
@@ -234,3 +234,286 @@ func TestShapeRef(t *testing.T) {
 	require.Equal("WeirdlycasEdType", ref.ShapeName)
 	require.Equal("string", ref.Shape.Type)
 }
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		subject    string
+		wantParts  []string
+		wantString string
+		wantOffset int
+		wantErr    string
+	}{
+		{
+			name:       "dotted field names",
+			subject:    "Spec.Name",
+			wantParts:  []string{"Spec", "Name"},
+			wantString: "Spec.Name",
+		},
+		{
+			name:       "list index",
+			subject:    "Spec.Rules[0].Priority",
+			wantParts:  []string{"Spec", "Rules", "[0]", "Priority"},
+			wantString: "Spec.Rules[0].Priority",
+		},
+		{
+			name:       "double-quoted map key",
+			subject:    `Spec.Tags["env"]`,
+			wantParts:  []string{"Spec", "Tags", `["env"]`},
+			wantString: `Spec.Tags["env"]`,
+		},
+		{
+			name:       "single-quoted map key is normalized",
+			subject:    `Spec.Tags['my "env"']`,
+			wantParts:  []string{"Spec", "Tags", `["my \"env\""]`},
+			wantString: `Spec.Tags["my \"env\""]`,
+		},
+		{
+			name:       "map key containing dots and brackets",
+			subject:    `Spec.Tags["a.b[0]"]`,
+			wantParts:  []string{"Spec", "Tags", `["a.b[0]"]`},
+			wantString: `Spec.Tags["a.b[0]"]`,
+		},
+		{
+			name:       "wildcard",
+			subject:    "Status.Endpoints[*].Address",
+			wantParts:  []string{"Status", "Endpoints", "[*]", "Address"},
+			wantString: "Status.Endpoints[*].Address",
+		},
+		{
+			name:       "consecutive selectors",
+			subject:    "Spec.Matrix[1][*]",
+			wantParts:  []string{"Spec", "Matrix", "[1]", "[*]"},
+			wantString: "Spec.Matrix[1][*]",
+		},
+		{
+			name:       "empty path",
+			subject:    "",
+			wantOffset: 0,
+			wantErr:    "empty field path",
+		},
+		{
+			name:       "legacy double dot",
+			subject:    "Spec.Users..Password",
+			wantParts:  []string{"Spec", "Users", "[*]", "Password"},
+			wantString: "Spec.Users[*].Password",
+		},
+		{
+			name:       "legacy double dot after selector",
+			subject:    "Spec.Users[0]..Password",
+			wantOffset: 14,
+			wantErr:    "use [*] to address the elements of a list",
+		},
+		{
+			name:       "trailing legacy double dot",
+			subject:    "Spec.Users..",
+			wantOffset: 12,
+			wantErr:    "expected field name",
+		},
+		{
+			name:       "leading dot",
+			subject:    ".Spec",
+			wantOffset: 0,
+			wantErr:    "use [*] to address the elements of a list",
+		},
+		{
+			name:       "trailing dot",
+			subject:    "Spec.Name.",
+			wantOffset: 10,
+			wantErr:    "expected field name",
+		},
+		{
+			name:       "leading selector",
+			subject:    "[0].Name",
+			wantOffset: 0,
+			wantErr:    `expected field name, found '['`,
+		},
+		{
+			name:       "unterminated selector",
+			subject:    "Spec.Rules[0",
+			wantOffset: 12,
+			wantErr:    "expected ']'",
+		},
+		{
+			name:       "empty selector",
+			subject:    "Spec.Rules[].Priority",
+			wantOffset: 11,
+			wantErr:    "empty selector",
+		},
+		{
+			name:       "unquoted map key",
+			subject:    "Spec.Tags[env]",
+			wantOffset: 10,
+			wantErr:    "expected an index, a quoted map key or *",
+		},
+		{
+			name:       "unterminated map key",
+			subject:    `Spec.Tags["env]`,
+			wantOffset: 10,
+			wantErr:    "unterminated map key",
+		},
+		{
+			name:       "missing dot after selector",
+			subject:    "Spec.Rules[0]Priority",
+			wantOffset: 13,
+			wantErr:    "expected '.' or '['",
+		},
+		{
+			name:       "invalid field name character",
+			subject:    "Spec.Na-me",
+			wantOffset: 7,
+			wantErr:    "expected '.' or '['",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			p, err := fieldpath.Parse(tt.subject)
+			if tt.wantErr != "" {
+				require.Error(err)
+				require.Contains(err.Error(), tt.wantErr)
+				var parseErr *fieldpath.ParseError
+				require.ErrorAs(err, &parseErr)
+				require.Equal(tt.wantOffset, parseErr.Offset)
+				return
+			}
+			require.NoError(err)
+			require.Equal(len(tt.wantParts), p.Size())
+			for idx, part := range tt.wantParts {
+				require.Equal(part, p.At(idx))
+			}
+			require.Equal(tt.wantString, p.String())
+		})
+	}
+}
+
+func TestUsesLegacyNotation(t *testing.T) {
+	require := require.New(t)
+
+	require.True(fieldpath.UsesLegacyNotation("Spec.Users..Password"))
+	require.False(fieldpath.UsesLegacyNotation("Spec.Users[*].Password"))
+	require.False(fieldpath.UsesLegacyNotation(`Spec.Tags["a..b"]`))
+	require.False(fieldpath.UsesLegacyNotation("Spec.Users.."))
+}
+
+func TestFromString_Legacy(t *testing.T) {
+	require := require.New(t)
+
+	// The legacy double dot notation is not a valid field path, but
+	// FromString still splits it on dots.
+	p := fieldpath.FromString("Users..Password")
+	require.Equal(3, p.Size())
+	require.Equal("", p.At(1))
+	require.Equal("Users..Password", p.String())
+
+	p = fieldpath.FromString("Spec.Rules[0].Priority")
+	require.Equal(4, p.Size())
+	require.Equal("[0]", p.At(2))
+	require.True(p.HasPrefix("Spec.Rules[0]"))
+	require.False(p.HasPrefix("Spec.Rules[1]"))
+}
+
+func TestSelectors(t *testing.T) {
+	require := require.New(t)
+
+	require.True(fieldpath.IsSelector("[0]"))
+	require.True(fieldpath.IsSelector(`["env"]`))
+	require.True(fieldpath.IsSelector(fieldpath.Wildcard))
+	require.False(fieldpath.IsSelector("Name"))
+
+	index, ok := fieldpath.Index("[3]")
+	require.True(ok)
+	require.Equal(3, index)
+	_, ok = fieldpath.Index(`["3"]`)
+	require.False(ok)
+	_, ok = fieldpath.Index(fieldpath.Wildcard)
+	require.False(ok)
+
+	key, ok := fieldpath.Key(`["a\"b"]`)
+	require.True(ok)
+	require.Equal(`a"b`, key)
+	_, ok = fieldpath.Key("[3]")
+	require.False(ok)
+
+	require.True(fieldpath.IsWildcard("[*]"))
+	require.False(fieldpath.IsWildcard("[0]"))
+}
+
+func TestShapeRef_Selectors(t *testing.T) {
+	require := require.New(t)
+
+	authShapeRef := &awssdkmodel.ShapeRef{
+		ShapeName: "Author",
+		Shape: &awssdkmodel.Shape{
+			Type: "structure",
+			MemberRefs: map[string]*awssdkmodel.ShapeRef{
+				"Books": &awssdkmodel.ShapeRef{
+					ShapeName: "BookList",
+					Shape: &awssdkmodel.Shape{
+						Type: "list",
+						MemberRef: awssdkmodel.ShapeRef{
+							ShapeName: "Book",
+							Shape: &awssdkmodel.Shape{
+								Type: "structure",
+								MemberRefs: map[string]*awssdkmodel.ShapeRef{
+									"ChapterPageCounts": &awssdkmodel.ShapeRef{
+										ShapeName: "ChapterPageCounts",
+										Shape: &awssdkmodel.Shape{
+											Type: "map",
+											KeyRef: awssdkmodel.ShapeRef{
+												ShapeName: "ChapterTitle",
+												Shape: &awssdkmodel.Shape{
+													Type: "string",
+												},
+											},
+											ValueRef: awssdkmodel.ShapeRef{
+												ShapeName: "PageCount",
+												Shape: &awssdkmodel.Shape{
+													Type: "integer",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	p := fieldpath.FromString("Author.Books[0]")
+	ref := p.ShapeRef(authShapeRef)
+	require.NotNil(ref)
+	require.Equal("Book", ref.ShapeName)
+
+	p = fieldpath.FromString(`Author.Books[*].ChapterPageCounts["Intro"]`)
+	ref = p.ShapeRef(authShapeRef)
+	require.NotNil(ref)
+	require.Equal("PageCount", ref.ShapeName)
+
+	p = fieldpath.FromString("Author.Books[*].ChapterPageCounts[*]")
+	ref = p.ShapeRef(authShapeRef)
+	require.NotNil(ref)
+	require.Equal("PageCount", ref.ShapeName)
+
+	refs := p.IterShapeRefs(authShapeRef)
+	require.Len(refs, 5)
+	require.Equal("Author", refs[0].ShapeName)
+	require.Equal("BookList", refs[1].ShapeName)
+	require.Equal("Book", refs[2].ShapeName)
+	require.Equal("ChapterPageCounts", refs[3].ShapeName)
+	require.Equal("PageCount", refs[4].ShapeName)
+
+	// A map key cannot select a list element, and an index cannot select a
+	// map value
+	p = fieldpath.FromString(`Author.Books["Intro"]`)
+	require.Nil(p.ShapeRef(authShapeRef))
+	p = fieldpath.FromString("Author.Books[0].ChapterPageCounts[0]")
+	require.Nil(p.ShapeRef(authShapeRef))
+	// Nor can any selector apply to a structure
+	p = fieldpath.FromString("Author[*]")
+	require.Nil(p.ShapeRef(authShapeRef))
+}
@@ -39,7 +39,8 @@ var (
 	}
 	apisCopyPaths = []string{}
 	apisFuncMap   = ttpl.FuncMap{
-		"Join": strings.Join,
+		"Join":    strings.Join,
+		"ToLower": strings.ToLower,
 	}
 )
 
@@ -80,7 +81,10 @@ func APIs(
 		apisFuncMap,
 	)
 
-	metaVars := m.MetaVars()
+	metaVars, err := m.MetaVars()
+	if err != nil {
+		return nil, err
+	}
 	apiVars := &templateAPIVars{
 		metaVars,
 		enumDefs,
@@ -93,10 +97,33 @@ func APIs(
 		}
 	}
 
+	// CRDs placed in another API group than the service's are generated in
+	// their own Go package, with their own copy of the shared type
+	// definitions, since the API group is a property of the Go package.
+	apiGroupCRDs := map[string]*ackmodel.CRD{}
+	for _, crd := range crds {
+		if pkg := crd.APIGroupPackage(); pkg != "" {
+			apiGroupCRDs[pkg] = crd
+		}
+	}
+	for _, pkg := range m.APIGroupPackages() {
+		pkgAPIVars := &templateAPIVars{
+			crdMetaVars(metaVars, apiGroupCRDs[pkg]),
+			enumDefs,
+			typeDefs,
+		}
+		for _, path := range apisTemplatePaths {
+			outPath := filepath.Join(pkg, strings.TrimSuffix(filepath.Base(path), ".tpl"))
+			if err = ts.Add(outPath, path, pkgAPIVars); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	for _, crd := range crds {
-		crdFileName := strcase.ToSnake(crd.Kind) + ".go"
+		crdFileName := filepath.Join(crd.APIGroupPackage(), strcase.ToSnake(crd.Kind)+".go")
 		crdVars := &templateCRDVars{
-			metaVars,
+			crdMetaVars(metaVars, crd),
 			m.SDKAPI,
 			crd,
 		}
@@ -124,3 +151,14 @@ type templateCRDVars struct {
 	SDKAPI *ackmodel.SDKAPI
 	CRD    *ackmodel.CRD
 }
+
+// crdMetaVars returns a copy of the supplied MetaVars for the templates of a
+// single CRD, with the APIGroup set to the CRD's API group
+func crdMetaVars(
+	metaVars templateset.MetaVars,
+	crd *ackmodel.CRD,
+) templateset.MetaVars {
+	crdMetaVars := metaVars
+	crdMetaVars.APIGroup = crd.APIGroup()
+	return crdMetaVars
+}
@@ -49,14 +49,20 @@ var (
 	controllerIncludePaths = []string{
 		"boilerplate.go.tpl",
 		"pkg/resource/references_read_referenced_resource.go.tpl",
+		"pkg/resource/references_read_referenced_object.go.tpl",
+		"pkg/resource/sdk_child_collections.go.tpl",
+		"pkg/resource/sdk_tag_sync.go.tpl",
 		"pkg/resource/sdk_delete_custom.go.tpl",
 		"pkg/resource/sdk_find_custom.go.tpl",
 		"pkg/resource/sdk_find_read_one.go.tpl",
 		"pkg/resource/sdk_find_get_attributes.go.tpl",
 		"pkg/resource/sdk_find_read_many.go.tpl",
 		"pkg/resource/sdk_find_not_implemented.go.tpl",
+		"pkg/resource/sdk_observe_only.go.tpl",
+		"pkg/resource/sdk_replace.go.tpl",
 		"pkg/resource/sdk_update.go.tpl",
 		"pkg/resource/sdk_update_custom.go.tpl",
+		"pkg/resource/sdk_update_operations.go.tpl",
 		"pkg/resource/sdk_update_set_attributes.go.tpl",
 		"pkg/resource/sdk_update_not_implemented.go.tpl",
 	}
@@ -88,6 +94,12 @@ var (
 		"GoCodeSetExceptionMessageCheck": func(r *ackmodel.CRD, httpStatusCode int) string {
 			return code.CheckExceptionMessage(r.Config(), r, httpStatusCode)
 		},
+		"GoCodeExceptionGoType": func(r *ackmodel.CRD, op *awssdkmodel.Operation, exceptionCode string) string {
+			return code.ExceptionGoType(r, op, exceptionCode)
+		},
+		"GoCodeExceptionCodeCheck": func(r *ackmodel.CRD, op *awssdkmodel.Operation, exceptionCode string, excVarName string) string {
+			return code.CheckExceptionCode(r, op, exceptionCode, excVarName)
+		},
 		"GoCodeSetReadOneOutput": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
 			return code.SetResource(r.Config(), r, ackmodel.OpTypeGet, sourceVarName, targetVarName, indentLevel)
 		},
@@ -121,6 +133,45 @@ var (
 		"GoCodeSetUpdateInput": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
 			return code.SetSDK(r.Config(), r, ackmodel.OpTypeUpdate, sourceVarName, targetVarName, indentLevel)
 		},
+		"GoCodeSetUpdateOperationInput": func(r *ackmodel.CRD, op *awssdkmodel.Operation, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
+			return code.SetSDKForUpdateOperation(r.Config(), r, op, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeSetUpdateOperationOutput": func(r *ackmodel.CRD, op *awssdkmodel.Operation, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
+			return code.SetResourceForUpdateOperation(r.Config(), r, op, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeUpdateOperations": func(r *ackmodel.CRD, desiredVarName string, deltaVarName string, koVarName string, indentLevel int) (string, error) {
+			return code.UpdateOperations(r, desiredVarName, deltaVarName, koVarName, indentLevel)
+		},
+		"GoCodeChildCollectionSync": func(r *ackmodel.CRD, cc *ackmodel.ChildCollection, desiredVarName string, latestVarName string, indentLevel int) string {
+			return code.ChildCollectionSync(r.Config(), r, cc, desiredVarName, latestVarName, indentLevel)
+		},
+		"GoCodeSetChildOperationInput": func(r *ackmodel.CRD, cc *ackmodel.ChildCollection, op *awssdkmodel.Operation, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
+			return code.SetSDKForChildOperation(r.Config(), r, cc, op, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeSetChildCollectionReadInput": func(r *ackmodel.CRD, cc *ackmodel.ChildCollection, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
+			return code.SetSDKForChildCollectionRead(r.Config(), r, cc, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeChildCollectionRead": func(r *ackmodel.CRD, cc *ackmodel.ChildCollection, koVarName string, indentLevel int) (string, error) {
+			return code.ChildCollectionRead(r.Config(), r, cc, koVarName, indentLevel)
+		},
+		"GoCodeChildCollectionReads": func(r *ackmodel.CRD, koVarName string, indentLevel int) (string, error) {
+			return code.ChildCollectionReads(r, koVarName, indentLevel)
+		},
+		"GoCodeTagSyncUpdate": func(r *ackmodel.CRD, desiredVarName string, latestVarName string, indentLevel int) (string, error) {
+			return code.TagSyncUpdate(r.Config(), r, desiredVarName, latestVarName, indentLevel)
+		},
+		"GoCodeTagSyncRead": func(r *ackmodel.CRD, koVarName string, indentLevel int) (string, error) {
+			return code.TagSyncRead(r.Config(), r, koVarName, indentLevel)
+		},
+		"GoCodeTagSyncReads": func(r *ackmodel.CRD, koVarName string, indentLevel int) (string, error) {
+			return code.TagSyncReads(r, koVarName, indentLevel)
+		},
+		"GoCodeSetReplaceOutput": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
+			return code.SetResource(r.Config(), r, ackmodel.OpTypeReplace, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeSetReplaceInput": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
+			return code.SetSDK(r.Config(), r, ackmodel.OpTypeReplace, sourceVarName, targetVarName, indentLevel)
+		},
 		"GoCodeSetDeleteInput": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
 			return code.SetSDK(r.Config(), r, ackmodel.OpTypeDelete, sourceVarName, targetVarName, indentLevel)
 		},
@@ -155,9 +206,18 @@ var (
 		"HasPreDeleteSync": func(r *ackmodel.CRD) bool {
 			return code.HasPreDeleteSync(r.Config(), r)
 		},
+		"HasCompareNormalizers": func(r *ackmodel.CRD) bool {
+			return code.HasCompareNormalizers(r.Config(), r)
+		},
+		"HasCompareARNNormalizer": func(r *ackmodel.CRD) bool {
+			return code.HasCompareARNNormalizer(r.Config(), r)
+		},
 		"GoCodeIsSynced": func(r *ackmodel.CRD, resVarName string, indentLevel int) (string, error) {
 			return code.ResourceIsSynced(r.Config(), r, resVarName, indentLevel)
 		},
+		"GoCodeResourceRequeueAfter": func(r *ackmodel.CRD, resVarName string, indentLevel int) (string, error) {
+			return code.ResourceRequeueAfter(r.Config(), r, resVarName, indentLevel)
+		},
 		"GoCodeResourceIsUpdateable": func(r *ackmodel.CRD, resVarName string, indentLevel int) (string, error) {
 			return code.ResourceIsUpdateable(r.Config(), r, resVarName, indentLevel)
 		},
@@ -170,6 +230,21 @@ var (
 		"GoCodeCustomSyncCreate": func(r *ackmodel.CRD, koVarName string, indentLevel int) string {
 			return code.CustomSyncCreate(r, koVarName, indentLevel)
 		},
+		"GoCodeSetAsyncOperationID": func(r *ackmodel.CRD, opType string, op *awssdkmodel.Operation, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
+			return code.SetAsyncOperationID(r, opType, op, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeAsyncOperationsInProgress": func(r *ackmodel.CRD, resVarName string, indentLevel int) (string, error) {
+			return code.AsyncOperationsInProgress(r, resVarName, indentLevel)
+		},
+		"GoCodeAsyncOperationsReads": func(r *ackmodel.CRD, koVarName string, indentLevel int) string {
+			return code.AsyncOperationsReads(r, koVarName, indentLevel)
+		},
+		"GoCodeAsyncOperationsCompleted": func(r *ackmodel.CRD, resVarName string, indentLevel int) (string, error) {
+			return code.AsyncOperationsCompleted(r, resVarName, indentLevel)
+		},
+		"GoCodePreDeleteUpdates": func(r *ackmodel.CRD, koVarName string, updatedVarName string, indentLevel int) (string, error) {
+			return code.PreDeleteUpdates(r, koVarName, updatedVarName, indentLevel)
+		},
 		"GoCodeCompareStruct": func(r *ackmodel.CRD, shape *awssdkmodel.Shape, deltaVarName string, sourceVarName string, targetVarName string, fieldPath string, indentLevel int) (string, error) {
 			return code.CompareStruct(r.Config(), r, nil, shape, deltaVarName, sourceVarName, targetVarName, fieldPath, indentLevel)
 		},
@@ -191,6 +266,9 @@ var (
 		"GoCodeSetResourceIdentifiers": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
 			return code.SetResourceIdentifiers(r.Config(), r, sourceVarName, targetVarName, indentLevel)
 		},
+		"GoCodeSetResourceARNFromTemplate": func(r *ackmodel.CRD, targetVarName string, indentLevel int) (string, error) {
+			return code.SetResourceARNFromTemplate(r.Config(), r, targetVarName, indentLevel)
+		},
 		"GoCodePopulateResourceFromAnnotation": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) (string, error) {
 			return code.PopulateResourceFromAnnotation(r.Config(), r, sourceVarName, targetVarName, indentLevel)
 		},
@@ -253,14 +331,28 @@ func Controller(
 	}
 	util.Tracef("GetCRDs (%d CRDs): %s\n", len(crds), time.Since(crdStart))
 
+	// Resources in a reference cycle never sync unless every reference of
+	// the cycle skips resource state validations. Cycles only fail the
+	// `ack-generate graph` command, existing controllers keep generating.
+	refGraph, err := m.GetReferenceGraph()
+	if err != nil {
+		return nil, err
+	}
+	if err := refGraph.ValidateCycles(); err != nil {
+		util.Warnf("%s\n", err)
+	}
+
 	tplStart := time.Now()
-	metaVars := m.MetaVars()
+	metaVars, err := m.MetaVars()
+	if err != nil {
+		return nil, err
+	}
 
 	// Hook code can reference a template path, and we can look up the template
 	// in any of our base paths...
 	controllerFuncMap["Hook"] = func(r *ackmodel.CRD, hookID string) (string, error) {
 		crdVars := &templateCRDVars{
-			metaVars,
+			crdMetaVars(metaVars, r),
 			m.SDKAPI,
 			r,
 		}
@@ -299,7 +391,7 @@ func Controller(
 			outPath := filepath.Join("pkg/resource", crd.Names.Snake, strings.TrimSuffix(target, ".tpl"))
 			tplPath := filepath.Join("pkg/resource", target)
 			crdVars := &templateCRDVars{
-				metaVars,
+				crdMetaVars(metaVars, crd),
 				m.SDKAPI,
 				crd,
 			}
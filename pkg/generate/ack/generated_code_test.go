@@ -0,0 +1,155 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ack_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/ack"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+// renderResourceFiles renders the controller for the supplied service and
+// generator config and returns the contents of the files generated for the
+// supplied resource, keyed by file name, e.g. "sdk.go".
+func renderResourceFiles(
+	t *testing.T,
+	serviceAlias string,
+	generatorConfigFile string,
+	resourceDir string,
+) map[string]string {
+	t.Helper()
+	require := require.New(t)
+
+	m := testutil.NewModelForServiceWithOptions(t, serviceAlias, &testutil.TestingModelOptions{
+		GeneratorConfigFile: generatorConfigFile,
+	})
+	wd, err := os.Getwd()
+	require.NoError(err)
+	ts, err := ack.Controller(m, []string{filepath.Join(wd, "..", "..", "..", "templates")}, "ack-controller")
+	require.NoError(err)
+	require.NoError(ts.Execute())
+
+	files := map[string]string{}
+	for path, buf := range ts.Executed() {
+		if filepath.Dir(path) == filepath.Join("pkg", "resource", resourceDir) {
+			files[filepath.Base(path)] = buf.String()
+		}
+	}
+	require.NotEmpty(files)
+	return files
+}
+
+// goSource is a parsed generated Go file
+type goSource struct {
+	src  string
+	fset *token.FileSet
+	file *ast.File
+}
+
+func parseGoSource(t *testing.T, src string) *goSource {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "generated.go", src, parser.ParseComments)
+	require.NoError(t, err)
+	return &goSource{src: src, fset: fset, file: file}
+}
+
+// text returns the source code of the supplied node
+func (s *goSource) text(from, to token.Pos) string {
+	return s.src[s.fset.Position(from).Offset:s.fset.Position(to).Offset]
+}
+
+// decls returns the source code, doc comments included, of the top-level
+// functions, methods, constants and variables with the supplied names
+func (s *goSource) decls(t *testing.T, names ...string) string {
+	t.Helper()
+	out := ""
+	for _, name := range names {
+		found := false
+		for _, decl := range s.file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Name.Name != name {
+					continue
+				}
+				from := d.Pos()
+				if d.Doc != nil {
+					from = d.Doc.Pos()
+				}
+				out += s.text(from, d.End()) + "\n\n"
+				found = true
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					vs, ok := spec.(*ast.ValueSpec)
+					if !ok || vs.Names[0].Name != name {
+						continue
+					}
+					from := d.Pos()
+					if d.Doc != nil {
+						from = d.Doc.Pos()
+					}
+					out += s.text(from, d.End()) + "\n\n"
+					found = true
+				}
+			}
+		}
+		require.True(t, found, "declaration %s not found in generated code", name)
+	}
+	return out
+}
+
+// stmt returns the source code of the first top-level statement of the body
+// of the supplied function or method containing the supplied text
+func (s *goSource) stmt(t *testing.T, funcName string, contains string) string {
+	t.Helper()
+	for _, decl := range s.file.Decls {
+		d, ok := decl.(*ast.FuncDecl)
+		if !ok || d.Name.Name != funcName || d.Body == nil {
+			continue
+		}
+		for _, stmt := range d.Body.List {
+			if text := s.text(stmt.Pos(), stmt.End()); strings.Contains(text, contains) {
+				return text
+			}
+		}
+	}
+	require.Fail(t, "statement not found in generated code", "%s: %s", funcName, contains)
+	return ""
+}
+
+// runGoProgram compiles and runs the supplied main package and returns its
+// output. The program is run from this package, so it can import the modules
+// this module depends on, like the ACK runtime.
+func runGoProgram(t *testing.T, program string) string {
+	t.Helper()
+	goBin, err := exec.LookPath("go")
+	if testing.Short() || err != nil {
+		t.Skip("running the generated code requires the go toolchain")
+	}
+	mainFile := filepath.Join(t.TempDir(), "main.go")
+	require.NoError(t, os.WriteFile(mainFile, []byte(program), 0644))
+	out, err := exec.Command(goBin, "run", mainFile).CombinedOutput()
+	require.NoError(t, err, "%s\n%s", out, program)
+	return string(out)
+}
@@ -0,0 +1,140 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ack_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPreDeleteUpdates_RDS_DBCluster runs the generated pre-delete update code
+// through the sequence of calls the ACK runtime makes when deleting a
+// resource: the resource is read, then the observed resource is deleted.
+func TestPreDeleteUpdates_RDS_DBCluster(t *testing.T) {
+	assert := assert.New(t)
+
+	files := renderResourceFiles(
+		t, "rds", "generator-pre-delete-updates.yaml", "db_cluster",
+	)
+	sdk := parseGoSource(t, files["sdk.go"])
+
+	program := `package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type DBClusterSpec struct {
+	DeletionProtection *bool
+}
+
+type DBCluster struct {
+	metav1.ObjectMeta
+	Spec DBClusterSpec
+}
+
+func (in *DBCluster) DeepCopy() *DBCluster {
+	out := *in
+	return &out
+}
+
+type resource struct {
+	ko *DBCluster
+}
+
+type resourceManager struct{}
+
+func newResourceDelta(a *resource, b *resource) *ackcompare.Delta {
+	return ackcompare.NewDelta()
+}
+
+// The backend AWS service API: an update is only visible from the next read.
+var (
+	deletionProtection = true
+	pending            *bool
+	updates            int
+)
+
+func (rm *resourceManager) sdkFind(r *resource) *resource {
+	if pending != nil {
+		deletionProtection, pending = *pending, nil
+	}
+	ko := r.ko.DeepCopy()
+	ko.Spec.DeletionProtection = aws.Bool(deletionProtection)
+	return &resource{ko}
+}
+
+func (rm *resourceManager) sdkUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (*resource, error) {
+	updates++
+	pending = desired.ko.Spec.DeletionProtection
+	return &resource{desired.ko.DeepCopy()}, nil
+}
+
+` + sdk.decls(t, "allowPreDeleteUpdatesAnnotation", "preDeleteUpdate") + `
+func (rm *resourceManager) sdkDelete(ctx context.Context, r *resource) (latest *resource, err error) {
+	` + sdk.stmt(t, "sdkDelete", "rm.preDeleteUpdate") + `
+	if deletionProtection {
+		return nil, errors.New("deletion protection is enabled")
+	}
+	return nil, nil
+}
+
+// deleteResource mirrors the ACK runtime's deleteResource: the resource is
+// read, then the observed resource is deleted.
+func deleteResource(rm *resourceManager, current *resource) string {
+	observed := rm.sdkFind(current)
+	_, err := rm.sdkDelete(context.TODO(), observed)
+	var requeueNeededAfter *ackrequeue.RequeueNeededAfter
+	switch {
+	case err == nil:
+		return fmt.Sprintf("deleted, updates=%d", updates)
+	case errors.As(err, &requeueNeededAfter):
+		return fmt.Sprintf("requeued, updates=%d", updates)
+	default:
+		return fmt.Sprintf("error %q, updates=%d", err, updates)
+	}
+}
+
+func main() {
+	rm := &resourceManager{}
+	current := &resource{&DBCluster{}}
+
+	// The resource is not updated unless the annotation allows it.
+	fmt.Println(deleteResource(rm, current))
+	current.ko.Annotations = map[string]string{allowPreDeleteUpdatesAnnotation: "true"}
+	// The resource is deleted once it is read with the update applied.
+	fmt.Println(deleteResource(rm, current))
+	fmt.Println(deleteResource(rm, current))
+}
+`
+	out := runGoProgram(t, program)
+	assert.Equal(`error "deletion protection is enabled", updates=0
+requeued, updates=1
+deleted, updates=1
+`, out)
+}
@@ -0,0 +1,192 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ack_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReadAfterCreateGrace_Route53_HostedZone runs the generated
+// read-after-create grace code through the sequence of calls the ACK runtime
+// makes when a created resource cannot be read back: Create, ReadOne retried
+// while NotFound, the Status saved, then the next reconciliations.
+func TestReadAfterCreateGrace_Route53_HostedZone(t *testing.T) {
+	assert := assert.New(t)
+
+	files := renderResourceFiles(
+		t, "route53", "generator-read-after-create-grace.yaml", "hosted_zone",
+	)
+	// The program declares the API types itself.
+	manager := parseGoSource(t, strings.ReplaceAll(files["manager.go"], "svcapitypes.", ""))
+	sdk := parseGoSource(t, files["sdk.go"])
+
+	program := `package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/smithy-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type HostedZoneStatus struct {
+	CreatedAt *string
+	ID        *string
+}
+
+type HostedZone struct {
+	metav1.ObjectMeta
+	Status HostedZoneStatus
+}
+
+func (in *HostedZone) DeepCopy() *HostedZone {
+	out := *in
+	return &out
+}
+
+type resource struct {
+	ko *HostedZone
+}
+
+type resourceManager struct{}
+
+// The backend AWS service API: the created hosted zone can only be read once
+// visible is true, and reads return readErr until then.
+var (
+	creates int
+	visible bool
+	readErr error = &smithy.GenericAPIError{Code: "InvalidInput"}
+)
+
+` + manager.decls(t,
+		"readAfterCreateGraceRequeueAfter",
+		"setCreatedAt",
+		"withinReadAfterCreateGrace",
+		"readAfterCreateGrace",
+	) + sdk.decls(t, "readAfterCreateGraceAWSError") + `
+func (rm *resourceManager) sdkFind(r *resource) (latest *resource, err error) {
+	` + sdk.stmt(t, "sdkFind", "readAfterCreateGraceAWSError") + `
+	if r.ko.Status.ID == nil {
+		return nil, ackerr.NotFound
+	}
+	if !visible {
+		return nil, readErr
+	}
+	return &resource{r.ko.DeepCopy()}, nil
+}
+
+func (rm *resourceManager) sdkCreate(desired *resource) (created *resource, err error) {
+	` + sdk.stmt(t, "sdkCreate", "readAfterCreateGrace(desired)") + `
+	creates++
+	ko := desired.ko.DeepCopy()
+	id := fmt.Sprintf("Z%d", creates)
+	ko.Status.ID = &id
+	` + sdk.stmt(t, "sdkCreate", "setCreatedAt(ko)") + `
+	return &resource{ko}, nil
+}
+
+// sync mirrors the ACK runtime's Sync for a resource that cannot be found:
+// createResource calls Create then, while ReadOne returns NotFound,
+// delayedReadOneAfterCreate retries ReadOne. It returns the resource whose
+// Status HandleReconcileError saves.
+func sync(rm *resourceManager, desired *resource) (*resource, error) {
+	latest, err := rm.sdkFind(desired)
+	if err == nil {
+		return latest, nil
+	}
+	if err != ackerr.NotFound {
+		return desired, err
+	}
+	created, err := rm.sdkCreate(desired)
+	if err != nil {
+		return desired, err
+	}
+	for attempt := 0; attempt < 3; attempt++ {
+		if _, err = rm.sdkFind(created); err != ackerr.NotFound {
+			break
+		}
+	}
+	if err == ackerr.NotFound {
+		return created, ackerr.NewReadOneFailAfterCreate(3)
+	}
+	return created, err
+}
+
+// reconcile syncs the stored resource and saves its Status, like the ACK
+// runtime does even when the reconciliation fails.
+func reconcile(rm *resourceManager, stored *resource) string {
+	latest, err := sync(rm, &resource{stored.ko.DeepCopy()})
+	stored.ko.Status = latest.ko.Status
+	var requeueNeededAfter *ackrequeue.RequeueNeededAfter
+	switch {
+	case err == nil:
+		return fmt.Sprintf("synced, creates=%d", creates)
+	case errors.Is(err, ackerr.ReadOneFailedAfterCreate):
+		return fmt.Sprintf("not found after create, creates=%d", creates)
+	case errors.As(err, &requeueNeededAfter):
+		return fmt.Sprintf("requeued after %s, creates=%d", requeueNeededAfter.Duration(), creates)
+	default:
+		return fmt.Sprintf("error %q, creates=%d", err, creates)
+	}
+}
+
+func main() {
+	rm := &resourceManager{}
+	stored := &resource{&HostedZone{}}
+
+	// The created resource cannot be read back before the runtime gives up.
+	fmt.Println(reconcile(rm, stored))
+	fmt.Println(stored.ko.Status.CreatedAt != nil)
+	// The resource is not created again within the grace window.
+	fmt.Println(reconcile(rm, stored))
+	visible = true
+	fmt.Println(reconcile(rm, stored))
+
+	// Outside of the grace window, the error codes are errors, and a
+	// resource that cannot be found is created again.
+	visible = false
+	longAgo := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	stored.ko.Status.CreatedAt = &longAgo
+	fmt.Println(reconcile(rm, stored))
+
+	// Resources being deleted are never graced.
+	deleting := &resource{stored.ko.DeepCopy()}
+	justNow := time.Now().UTC().Format(time.RFC3339)
+	deleting.ko.Status.CreatedAt = &justNow
+	deleting.ko.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	_, err := rm.sdkFind(deleting)
+	fmt.Println(err != ackerr.NotFound, readAfterCreateGrace(deleting) == nil)
+
+	readErr = ackerr.NotFound
+	stored.ko.Status.CreatedAt = &longAgo
+	fmt.Println(reconcile(rm, stored))
+}
+`
+	out := runGoProgram(t, program)
+	assert.Equal(`not found after create, creates=1
+true
+requeued after 5s, creates=1
+synced, creates=1
+error "api error InvalidInput: ", creates=1
+true true
+not found after create, creates=2
+`, out)
+}
@@ -97,13 +97,16 @@ func Release(
 	// serviceAccountName is the name of the ServiceAccount used in the Helm chart
 	serviceAccountName string,
 ) (*templateset.TemplateSet, error) {
+	metaVars, err := m.MetaVars()
+	if err != nil {
+		return nil, err
+	}
 	ts := templateset.New(
 		templateBasePaths,
 		releaseIncludePaths,
 		releaseCopyPaths,
-		releaseFuncMap(m.MetaVars().ControllerName),
+		releaseFuncMap(metaVars.ControllerName),
 	)
-	metaVars := m.MetaVars()
 	// Using GetCRDs() directly gives us the proper CamelCase format
 	// that matches the Kubernetes API resource kinds. The previous approach using
 	// metaVars.CRDNames with names.New(name).Camel was incorrect because CRDNames
@@ -0,0 +1,183 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ack_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRequeueWhen_Lambda_Function runs the generated reconcile.requeue_when
+// code through the sequence of calls the ACK runtime makes when syncing a
+// resource in a transitional state: its conditions are cleared, it is read,
+// updated if needed, then late initialized, and the late initialized resource
+// is saved before it is requeued.
+func TestRequeueWhen_Lambda_Function(t *testing.T) {
+	assert := assert.New(t)
+
+	files := renderResourceFiles(
+		t, "lambda", "generator-requeue-when.yaml", "function",
+	)
+	// The program declares the runtime types it needs itself.
+	manager := parseGoSource(t, strings.ReplaceAll(files["manager.go"], "acktypes.", ""))
+
+	// ReadOne does not requeue resources, which would prevent the runtime
+	// from updating them.
+	assert.NotContains(manager.decls(t, "ReadOne"), "requeue")
+
+	program := `package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type FunctionStatus struct {
+	Conditions       []*ackv1alpha1.Condition
+	LastUpdateStatus *string
+	State            *string
+}
+
+type Function struct {
+	metav1.ObjectMeta
+	Status FunctionStatus
+}
+
+type AWSResource interface {
+	DeepCopy() AWSResource
+}
+
+type resource struct {
+	ko *Function
+}
+
+func (r *resource) DeepCopy() AWSResource {
+	ko := *r.ko
+	ko.Annotations = map[string]string{}
+	for k, v := range r.ko.Annotations {
+		ko.Annotations[k] = v
+	}
+	ko.Status.Conditions = append([]*ackv1alpha1.Condition{}, r.ko.Status.Conditions...)
+	return &resource{&ko}
+}
+
+func (r *resource) Conditions() []*ackv1alpha1.Condition {
+	return r.ko.Status.Conditions
+}
+
+func (r *resource) ReplaceConditions(conditions []*ackv1alpha1.Condition) {
+	r.ko.Status.Conditions = conditions
+}
+
+type resourceManager struct{}
+
+func (rm *resourceManager) concreteResource(res AWSResource) *resource {
+	return res.(*resource)
+}
+
+// The function has no fields to late initialize.
+func (rm *resourceManager) lateInitialize(
+	ctx context.Context,
+	latest AWSResource,
+) (AWSResource, error) {
+	return latest, nil
+}
+
+// The backend AWS service API
+var (
+	state            = "Pending"
+	lastUpdateStatus = "Successful"
+)
+
+` + manager.decls(t,
+		"LateInitialize",
+		"requeueBackoffSinceAnnotation",
+		"requeueWhen",
+		"requeueAfter",
+		"requeueBackoff",
+	) + `
+// reconcile mirrors the ACK runtime's reconciliation of a resource that
+// exists. The stored resource is replaced by the late initialized resource,
+// like the runtime patches its metadata, Spec and Status.
+func reconcile(rm *resourceManager, stored *resource) string {
+	desired := stored.DeepCopy().(*resource)
+	desired.ko.Status.Conditions = nil
+	latest := desired.DeepCopy().(*resource)
+	latest.ko.Status.State = &state
+	latest.ko.Status.LastUpdateStatus = &lastUpdateStatus
+	lateInitialized, err := rm.LateInitialize(context.TODO(), latest)
+	stored.ko = rm.concreteResource(lateInitialized).ko
+	var requeueNeededAfter *ackrequeue.RequeueNeededAfter
+	if errors.As(err, &requeueNeededAfter) {
+		synced := ackcondition.Synced(stored)
+		return fmt.Sprintf(
+			"requeued after %s, synced=%s",
+			requeueNeededAfter.Duration().Round(time.Second), synced.Status,
+		)
+	}
+	return "synced"
+}
+
+// since records that the stored resource started matching the rules the
+// supplied time ago.
+func since(stored *resource, ago time.Duration) {
+	stored.ko.Annotations[requeueBackoffSinceAnnotation] = time.Now().Add(-ago).UTC().Format(time.RFC3339)
+}
+
+func main() {
+	rm := &resourceManager{}
+	stored := &resource{&Function{}}
+
+	fmt.Println(reconcile(rm, stored))
+	_, found := stored.ko.Annotations[requeueBackoffSinceAnnotation]
+	fmt.Println(found)
+	fmt.Println(reconcile(rm, stored))
+	// The delay grows with the time elapsed since the resource started
+	// matching the rules, up to the maximum delay.
+	since(stored, 2*time.Minute)
+	fmt.Println(reconcile(rm, stored))
+	since(stored, time.Hour)
+	fmt.Println(reconcile(rm, stored))
+
+	state = "Active"
+	lastUpdateStatus = "InProgress"
+	fmt.Println(reconcile(rm, stored))
+
+	lastUpdateStatus = "Successful"
+	fmt.Println(reconcile(rm, stored))
+	_, found = stored.ko.Annotations[requeueBackoffSinceAnnotation]
+	fmt.Println(found)
+}
+`
+	out := runGoProgram(t, program)
+	assert.Equal(`requeued after 30s, synced=False
+true
+requeued after 30s, synced=False
+requeued after 2m0s, synced=False
+requeued after 5m0s, synced=False
+requeued after 10s, synced=False
+synced
+false
+`, out)
+}
@@ -0,0 +1,143 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/util"
+)
+
+// arnTemplateMetadataVars maps the metadata placeholders of an ARN template
+// to the fields of the resource manager holding their values.
+var arnTemplateMetadataVars = map[string]string{
+	"partition": "rm.awsPartition",
+	"region":    "rm.awsRegion",
+	"account":   "rm.awsAccountID",
+}
+
+// SetResourceARNFromTemplate returns the Go code that sets the ARN of a
+// resource from its `arn_template` when the ARN is not set, i.e. when the API
+// does not return it. The code runs once the resource is created or read,
+// whatever the shape of the output of the operation. It returns an empty
+// string if the resource has no `arn_template`, and an error if a placeholder
+// of the template is not a string field of the resource.
+//
+// Sample output:
+//
+//	if ko.Status.ACKResourceMetadata == nil {
+//		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+//	}
+//	if ko.Status.ACKResourceMetadata.ARN == nil && ko.Spec.Name != nil {
+//		arn := ackv1alpha1.AWSResourceName(fmt.Sprintf(
+//			"arn:%s:logs:%s:%s:log-group:%s",
+//			rm.awsPartition, rm.awsRegion, rm.awsAccountID, *ko.Spec.Name,
+//		))
+//		ko.Status.ACKResourceMetadata.ARN = &arn
+//	}
+func SetResourceARNFromTemplate(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// The variable name of the resource's Kubernetes object — "ko"
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	arnTemplate := r.ARNTemplate()
+	if arnTemplate == "" {
+		return "", nil
+	}
+	parts, err := ackgenconfig.ParseARNTemplate(arnTemplate)
+	if err != nil {
+		return "", fmt.Errorf("resource %q: %w", r.Names.Original, err)
+	}
+
+	conditions := []string{
+		fmt.Sprintf("%s.Status.ACKResourceMetadata.ARN == nil", targetVarName),
+	}
+	format := ""
+	args := []string{}
+	for i, part := range parts {
+		if i%2 == 0 {
+			format += strings.ReplaceAll(part, "%", "%%")
+			continue
+		}
+		format += "%s"
+		if metadataVar, found := arnTemplateMetadataVars[part]; found {
+			args = append(args, metadataVar)
+			continue
+		}
+		fieldVar, nilChecks, err := arnTemplateFieldVar(cfg, r, targetVarName, part)
+		if err != nil {
+			return "", fmt.Errorf(
+				"resource %q: arn_template %q: %w", r.Names.Original, arnTemplate, err,
+			)
+		}
+		for _, check := range nilChecks {
+			if !util.InStrings(check, conditions) {
+				conditions = append(conditions, check)
+			}
+		}
+		args = append(args, "*"+fieldVar)
+	}
+
+	indent := strings.Repeat("\t", indentLevel)
+	out := ackResourceMetadataGuardConstructor(
+		fmt.Sprintf("%s.Status", targetVarName), indentLevel,
+	)
+	// if ko.Status.ACKResourceMetadata.ARN == nil && ko.Spec.Name != nil {
+	out += fmt.Sprintf("%sif %s {\n", indent, strings.Join(conditions, " && "))
+	if len(args) == 0 {
+		out += fmt.Sprintf(
+			"%s\tarn := ackv1alpha1.AWSResourceName(%q)\n", indent, arnTemplate,
+		)
+	} else {
+		out += fmt.Sprintf("%s\tarn := ackv1alpha1.AWSResourceName(fmt.Sprintf(\n", indent)
+		out += fmt.Sprintf("%s\t\t%q,\n", indent, format)
+		out += fmt.Sprintf("%s\t\t%s,\n", indent, strings.Join(args, ", "))
+		out += fmt.Sprintf("%s\t))\n", indent)
+	}
+	out += fmt.Sprintf(
+		"%s\t%s.Status.ACKResourceMetadata.ARN = &arn\n", indent, targetVarName,
+	)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out, nil
+}
+
+// arnTemplateFieldVar returns the Go variable of the string field named by
+// the supplied `Spec.` or `Status.` placeholder of an ARN template, and the
+// nil checks of the field and of the structs containing it.
+func arnTemplateFieldVar(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	targetVarName string,
+	placeholder string,
+) (string, []string, error) {
+	field, err := r.ARNTemplateField(placeholder)
+	if err != nil {
+		return "", nil, err
+	}
+	topFieldName, _, _ := strings.Cut(field.Path, ".")
+	memberPath, _ := findFieldInCR(cfg, r, r.Fields[topFieldName].Names.Original)
+	fieldVar := targetVarName + memberPath
+	nilChecks := []string{}
+	for _, elem := range strings.Split(field.Path, ".") {
+		fieldVar += "." + elem
+		nilChecks = append(nilChecks, fieldVar+" != nil")
+	}
+	return fieldVar, nilChecks, nil
+}
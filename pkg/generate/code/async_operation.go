@@ -0,0 +1,301 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws-controllers-k8s/code-generator/pkg/api"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// SetAsyncOperationID returns Go code that stores the ID of the asynchronous
+// operation of the supplied type (Create, Update or Delete), read from the
+// response of the supplied SDK operation, in the Status field configured in
+// the resource's `async_operations`. It returns an empty string if the
+// operation completes synchronously.
+//
+// Sample output:
+//
+//	if resp.ChangeInfo != nil && resp.ChangeInfo.Id != nil {
+//		ko.Status.ChangeID = resp.ChangeInfo.Id
+//	}
+func SetAsyncOperationID(
+	r *model.CRD,
+	// the type of the operation: Create, Update or Delete
+	opType string,
+	// the SDK operation called for that type of operation, e.g. the
+	// resource's Replace operation or one of its update_operations
+	op *awssdkmodel.Operation,
+	// the variable name of the operation's response — "resp"
+	sourceVarName string,
+	// the variable name of the resource's Kubernetes object — "ko"
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	asyncOps, err := r.AsyncOperations()
+	if err != nil {
+		return "", err
+	}
+	for _, asyncOp := range asyncOps {
+		if asyncOp.OpType != opType || op == nil {
+			continue
+		}
+		idPath, found := asyncOp.IDPaths[op.ExportedName]
+		if !found {
+			continue
+		}
+		indent := strings.Repeat("\t", indentLevel)
+		checks := memberPathNilChecks(sourceVarName, idPath, true)
+		out := "\n"
+		// if resp.ChangeInfo != nil && resp.ChangeInfo.Id != nil {
+		out += fmt.Sprintf("%sif %s {\n", indent, strings.Join(checks, " && "))
+		// ko.Status.ChangeID = resp.ChangeInfo.Id
+		out += fmt.Sprintf(
+			"%s\t%s.Status.%s = %s.%s\n", indent, targetVarName,
+			asyncOp.StatusField.Names.Camel, sourceVarName,
+			strings.Join(idPath, "."),
+		)
+		out += fmt.Sprintf("%s}", indent)
+		return out, nil
+	}
+	return "", nil
+}
+
+// AsyncOperationsCompleted returns Go code that polls the status of the
+// asynchronous operations of a resource whose ID is stored in its Status. The
+// generated code returns false while an operation is in progress. The
+// operation ID is cleared once the operation has completed or failed, so the
+// code is only called, from sdkFind, on the copy of the resource sdkFind
+// returns. A failed operation is not an error of sdkFind: it sets a Terminal
+// condition on the resource instead, and the operation is attempted again on
+// the next reconciliation. Operations storing their ID in the same Status
+// field are polled once, with the config of the first of them.
+//
+// Sample output:
+//
+//	if r.ko.Status.ChangeID != nil {
+//		resp, err := rm.sdkapi.GetChange(ctx, &svcsdk.GetChangeInput{
+//			Id: r.ko.Status.ChangeID,
+//		})
+//		rm.metrics.RecordAPICall("READ_ONE", "GetChange", err)
+//		if err != nil {
+//			return false, err
+//		}
+//		if resp.ChangeInfo == nil {
+//			return false, nil
+//		}
+//		switch string(resp.ChangeInfo.Status) {
+//		case "INSYNC":
+//			r.ko.Status.ChangeID = nil
+//		case "FAILED":
+//			msg := fmt.Sprintf(
+//				"asynchronous operation %s failed with status %s",
+//				*r.ko.Status.ChangeID, string(resp.ChangeInfo.Status),
+//			)
+//			r.ko.Status.ChangeID = nil
+//			ackcondition.SetTerminal(r, corev1.ConditionTrue, &msg, nil)
+//		default:
+//			return false, nil
+//		}
+//	}
+func AsyncOperationsCompleted(
+	r *model.CRD,
+	// resource variable name — "r"
+	resVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	asyncOps, err := r.AsyncOperations()
+	if err != nil {
+		return "", err
+	}
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	polled := map[string]bool{}
+	for _, asyncOp := range asyncOps {
+		fieldName := asyncOp.StatusField.Names.Camel
+		if polled[fieldName] {
+			continue
+		}
+		polled[fieldName] = true
+		idVar := fmt.Sprintf("%s.ko.Status.%s", resVarName, fieldName)
+		statusOpName := asyncOp.StatusOp.ExportedName
+
+		// if r.ko.Status.ChangeID != nil {
+		out += fmt.Sprintf("%sif %s != nil {\n", indent, idVar)
+		// resp, err := rm.sdkapi.GetChange(ctx, &svcsdk.GetChangeInput{
+		out += fmt.Sprintf(
+			"%s\tresp, err := rm.sdkapi.%s(ctx, &svcsdk.%s{\n", indent,
+			statusOpName, asyncOp.StatusOp.InputRef.Shape.ShapeName,
+		)
+		// Id: r.ko.Status.ChangeID,
+		out += fmt.Sprintf("%s\t\t%s: %s,\n", indent, asyncOp.StatusIDMember, idVar)
+		out += fmt.Sprintf("%s\t})\n", indent)
+		out += fmt.Sprintf(
+			"%s\trm.metrics.RecordAPICall(\"READ_ONE\", %q, err)\n",
+			indent, statusOpName,
+		)
+		out += fmt.Sprintf("%s\tif err != nil {\n", indent)
+		out += fmt.Sprintf("%s\t\treturn false, err\n", indent)
+		out += fmt.Sprintf("%s\t}\n", indent)
+
+		isEnum := asyncOp.StatusShapeRef.Shape.IsEnum()
+		statusVar := "resp." + strings.Join(asyncOp.StatusPath, ".")
+		// Enum values are not pointers in the SDK, so only the structures
+		// containing them may be nil.
+		checks := memberPathNilChecks("resp", asyncOp.StatusPath, !isEnum)
+		if len(checks) > 0 {
+			negated := make([]string, len(checks))
+			for i, check := range checks {
+				negated[i] = strings.Replace(check, " != nil", " == nil", 1)
+			}
+			// if resp.ChangeInfo == nil {
+			out += fmt.Sprintf("%s\tif %s {\n", indent, strings.Join(negated, " || "))
+			out += fmt.Sprintf("%s\t\treturn false, nil\n", indent)
+			out += fmt.Sprintf("%s\t}\n", indent)
+		}
+		if isEnum {
+			statusVar = "string(" + statusVar + ")"
+		} else {
+			statusVar = "*" + statusVar
+		}
+		// switch string(resp.ChangeInfo.Status) {
+		out += fmt.Sprintf("%s\tswitch %s {\n", indent, statusVar)
+		out += fmt.Sprintf("%s\tcase %s:\n", indent, quotedList(asyncOp.CompletedWhen))
+		out += fmt.Sprintf("%s\t\t%s = nil\n", indent, idVar)
+		if len(asyncOp.FailedWhen) > 0 {
+			out += fmt.Sprintf("%s\tcase %s:\n", indent, quotedList(asyncOp.FailedWhen))
+			out += fmt.Sprintf("%s\t\tmsg := fmt.Sprintf(\n", indent)
+			out += fmt.Sprintf(
+				"%s\t\t\t\"asynchronous operation %%s failed with status %%s\",\n",
+				indent,
+			)
+			out += fmt.Sprintf("%s\t\t\t*%s, %s,\n", indent, idVar, statusVar)
+			out += fmt.Sprintf("%s\t\t)\n", indent)
+			out += fmt.Sprintf("%s\t\t%s = nil\n", indent, idVar)
+			out += fmt.Sprintf(
+				"%s\t\tackcondition.SetTerminal(%s, corev1.ConditionTrue, &msg, nil)\n",
+				indent, resVarName,
+			)
+		}
+		out += fmt.Sprintf("%s\tdefault:\n", indent)
+		out += fmt.Sprintf("%s\t\treturn false, nil\n", indent)
+		out += fmt.Sprintf("%s\t}\n", indent)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out, nil
+}
+
+// AsyncOperationsInProgress returns Go code that returns true if the ID of an
+// asynchronous operation of the resource is stored in its Status, meaning the
+// operation was in progress when the resource was last read. The generated
+// code does not call the AWS API, so it can be used from IsSynced, and before
+// updating or deleting the resource.
+//
+// Sample output:
+//
+//	if r.ko.Status.ChangeID != nil {
+//		return true
+//	}
+func AsyncOperationsInProgress(
+	r *model.CRD,
+	// resource variable name — "r"
+	resVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	asyncOps, err := r.AsyncOperations()
+	if err != nil {
+		return "", err
+	}
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	checked := map[string]bool{}
+	for _, asyncOp := range asyncOps {
+		fieldName := asyncOp.StatusField.Names.Camel
+		if checked[fieldName] {
+			continue
+		}
+		checked[fieldName] = true
+		out += fmt.Sprintf(
+			"%sif %s.ko.Status.%s != nil {\n", indent, resVarName, fieldName,
+		)
+		out += fmt.Sprintf("%s\treturn true\n", indent)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out, nil
+}
+
+// AsyncOperationsReads returns the Go code, for sdkFind, that polls the
+// asynchronous operations of a resource configured with `async_operations`,
+// clearing the IDs of the completed operations from the supplied object.
+//
+// Sample output:
+//
+//	if _, err = rm.asyncOperationsCompleted(ctx, &resource{ko}); err != nil {
+//		return nil, err
+//	}
+func AsyncOperationsReads(
+	r *model.CRD,
+	// String representing the name of the variable holding the CRD struct
+	// returned by sdkFind. This will likely be "ko".
+	koVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	if !r.HasAsyncOperations() {
+		return ""
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	out := fmt.Sprintf(
+		"\n%sif _, err = rm.asyncOperationsCompleted(ctx, &resource{%s}); err != nil {\n",
+		indent, koVarName,
+	)
+	out += fmt.Sprintf("%s\treturn nil, err\n", indent)
+	out += fmt.Sprintf("%s}", indent)
+	return out
+}
+
+// memberPathNilChecks returns the nil checks of the members of the supplied
+// path, e.g. `resp.ChangeInfo != nil`, `resp.ChangeInfo.Id != nil`. The last
+// member is only checked if includeLast is true.
+func memberPathNilChecks(
+	varName string,
+	memberPath []string,
+	includeLast bool,
+) []string {
+	checks := []string{}
+	for i := range memberPath {
+		if i == len(memberPath)-1 && !includeLast {
+			break
+		}
+		checks = append(checks, fmt.Sprintf(
+			"%s.%s != nil", varName, strings.Join(memberPath[:i+1], "."),
+		))
+	}
+	return checks
+}
+
+// quotedList returns the supplied values as a comma-separated list of Go
+// string literals, e.g. `"INSYNC", "DONE"`.
+func quotedList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,185 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestSetAsyncOperationID_Route53_RecordSet(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "route53", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-async-operations.yaml",
+	})
+	crd := testutil.GetCRDByName(t, g, "RecordSet")
+	require.NotNil(crd)
+	require.True(crd.HasAsyncOperations())
+
+	expected := `
+	if resp.ChangeInfo != nil && resp.ChangeInfo.Id != nil {
+		ko.Status.ChangeID = resp.ChangeInfo.Id
+	}`
+	got, err := code.SetAsyncOperationID(crd, "Create", crd.Ops.Create, "resp", "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+
+	// RecordSet has no asynchronous Update operation
+	got, err = code.SetAsyncOperationID(crd, "Update", crd.Ops.Update, "resp", "ko", 1)
+	require.NoError(err)
+	assert.Equal("", got)
+}
+
+func TestSetAsyncOperationID_Route53_HostedZone_UpdateOperations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "route53", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-async-operations.yaml",
+	})
+	crd := testutil.GetCRDByName(t, g, "HostedZone")
+	require.NotNil(crd)
+
+	updateOps, err := crd.UpdateOperations()
+	require.NoError(err)
+	require.Len(updateOps, 2)
+
+	// Only AssociateVPCWithHostedZone returns a ChangeInfo, so only its
+	// response carries the ID of the asynchronous Update operation.
+	got, err := code.SetAsyncOperationID(crd, "Update", updateOps[0].Operation, "resp", "ko", 1)
+	require.NoError(err)
+	assert.Equal("", got)
+
+	expected := `
+	if resp.ChangeInfo != nil && resp.ChangeInfo.Id != nil {
+		ko.Status.ChangeID = resp.ChangeInfo.Id
+	}`
+	got, err = code.SetAsyncOperationID(crd, "Update", updateOps[1].Operation, "resp", "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func TestAsyncOperationsCompleted_Route53_RecordSet(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "route53", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-async-operations.yaml",
+	})
+	crd := testutil.GetCRDByName(t, g, "RecordSet")
+	require.NotNil(crd)
+
+	// The Create and Delete operations share the ChangeID Status field, which
+	// is polled once.
+	expected := `	if r.ko.Status.ChangeID != nil {
+		resp, err := rm.sdkapi.GetChange(ctx, &svcsdk.GetChangeInput{
+			Id: r.ko.Status.ChangeID,
+		})
+		rm.metrics.RecordAPICall("READ_ONE", "GetChange", err)
+		if err != nil {
+			return false, err
+		}
+		if resp.ChangeInfo == nil {
+			return false, nil
+		}
+		switch string(resp.ChangeInfo.Status) {
+		case "INSYNC":
+			r.ko.Status.ChangeID = nil
+		default:
+			return false, nil
+		}
+	}
+`
+	got, err := code.AsyncOperationsCompleted(crd, "r", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+
+	// The Status field is checked, without calling the AWS API, once too.
+	expected = `	if r.ko.Status.ChangeID != nil {
+		return true
+	}
+`
+	got, err = code.AsyncOperationsInProgress(crd, "r", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+
+	assert.Equal(`
+	if _, err = rm.asyncOperationsCompleted(ctx, &resource{ko}); err != nil {
+		return nil, err
+	}`, code.AsyncOperationsReads(crd, "ko", 1))
+}
+
+func TestAsyncOperationsCompleted_Route53_HostedZone_FailedWhen(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "route53", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-async-operations.yaml",
+	})
+	crd := testutil.GetCRDByName(t, g, "HostedZone")
+	require.NotNil(crd)
+
+	// A failed operation is cleared and reported with a Terminal condition,
+	// without failing sdkFind.
+	expected := `	if r.ko.Status.ChangeID != nil {
+		resp, err := rm.sdkapi.GetChange(ctx, &svcsdk.GetChangeInput{
+			Id: r.ko.Status.ChangeID,
+		})
+		rm.metrics.RecordAPICall("READ_ONE", "GetChange", err)
+		if err != nil {
+			return false, err
+		}
+		if resp.ChangeInfo == nil {
+			return false, nil
+		}
+		switch string(resp.ChangeInfo.Status) {
+		case "INSYNC":
+			r.ko.Status.ChangeID = nil
+		case "FAILED":
+			msg := fmt.Sprintf(
+				"asynchronous operation %s failed with status %s",
+				*r.ko.Status.ChangeID, string(resp.ChangeInfo.Status),
+			)
+			r.ko.Status.ChangeID = nil
+			ackcondition.SetTerminal(r, corev1.ConditionTrue, &msg, nil)
+		default:
+			return false, nil
+		}
+	}
+`
+	got, err := code.AsyncOperationsCompleted(crd, "r", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func TestAsyncOperationsCompleted_NoAsyncOperations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "route53")
+	crd := testutil.GetCRDByName(t, g, "RecordSet")
+	require.NotNil(crd)
+	require.False(crd.HasAsyncOperations())
+
+	got, err := code.AsyncOperationsCompleted(crd, "r", 1)
+	require.NoError(err)
+	assert.Equal("", got)
+}
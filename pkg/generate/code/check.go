@@ -22,6 +22,7 @@ import (
 	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
 	"github.com/aws-controllers-k8s/code-generator/pkg/fieldpath"
 	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/util"
 )
 
 // CheckExceptionMessage returns Go code that contains a condition to
@@ -57,6 +58,79 @@ func CheckExceptionMessage(
 	return ""
 }
 
+// ExceptionGoType returns the Go type that errors returned by the supplied
+// operation are matched against, with errors.As, to check for the exception
+// having the supplied exception code. This is the aws-sdk-go-v2 typed
+// exception struct when the operation declares the exception in the API
+// model, and smithy.APIError otherwise, in which case the error code must
+// also be checked with CheckExceptionCode. aws-sdk-go-v2 only deserializes
+// the exceptions declared on an operation into their typed structs, and
+// returns any other exception as a smithy.GenericAPIError.
+//
+// Sample Output:
+//
+// *svcsdktypes.RepositoryNotFoundException
+func ExceptionGoType(
+	r *model.CRD,
+	op *awssdkmodel.Operation,
+	exceptionCode string,
+) string {
+	shape := operationExceptionShape(r, op, exceptionCode)
+	if shape == nil {
+		return "smithy.APIError"
+	}
+	// The SDK names its types after the original shape names
+	shapeName := shape.OriginalShapeName
+	if shapeName == "" {
+		shapeName = shape.ShapeName
+	}
+	return "*svcsdktypes." + shapeName
+}
+
+// CheckExceptionCode returns Go code that contains a condition to check that
+// the error held in the supplied variable, of the type returned by
+// ExceptionGoType for the supplied operation, has the supplied exception
+// code. When the exception is matched against its typed exception struct,
+// the type already identifies the exception and we return an empty string.
+//
+// Sample Output:
+//
+// && awsErr.ErrorCode() == "UNKNOWN"
+func CheckExceptionCode(
+	r *model.CRD,
+	op *awssdkmodel.Operation,
+	exceptionCode string,
+	// String representing the name of the variable holding the error, of the
+	// type returned by ExceptionGoType. This will likely be "awsErr".
+	excVarName string,
+) string {
+	if operationExceptionShape(r, op, exceptionCode) != nil {
+		return ""
+	}
+	return fmt.Sprintf("&& %s.ErrorCode() == %q ", excVarName, exceptionCode)
+}
+
+// operationExceptionShape returns the exception shape having the supplied
+// exception code if the supplied operation declares it in its errors, nil
+// otherwise.
+func operationExceptionShape(
+	r *model.CRD,
+	op *awssdkmodel.Operation,
+	exceptionCode string,
+) *awssdkmodel.Shape {
+	shape := r.ExceptionShape(exceptionCode)
+	if shape == nil || op == nil {
+		return nil
+	}
+	for _, errShapeRef := range op.ErrorRefs {
+		if errShapeRef.Shape == shape ||
+			(errShapeRef.Shape != nil && errShapeRef.Shape.ShapeName == shape.ShapeName) {
+			return shape
+		}
+	}
+	return nil
+}
+
 // CheckRequiredFieldsMissingFromShape returns Go code that contains a
 // condition checking that the required fields in the supplied Shape have a
 // non-nil value in the corresponding CR's Spec or Status substruct.
@@ -77,7 +151,7 @@ func CheckRequiredFieldsMissingFromShape(
 	case model.OpTypeList:
 		op = r.Ops.ReadMany
 		return checkRequiredFieldsMissingFromShapeReadMany(
-			r, koVarName, indentLevel, op, op.InputRef.Shape), nil
+			r, koVarName, indentLevel, op, op.InputRef.Shape)
 	case model.OpTypeGetAttributes:
 		op = r.Ops.GetAttributes
 	case model.OpTypeSetAttributes:
@@ -104,15 +178,34 @@ func checkRequiredFieldsMissingFromShape(
 	shape *awssdkmodel.Shape,
 ) (string, error) {
 	indent := strings.Repeat("\t", indentLevel)
+	missing := []string{}
+	if r.IsSingleton() && op == r.Ops.ReadOne {
+		// A singleton resource always exists in AWS but is only created, by
+		// its first write, once the controller has set its status defaults.
+		missing = append(missing, fmt.Sprintf(
+			"%s.Status.ACKResourceMetadata == nil", koVarName,
+		))
+	}
+	if op == r.Ops.ReadOne || op == r.Ops.GetAttributes {
+		// Every field of a composite primary key is needed to read the
+		// resource, whether or not the shape requires it.
+		compositeMissing, err := compositePrimaryKeyMissing(r, koVarName, op, shape)
+		if err != nil {
+			return "", err
+		}
+		missing = append(missing, compositeMissing...)
+	}
 	if shape == nil || len(shape.Required) == 0 {
-		return fmt.Sprintf("%sreturn false", indent), nil
+		if len(missing) == 0 {
+			return fmt.Sprintf("%sreturn false", indent), nil
+		}
+		return fmt.Sprintf("%sreturn %s\n", indent, strings.Join(missing, " || ")), nil
 	}
 
 	// Loop over the required member fields in the shape and identify whether
 	// the field exists in either the Status or the Spec of the resource and
 	// generate an if condition checking for all required fields having non-nil
 	// corresponding resource Spec/Status values
-	missing := []string{}
 	for _, memberName := range shape.Required {
 		if r.UnpacksAttributesMap() {
 			// We set the Attributes field specially... depending on whether
@@ -152,6 +245,46 @@ func checkRequiredFieldsMissingFromShape(
 	return fmt.Sprintf("%sreturn %s\n", indent, missingCondition), nil
 }
 
+// compositePrimaryKeyMissing returns the conditions checking that the fields
+// of the composite primary key of a resource that are not required members of
+// the supplied Input shape of the supplied operation have a nil value, e.g.
+// `r.ko.Spec.DomainName == nil`.
+func compositePrimaryKeyMissing(
+	r *model.CRD,
+	koVarName string,
+	op *awssdkmodel.Operation,
+	shape *awssdkmodel.Shape,
+) ([]string, error) {
+	primaryFields, err := r.GetPrimaryKeyFields()
+	if err != nil || len(primaryFields) < 2 {
+		return nil, err
+	}
+	cfg := r.Config()
+	missing := []string{}
+	for _, field := range primaryFields {
+		memberPath, _ := findFieldInCR(cfg, r, field.Names.Original)
+		if shape != nil && memberPath != "" {
+			required := false
+			for _, memberName := range shape.Required {
+				fieldName := cfg.GetResourceFieldName(
+					r.Names.Original, op.ExportedName, memberName,
+				)
+				if _, f := findFieldInCR(cfg, r, fieldName); f == field {
+					required = true
+					break
+				}
+			}
+			if required {
+				continue
+			}
+		}
+		missing = append(missing, fmt.Sprintf(
+			"%s%s.%s == nil", koVarName, memberPath, field.Path,
+		))
+	}
+	return missing, nil
+}
+
 // checkRequiredFieldsMissingFromShapeReadMany is a special-case handling
 // of those APIs where there is no ReadOne operation and instead the only way to
 // grab information for a single object is to call the ReadMany/List operation
@@ -165,6 +298,9 @@ func checkRequiredFieldsMissingFromShape(
 // the VpcId field to be present to ensure the returned array from the API call
 // consists only of the desired Vpc.
 //
+// Every field of a composite primary key is also required, since the
+// resource cannot be told apart from the others in the list without them.
+//
 // Sample Output:
 //
 // return r.ko.Status.VPCID == nil
@@ -174,18 +310,30 @@ func checkRequiredFieldsMissingFromShapeReadMany(
 	indentLevel int,
 	op *awssdkmodel.Operation,
 	shape *awssdkmodel.Shape,
-) string {
+) (string, error) {
 	indent := strings.Repeat("\t", indentLevel)
-	result := fmt.Sprintf("%sreturn false", indent)
+	missing := []string{}
 
 	reqIdentifier, _ := FindPluralizedIdentifiersInShape(r, shape, op)
 	resVarPath, err := r.GetSanitizedMemberPath(reqIdentifier, op, koVarName)
+	if err == nil {
+		missing = append(missing, fmt.Sprintf("%s == nil", resVarPath))
+	}
+	// The List operation's Input shape does not require the fields of the
+	// composite primary key, so all of them are checked.
+	compositeMissing, err := compositePrimaryKeyMissing(r, koVarName, op, nil)
 	if err != nil {
-		return result
+		return "", err
 	}
-
-	result = fmt.Sprintf("%s == nil", resVarPath)
-	return fmt.Sprintf("%sreturn %s\n", indent, result)
+	for _, condition := range compositeMissing {
+		if !util.InStrings(condition, missing) {
+			missing = append(missing, condition)
+		}
+	}
+	if len(missing) == 0 {
+		return fmt.Sprintf("%sreturn false", indent), nil
+	}
+	return fmt.Sprintf("%sreturn %s\n", indent, strings.Join(missing, " || ")), nil
 }
 
 // CheckNilFieldPath returns the condition statement for Nil check
@@ -55,6 +55,34 @@ func TestCheckRequiredFields_Attributes_ARNField(t *testing.T) {
 	)
 }
 
+func TestCheckRequiredFields_Singleton(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-singleton.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "RegistryScanningConfiguration")
+	require.NotNil(crd)
+
+	// The GetRegistryScanningConfigurationInput shape has no required fields,
+	// but the singleton is only created by its first write, after which the
+	// resource has status defaults.
+	expRequiredFieldsCode := `
+	return ko.Status.ACKResourceMetadata == nil
+`
+	gotCode, err := code.CheckRequiredFieldsMissingFromShape(
+		crd, model.OpTypeGet, "ko", 1,
+	)
+	require.NoError(err)
+	assert.Equal(
+		strings.TrimSpace(expRequiredFieldsCode),
+		strings.TrimSpace(gotCode),
+	)
+}
+
 func TestCheckRequiredFields_Attributes_StatusField(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -205,3 +233,96 @@ func TestCheckNilReferencesPath(t *testing.T) {
 		"obj.Status.ACKResourceMetadata == nil || obj.Status.ACKResourceMetadata.ARN == nil",
 		code.CheckNilReferencesPath(&field, "obj"))
 }
+
+func TestCheckException_TypedException(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	// The read operation declares the exception, so errors are matched
+	// against the typed exception struct and the code needs no check.
+	notFoundCode := crd.ExceptionCode(404)
+	assert.Equal("RepositoryNotFoundException", notFoundCode)
+	assert.Equal(
+		"*svcsdktypes.RepositoryNotFoundException",
+		code.ExceptionGoType(crd, crd.Ops.ReadMany, notFoundCode),
+	)
+	assert.Empty(code.CheckExceptionCode(crd, crd.Ops.ReadMany, notFoundCode, "awsErr"))
+
+	// Codes without an exception shape fall back to the error code string.
+	assert.Equal("smithy.APIError", code.ExceptionGoType(crd, crd.Ops.ReadMany, "UNKNOWN"))
+	assert.Equal(
+		`&& awsErr.ErrorCode() == "UNKNOWN" `,
+		code.CheckExceptionCode(crd, crd.Ops.ReadMany, "UNKNOWN", "awsErr"),
+	)
+}
+
+func TestCheckException_UndeclaredException(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	// CreateRepository does not declare RepositoryNotFoundException, which
+	// the SDK then returns as a smithy.GenericAPIError, so the exception is
+	// matched by its code.
+	notFoundCode := crd.ExceptionCode(404)
+	assert.Equal("smithy.APIError", code.ExceptionGoType(crd, crd.Ops.Create, notFoundCode))
+	assert.Equal(
+		`&& awsErr.ErrorCode() == "RepositoryNotFoundException" `,
+		code.CheckExceptionCode(crd, crd.Ops.Create, notFoundCode, "awsErr"),
+	)
+}
+
+func TestCheckException_QueryCompatibleCode(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "sqs")
+
+	crd := testutil.GetCRDByName(t, g, "Queue")
+	require.NotNil(crd)
+
+	// The awsQueryError code differs from the name of the exception shape.
+	assert.Equal(
+		"*svcsdktypes.QueueDoesNotExist",
+		code.ExceptionGoType(crd, crd.Ops.GetAttributes, "AWS.SimpleQueueService.NonExistentQueue"),
+	)
+	assert.Empty(code.CheckExceptionCode(
+		crd, crd.Ops.GetAttributes, "AWS.SimpleQueueService.NonExistentQueue", "awsErr",
+	))
+}
+
+func TestCheckRequiredFieldsMissingFromShape_Route53_RecordSet_CompositeKey(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "route53")
+
+	crd := testutil.GetCRDByName(t, g, "RecordSet")
+	require.NotNil(crd)
+	require.Nil(crd.Ops.ReadOne)
+
+	// RecordSet is read with the ListResourceRecordSets operation, which
+	// requires none of the fields of a composite primary key.
+	fieldConfigs := crd.Config().GetFieldConfigs("RecordSet")
+	fieldConfigs["ID"].IsPrimaryKey = false
+	for i, fieldName := range []string{"HostedZoneId", "Name", "RecordType"} {
+		fieldConfigs[fieldName].IsPrimaryKey = true
+		fieldConfigs[fieldName].PrimaryKeyPosition = i + 1
+	}
+
+	got, err := code.CheckRequiredFieldsMissingFromShape(crd, model.OpTypeList, "r.ko", 1)
+	require.NoError(err)
+	assert.Equal(
+		"\treturn r.ko.Spec.HostedZoneId == nil || r.ko.Spec.Name == nil || r.ko.Spec.RecordType == nil\n",
+		got,
+	)
+}
@@ -0,0 +1,451 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws-controllers-k8s/code-generator/pkg/api"
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// ChildCollectionSync returns the body of the generated sync method for a
+// child collection. The children present in the desired resource but not in
+// the latest resource are added, and the children present in the latest
+// resource but not in the desired resource are removed. Removals happen first
+// so that a collection with a service-side size limit can be swapped out.
+//
+// Sample output, for an IAM Role's attached policies:
+//
+//	toAdd := desired.ko.Spec.Policies[:0:0]
+//	for _, a := range desired.ko.Spec.Policies {
+//		found := false
+//		for _, b := range latest.ko.Spec.Policies {
+//			if reflect.DeepEqual(a, b) {
+//				found = true
+//				break
+//			}
+//		}
+//		if !found {
+//			toAdd = append(toAdd, a)
+//		}
+//	}
+//	toRemove := ...
+//	for start := 0; start < len(toRemove); start += 1 {
+//		end := start + 1
+//		if end > len(toRemove) {
+//			end = len(toRemove)
+//		}
+//		batch := desired.ko.DeepCopy()
+//		batch.Spec.Policies = toRemove[start:end]
+//		input, err := rm.newChildPoliciesDetachRolePolicyPayload(&resource{batch})
+//		if err != nil {
+//			return err
+//		}
+//		_, err = rm.sdkapi.DetachRolePolicy(ctx, input)
+//		rm.metrics.RecordAPICall("UPDATE", "DetachRolePolicy", err)
+//		if err != nil {
+//			return err
+//		}
+//	}
+//	for start := 0; start < len(toAdd); start += 1 {
+//		...
+//	}
+//	return nil
+func ChildCollectionSync(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	cc *model.ChildCollection,
+	// desired resource variable name — "desired" for the sync method
+	desiredVarName string,
+	// latest resource variable name — "latest" for the sync method
+	latestVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	indent := strings.Repeat("\t", indentLevel)
+	fieldPath := cfg.PrefixConfig.SpecField + "." + cc.Field.Names.Camel
+	desiredField := desiredVarName + ".ko" + fieldPath
+	latestField := latestVarName + ".ko" + fieldPath
+
+	out := childCollectionDifference("toAdd", desiredField, latestField, indent)
+	out += childCollectionDifference("toRemove", latestField, desiredField, indent)
+	out += childCollectionBatches(
+		cc, cc.RemoveOp, "toRemove", desiredVarName, fieldPath, indent,
+	)
+	out += childCollectionBatches(
+		cc, cc.AddOp, "toAdd", desiredVarName, fieldPath, indent,
+	)
+	out += fmt.Sprintf("%sreturn nil\n", indent)
+	return out
+}
+
+// childCollectionDifference returns Go code that collects into a new variable
+// the elements of the "from" list that are not in the "other" list.
+func childCollectionDifference(
+	varName string,
+	fromVarName string,
+	otherVarName string,
+	indent string,
+) string {
+	out := fmt.Sprintf("%s%s := %s[:0:0]\n", indent, varName, fromVarName)
+	out += fmt.Sprintf("%sfor _, a := range %s {\n", indent, fromVarName)
+	out += fmt.Sprintf("%s\tfound := false\n", indent)
+	out += fmt.Sprintf("%s\tfor _, b := range %s {\n", indent, otherVarName)
+	out += fmt.Sprintf("%s\t\tif reflect.DeepEqual(a, b) {\n", indent)
+	out += fmt.Sprintf("%s\t\t\tfound = true\n", indent)
+	out += fmt.Sprintf("%s\t\t\tbreak\n", indent)
+	out += fmt.Sprintf("%s\t\t}\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s\tif !found {\n", indent)
+	out += fmt.Sprintf("%s\t\t%s = append(%s, a)\n", indent, varName, varName)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
+// childCollectionBatches returns Go code that calls the supplied add or remove
+// operation for the children in the named variable, one batch at a time.
+func childCollectionBatches(
+	cc *model.ChildCollection,
+	op *awssdkmodel.Operation,
+	varName string,
+	desiredVarName string,
+	fieldPath string,
+	indent string,
+) string {
+	opName := op.ExportedName
+	batchSize := "1"
+	if cc.IsBatched() {
+		batchSize = fmt.Sprintf("len(%s)", varName)
+		if cc.BatchSize > 0 {
+			batchSize = fmt.Sprintf("%d", cc.BatchSize)
+		}
+	}
+	out := fmt.Sprintf(
+		"%sfor start := 0; start < len(%s); start += %s {\n",
+		indent, varName, batchSize,
+	)
+	out += fmt.Sprintf("%s\tend := start + %s\n", indent, batchSize)
+	out += fmt.Sprintf("%s\tif end > len(%s) {\n", indent, varName)
+	out += fmt.Sprintf("%s\t\tend = len(%s)\n", indent, varName)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s\tbatch := %s.ko.DeepCopy()\n", indent, desiredVarName)
+	out += fmt.Sprintf(
+		"%s\tbatch%s = %s[start:end]\n", indent, fieldPath, varName,
+	)
+	out += fmt.Sprintf(
+		"%s\tinput, err := rm.%s(&resource{batch})\n",
+		indent, cc.PayloadMethodName(op),
+	)
+	out += fmt.Sprintf("%s\tif err != nil {\n", indent)
+	out += fmt.Sprintf("%s\t\treturn err\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s\t_, err = rm.sdkapi.%s(ctx, input)\n", indent, opName)
+	out += fmt.Sprintf(
+		"%s\trm.metrics.RecordAPICall(\"UPDATE\", %q, err)\n", indent, opName,
+	)
+	out += fmt.Sprintf("%s\tif err != nil {\n", indent)
+	out += fmt.Sprintf("%s\t\treturn err\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
+// SetSDKForChildOperation returns the Go code that sets the Input shape of a
+// child collection's add or remove operation. The Input shape is populated
+// from the resource the same way SetSDK populates it, except for the member
+// receiving the children, which is set from the resource's child collection
+// field. The generated sync method passes a copy of the resource whose child
+// collection field only contains the children of the current call.
+//
+// Sample output, for IAM's AttachRolePolicy operation:
+//
+//	if r.ko.Spec.Name != nil {
+//		res.RoleName = r.ko.Spec.Name
+//	}
+//	if len(r.ko.Spec.Policies) > 0 {
+//		res.PolicyArn = r.ko.Spec.Policies[0]
+//	}
+func SetSDKForChildOperation(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	cc *model.ChildCollection,
+	// The add or remove operation to set the Input shape for
+	op *awssdkmodel.Operation,
+	// String representing the name of the variable that we will grab the Input
+	// shape from. This will likely be "r.ko".
+	sourceVarName string,
+	// String representing the name of the variable that we will be **setting**
+	// with values from the resource. This will likely be "res".
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	opType := cc.AddOpType
+	if op == cc.RemoveOp {
+		opType = cc.RemoveOpType
+	}
+	out, err := setSDKForOperation(
+		cfg, r, op, opType, sourceVarName, targetVarName, cc.InputMember,
+		indentLevel,
+	)
+	if err != nil {
+		return "", err
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	sourceFieldPath := cc.Field.Names.Camel
+	sourceAdaptedVarName := sourceVarName + cfg.PrefixConfig.SpecField + "." + sourceFieldPath
+	memberShapeRef := op.InputRef.Shape.MemberRefs[cc.InputMember]
+
+	if !cc.IsBatched() {
+		out += fmt.Sprintf("%sif len(%s) > 0 {\n", indent, sourceAdaptedVarName)
+		out += fmt.Sprintf(
+			"%s\t%s.%s = %s[0]\n",
+			indent, targetVarName, cc.InputMember, sourceAdaptedVarName,
+		)
+		out += fmt.Sprintf("%s}\n", indent)
+		return out, nil
+	}
+
+	out += fmt.Sprintf("%sif %s != nil {\n", indent, sourceAdaptedVarName)
+	adaptiveCollection := setSDKAdaptiveResourceCollection(
+		memberShapeRef.Shape, targetVarName, cc.InputMember,
+		sourceAdaptedVarName, indent, false,
+	)
+	if adaptiveCollection != "" {
+		out += adaptiveCollection
+	} else {
+		memberVarName := "f0"
+		out += varEmptyConstructorSDKType(
+			cfg, r, memberVarName, memberShapeRef.Shape, indentLevel+1,
+		)
+		containerOut, err := setSDKForContainer(
+			cfg, r,
+			cc.InputMember,
+			memberVarName,
+			sourceFieldPath,
+			sourceAdaptedVarName,
+			memberShapeRef,
+			false,
+			opType,
+			indentLevel+1,
+		)
+		if err != nil {
+			return "", err
+		}
+		out += containerOut
+		out += setSDKForScalar(
+			cc.InputMember,
+			targetVarName,
+			op.InputRef.Shape.Type,
+			sourceFieldPath,
+			memberVarName,
+			false,
+			memberShapeRef,
+			indentLevel+1,
+		)
+	}
+	out += fmt.Sprintf("%s}\n", indent)
+	return out, nil
+}
+
+// SetSDKForChildCollectionRead returns the Go code that sets the Input shape
+// of a child collection's read operation from the resource.
+func SetSDKForChildCollectionRead(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	cc *model.ChildCollection,
+	// String representing the name of the variable that we will grab the Input
+	// shape from. This will likely be "r.ko".
+	sourceVarName string,
+	// String representing the name of the variable that we will be **setting**
+	// with values from the resource. This will likely be "res".
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	return setSDKForOperation(
+		cfg, r, cc.ReadOp, model.OpTypeGet, sourceVarName, targetVarName, "",
+		indentLevel,
+	)
+}
+
+// ChildCollectionRead returns the body of the generated read method for a
+// child collection with a read operation. The read operation is called for
+// every page of its results and the resource's child collection field is set
+// from the Output shapes.
+//
+// The read_output_path is either the name of an Output shape member that is a
+// list of strings, or the name of a member that is a list of structures
+// followed by the name of the string member of those structures holding the
+// child.
+//
+// Sample output, for a read_output_path of "AttachedPolicies.PolicyArn":
+//
+//	input, err := rm.newChildPoliciesListAttachedRolePoliciesPayload(&resource{ko})
+//	if err != nil {
+//		return err
+//	}
+//	children := []*string{}
+//	for {
+//		var resp *svcsdk.ListAttachedRolePoliciesOutput
+//		resp, err = rm.sdkapi.ListAttachedRolePolicies(ctx, input)
+//		rm.metrics.RecordAPICall("READ_ONE", "ListAttachedRolePolicies", err)
+//		if err != nil {
+//			return err
+//		}
+//		for _, elem := range resp.AttachedPolicies {
+//			if elem.PolicyArn != nil {
+//				children = append(children, elem.PolicyArn)
+//			}
+//		}
+//		if resp.Marker == nil || *resp.Marker == "" {
+//			break
+//		}
+//		input.Marker = resp.Marker
+//	}
+//	if len(children) > 0 || len(ko.Spec.Policies) > 0 {
+//		ko.Spec.Policies = children
+//	}
+//	return nil
+func ChildCollectionRead(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	cc *model.ChildCollection,
+	// String representing the name of the variable holding the CRD struct to
+	// set. This will likely be "ko".
+	koVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	if cc.ReadOp == nil || cc.ReadOp.OutputRef.Shape == nil {
+		return "", nil
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	targetField := koVarName + cfg.PrefixConfig.SpecField + "." + cc.Field.Names.Camel
+	pathErr := fmt.Errorf(
+		"resource %q, field %q: child_collection: read_output_path %q must "+
+			"name a list of strings or a string member of a list of structures "+
+			"in the Output shape of operation %q",
+		r.Names.Original, cc.Field.Names.Camel, cc.ReadOutputPath,
+		cc.ReadOp.ExportedName,
+	)
+	if cc.Field.GoType != "[]*string" {
+		return "", pathErr
+	}
+
+	parts := strings.Split(cc.ReadOutputPath, ".")
+	if len(parts) > 2 {
+		return "", pathErr
+	}
+	listRef, found := cc.ReadOp.OutputRef.Shape.MemberRefs[parts[0]]
+	if !found || listRef.Shape.Type != "list" {
+		return "", pathErr
+	}
+	elemShape := listRef.Shape.MemberRef.Shape
+	listVarName := "resp." + parts[0]
+	switch len(parts) {
+	case 1:
+		if elemShape.Type != "string" {
+			return "", pathErr
+		}
+	case 2:
+		if elemShape.Type != "structure" {
+			return "", pathErr
+		}
+		childRef, found := elemShape.MemberRefs[parts[1]]
+		if !found || childRef.Shape.Type != "string" {
+			return "", pathErr
+		}
+	}
+	readPage := func(pageIndentLevel int) (string, error) {
+		pageIndent := strings.Repeat("\t", pageIndentLevel)
+		if len(parts) == 1 {
+			return fmt.Sprintf(
+				"%schildren = append(children, aws.StringSlice(%s)...)\n",
+				pageIndent, listVarName,
+			), nil
+		}
+		out := fmt.Sprintf("%sfor _, elem := range %s {\n", pageIndent, listVarName)
+		out += fmt.Sprintf("%s\tif elem.%s != nil {\n", pageIndent, parts[1])
+		out += fmt.Sprintf(
+			"%s\t\tchildren = append(children, elem.%s)\n", pageIndent, parts[1],
+		)
+		out += fmt.Sprintf("%s\t}\n", pageIndent)
+		out += fmt.Sprintf("%s}\n", pageIndent)
+		return out, nil
+	}
+
+	out := fmt.Sprintf(
+		"%sinput, err := rm.%s(&resource{%s})\n",
+		indent, cc.PayloadMethodName(cc.ReadOp), koVarName,
+	)
+	out += fmt.Sprintf("%sif err != nil {\n", indent)
+	out += fmt.Sprintf("%s\treturn err\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	out += fmt.Sprintf("%schildren := []*string{}\n", indent)
+	pages, err := readPages(r, cc.ReadOp, readPage, indentLevel)
+	if err != nil {
+		return "", err
+	}
+	out += pages
+	// Leave an empty desired list alone when nothing is observed, so that a nil
+	// and an empty list do not show up as a difference.
+	out += fmt.Sprintf(
+		"%sif len(children) > 0 || len(%s) > 0 {\n", indent, targetField,
+	)
+	out += fmt.Sprintf("%s\t%s = children\n", indent, targetField)
+	out += fmt.Sprintf("%s}\n", indent)
+	out += fmt.Sprintf("%sreturn nil\n", indent)
+	return out, nil
+}
+
+// ChildCollectionReads returns the Go code, for sdkFind, that sets each of
+// the resource's child collection fields configured with a read operation.
+//
+// Sample output:
+//
+//	if err = rm.readPolicies(ctx, ko); err != nil {
+//		return nil, err
+//	}
+func ChildCollectionReads(
+	r *model.CRD,
+	// String representing the name of the variable holding the CRD struct to
+	// set. This will likely be "ko".
+	koVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	ccs, err := r.ChildCollections()
+	if err != nil {
+		return "", err
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	out := ""
+	for _, cc := range ccs {
+		if cc.ReadOp == nil {
+			continue
+		}
+		out += fmt.Sprintf(
+			"\n%sif err = rm.read%s(ctx, %s); err != nil {\n",
+			indent, cc.Field.Names.Camel, koVarName,
+		)
+		out += fmt.Sprintf("%s\treturn nil, err\n", indent)
+		out += fmt.Sprintf("%s}", indent)
+	}
+	return out, nil
+}
@@ -0,0 +1,155 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func childCollectionForIAMRole(t *testing.T) (*model.CRD, *model.ChildCollection) {
+	g := testutil.NewModelForServiceWithOptions(t, "iam",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-child-collections.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Role")
+	require.NotNil(t, crd)
+
+	ccs, err := crd.ChildCollections()
+	require.NoError(t, err)
+	require.Len(t, ccs, 1)
+	return crd, ccs[0]
+}
+
+func TestChildCollectionSync_IAM_Role(t *testing.T) {
+	assert := assert.New(t)
+
+	crd, cc := childCollectionForIAMRole(t)
+
+	got := code.ChildCollectionSync(crd.Config(), crd, cc, "desired", "latest", 1)
+
+	// The children are diffed in both directions...
+	assert.Contains(got, `	toAdd := desired.ko.Spec.Policies[:0:0]
+	for _, a := range desired.ko.Spec.Policies {
+		found := false
+		for _, b := range latest.ko.Spec.Policies {
+			if reflect.DeepEqual(a, b) {
+`)
+	assert.Contains(got, `
+	toRemove := latest.ko.Spec.Policies[:0:0]
+	for _, a := range latest.ko.Spec.Policies {
+`)
+	// ...and, as the operations receive a single child, each child is passed
+	// in its own call, removals first.
+	expectedRemove := `
+	for start := 0; start < len(toRemove); start += 1 {
+		end := start + 1
+		if end > len(toRemove) {
+			end = len(toRemove)
+		}
+		batch := desired.ko.DeepCopy()
+		batch.Spec.Policies = toRemove[start:end]
+		input, err := rm.newChildPoliciesDetachRolePolicyPayload(&resource{batch})
+		if err != nil {
+			return err
+		}
+		_, err = rm.sdkapi.DetachRolePolicy(ctx, input)
+		rm.metrics.RecordAPICall("UPDATE", "DetachRolePolicy", err)
+		if err != nil {
+			return err
+		}
+	}
+`
+	assert.Contains(got, expectedRemove)
+	assert.Contains(got, "rm.newChildPoliciesAttachRolePolicyPayload(&resource{batch})")
+	assert.Less(
+		strings.Index(got, "rm.sdkapi.DetachRolePolicy"),
+		strings.Index(got, "rm.sdkapi.AttachRolePolicy"),
+	)
+}
+
+func TestSetSDKForChildOperation_IAM_Role(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	crd, cc := childCollectionForIAMRole(t)
+
+	expected := `
+	if r.ko.Spec.Name != nil {
+		res.RoleName = r.ko.Spec.Name
+	}
+	if len(r.ko.Spec.Policies) > 0 {
+		res.PolicyArn = r.ko.Spec.Policies[0]
+	}
+`
+	got, err := code.SetSDKForChildOperation(crd.Config(), crd, cc, cc.AddOp, "r.ko", "res", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func TestChildCollectionRead_IAM_Role(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	crd, cc := childCollectionForIAMRole(t)
+
+	// ListAttachedRolePolicies is paginated with the Marker member, so every
+	// page is read before the field is set.
+	expected := `	input, err := rm.newChildPoliciesListAttachedRolePoliciesPayload(&resource{ko})
+	if err != nil {
+		return err
+	}
+	children := []*string{}
+	for {
+		var resp *svcsdk.ListAttachedRolePoliciesOutput
+		resp, err = rm.sdkapi.ListAttachedRolePolicies(ctx, input)
+		rm.metrics.RecordAPICall("READ_ONE", "ListAttachedRolePolicies", err)
+		if err != nil {
+			return err
+		}
+		for _, elem := range resp.AttachedPolicies {
+			if elem.PolicyArn != nil {
+				children = append(children, elem.PolicyArn)
+			}
+		}
+		if resp.Marker == nil || *resp.Marker == "" {
+			break
+		}
+		input.Marker = resp.Marker
+	}
+	if len(children) > 0 || len(ko.Spec.Policies) > 0 {
+		ko.Spec.Policies = children
+	}
+	return nil
+`
+	got, err := code.ChildCollectionRead(crd.Config(), crd, cc, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+
+	expected = `
+	if err = rm.readPolicies(ctx, ko); err != nil {
+		return nil, err
+	}`
+	got, err = code.ChildCollectionReads(crd, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
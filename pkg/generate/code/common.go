@@ -220,3 +220,97 @@ func GetMemberIndex(shape *awssdkmodel.Shape, memberName string) (int, error) {
 	}
 	return -1, fmt.Errorf("Could not find %s in shape %s", memberName, shape.ShapeName)
 }
+
+// paginationTokens returns the names of the Input and Output shape members
+// holding the page token of a paginated operation, or empty strings when the
+// operation is not paginated or its page token is not a top-level string
+// member of both shapes.
+func paginationTokens(op *awssdkmodel.Operation) (string, string) {
+	if op.Paginator == nil || op.InputRef.Shape == nil || op.OutputRef.Shape == nil {
+		return "", ""
+	}
+	inputTokens, _ := op.Paginator.InputTokens.([]string)
+	outputTokens, _ := op.Paginator.OutputTokens.([]string)
+	if len(inputTokens) != 1 || len(outputTokens) != 1 {
+		return "", ""
+	}
+	inputRef, found := op.InputRef.Shape.MemberRefs[inputTokens[0]]
+	if !found || inputRef.Shape == nil || inputRef.Shape.Type != "string" {
+		return "", ""
+	}
+	outputRef, found := op.OutputRef.Shape.MemberRefs[outputTokens[0]]
+	if !found || outputRef.Shape == nil || outputRef.Shape.Type != "string" {
+		return "", ""
+	}
+	return inputTokens[0], outputTokens[0]
+}
+
+// readPages returns Go code that calls the supplied read operation with the
+// Input shape held in the `input` variable and runs the supplied code, which
+// reads the Output shape from the `resp` variable, for every page of the
+// results. The generated code returns the error of a failed call.
+//
+// Sample output, for IAM's ListAttachedRolePolicies operation:
+//
+//	for {
+//		var resp *svcsdk.ListAttachedRolePoliciesOutput
+//		resp, err = rm.sdkapi.ListAttachedRolePolicies(ctx, input)
+//		rm.metrics.RecordAPICall("READ_ONE", "ListAttachedRolePolicies", err)
+//		if err != nil {
+//			return err
+//		}
+//		<page code>
+//		if resp.Marker == nil || *resp.Marker == "" {
+//			break
+//		}
+//		input.Marker = resp.Marker
+//	}
+func readPages(
+	r *model.CRD,
+	op *awssdkmodel.Operation,
+	// Returns the code reading a page at the supplied level of indentation
+	pageCode func(indentLevel int) (string, error),
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	indent := strings.Repeat("\t", indentLevel)
+	inputToken, outputToken := paginationTokens(op)
+	callIndentLevel := indentLevel
+	out := ""
+	if inputToken != "" {
+		callIndentLevel++
+		out += fmt.Sprintf("%sfor {\n", indent)
+	}
+	callIndent := strings.Repeat("\t", callIndentLevel)
+	opName := op.ExportedName
+	respType, err := r.GetOutputShapeGoType(op)
+	if err != nil {
+		return "", err
+	}
+	out += fmt.Sprintf("%svar resp %s\n", callIndent, respType)
+	out += fmt.Sprintf("%sresp, err = rm.sdkapi.%s(ctx, input)\n", callIndent, opName)
+	out += fmt.Sprintf(
+		"%srm.metrics.RecordAPICall(\"READ_ONE\", %q, err)\n", callIndent, opName,
+	)
+	out += fmt.Sprintf("%sif err != nil {\n", callIndent)
+	out += fmt.Sprintf("%s\treturn err\n", callIndent)
+	out += fmt.Sprintf("%s}\n", callIndent)
+	page, err := pageCode(callIndentLevel)
+	if err != nil {
+		return "", err
+	}
+	out += page
+	if inputToken != "" {
+		out += fmt.Sprintf(
+			"%sif resp.%s == nil || *resp.%s == \"\" {\n",
+			callIndent, outputToken, outputToken,
+		)
+		out += fmt.Sprintf("%s\tbreak\n", callIndent)
+		out += fmt.Sprintf("%s}\n", callIndent)
+		out += fmt.Sprintf(
+			"%sinput.%s = resp.%s\n", callIndent, inputToken, outputToken,
+		)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out, nil
+}
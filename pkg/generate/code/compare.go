@@ -22,6 +22,7 @@ import (
 	"github.com/aws-controllers-k8s/pkg/names"
 
 	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/fieldpath"
 	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 )
 
@@ -316,12 +317,206 @@ func CompareResource(
 	// Number of levels of indentation to use
 	indentLevel int,
 ) (string, error) {
-	return compareResourceFields(cfg, r, deltaVarName, firstResVarName, secondResVarName, indentLevel,
+	ignoreOut, firstResVarName, secondResVarName, err := compareIgnoredPaths(
+		cfg, r, firstResVarName, secondResVarName, indentLevel,
+	)
+	if err != nil {
+		return "", err
+	}
+	out, err := compareResourceFields(cfg, r, deltaVarName, firstResVarName, secondResVarName, indentLevel,
 		func(compareConfig *ackgenconfig.CompareFieldConfig) bool {
 			// Skip fields marked as ignored in normal reconciliation
 			return compareConfig == nil || !compareConfig.IsIgnored
 		},
 	)
+	if err != nil {
+		return "", err
+	}
+	return ignoreOut + out, nil
+}
+
+// compareIgnoredPaths returns Go code that copies the two CRs under
+// comparison and clears, in both copies, the values selected by the
+// resource's `compare.ignore` field paths, along with the names of the
+// variables holding the copies, which are then compared instead of the CRs.
+// It returns no code and the supplied variable names if the resource has no
+// `compare.ignore` field paths.
+//
+// Field paths are relative to the CR, e.g. `Spec.Tags["env"]` or
+// `Spec.Rules[*].Priority`. Status field paths are skipped, since only Spec
+// fields are compared.
+//
+// Output code will look something like this:
+//
+//	comparedA, comparedB := a.ko.DeepCopy(), b.ko.DeepCopy()
+//	if comparedA.Spec.Environment != nil {
+//		delete(comparedA.Spec.Environment.Variables, "STAGE")
+//	}
+//	if comparedB.Spec.Environment != nil {
+//		delete(comparedB.Spec.Environment.Variables, "STAGE")
+//	}
+//	for idx0 := range comparedA.Spec.FileSystemConfigs {
+//		if comparedA.Spec.FileSystemConfigs[idx0] != nil {
+//			comparedA.Spec.FileSystemConfigs[idx0].LocalMountPath = nil
+//		}
+//	}
+//	if len(comparedA.Spec.Layers) > 0 {
+//		comparedA.Spec.Layers = append(comparedA.Spec.Layers[:0:0], comparedA.Spec.Layers[1:]...)
+//	}
+//	...
+//
+// A list element selected by index is removed from the list, and a wildcard
+// selecting every element of a list (or every value of a map) clears the
+// whole list (or map).
+func compareIgnoredPaths(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// String representing the name of the variable that represents the first
+	// CR under comparison, e.g. "a.ko"
+	firstResVarName string,
+	// String representing the name of the variable that represents the
+	// second CR under comparison, e.g. "b.ko"
+	secondResVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, string, string, error) {
+	ignorePaths := r.CompareIgnoredFields()
+	if len(ignorePaths) == 0 {
+		return "", firstResVarName, secondResVarName, nil
+	}
+	specPrefix := strings.TrimPrefix(cfg.PrefixConfig.SpecField, ".")
+	statusPrefix := strings.TrimPrefix(cfg.PrefixConfig.StatusField, ".")
+	koVarNames := []string{"comparedA", "comparedB"}
+	indent := strings.Repeat("\t", indentLevel)
+
+	out := fmt.Sprintf(
+		"%s%s, %s := %s.DeepCopy(), %s.DeepCopy()\n",
+		indent, koVarNames[0], koVarNames[1], firstResVarName, secondResVarName,
+	)
+	for _, ignorePath := range ignorePaths {
+		fp, err := fieldpath.Parse(ignorePath)
+		if err != nil {
+			return "", "", "", fmt.Errorf(
+				"resource %q: compare.ignore: %w", r.Names.Original, err,
+			)
+		}
+		switch fp.Front() {
+		case statusPrefix:
+			continue
+		case specPrefix:
+			fp.PopFront()
+		}
+		parts, err := compareIgnoredPathParts(r, fp)
+		if err != nil {
+			return "", "", "", fmt.Errorf(
+				"resource %q: compare.ignore path %q: %w",
+				r.Names.Original, ignorePath, err,
+			)
+		}
+		last := parts[len(parts)-1]
+		key, isKey := fieldpath.Key(last.part)
+		for _, koVarName := range koVarNames {
+			var traversal, accessor string
+			var depth int
+			var clear string
+			if isKey && last.mapValues {
+				// delete(comparedA.Spec.Environment.Variables, "STAGE")
+				traversal, accessor, depth = lateInitTraversal(
+					parts[:len(parts)-1], []string{koVarName}, indentLevel,
+				)
+				clear = fmt.Sprintf("delete(%s.%s, %q)", koVarName, accessor, key)
+			} else if index, isIndex := fieldpath.Index(last.part); isIndex {
+				// The selected element is removed rather than set to nil,
+				// since the comparison functions of lists of scalars
+				// dereference every element.
+				//
+				// if len(comparedA.Spec.Layers) > 0 {
+				// 	comparedA.Spec.Layers = append(comparedA.Spec.Layers[:0:0], comparedA.Spec.Layers[1:]...)
+				// }
+				traversal, accessor, depth = lateInitTraversal(
+					parts[:len(parts)-1], []string{koVarName}, indentLevel,
+				)
+				list := koVarName + "." + accessor
+				traversal += fmt.Sprintf(
+					"%sif len(%s) > %d {\n",
+					strings.Repeat("\t", indentLevel+depth), list, index,
+				)
+				depth++
+				clear = fmt.Sprintf(
+					"%s = append(%s[:%d:%d], %s[%d:]...)",
+					list, list, index, index, list, index+1,
+				)
+			} else if fieldpath.IsWildcard(last.part) {
+				// Every element is ignored, so the whole list (or map) is.
+				//
+				// comparedA.Spec.Layers = nil
+				traversal, accessor, depth = lateInitTraversal(
+					parts[:len(parts)-1], []string{koVarName}, indentLevel,
+				)
+				clear = fmt.Sprintf("%s.%s = nil", koVarName, accessor)
+			} else {
+				// comparedA.Spec.Description = nil
+				traversal, accessor, depth = lateInitTraversal(
+					parts, []string{koVarName}, indentLevel,
+				)
+				clear = fmt.Sprintf("%s.%s = nil", koVarName, accessor)
+			}
+			out += traversal
+			out += fmt.Sprintf("%s%s\n", strings.Repeat("\t", indentLevel+depth), clear)
+			for ; depth > 0; depth-- {
+				out += fmt.Sprintf("%s}\n", strings.Repeat("\t", indentLevel+depth-1))
+			}
+		}
+	}
+	return out, koVarNames[0], koVarNames[1], nil
+}
+
+// compareIgnoredPathParts returns the parts of the supplied `compare.ignore`
+// field path, relative to the Spec, with every field name replaced by the Go
+// name of the field it resolves to. It returns an error if a field name does
+// not resolve to a Spec field or a member of its parent field, or if a map
+// key selects an element of a list.
+func compareIgnoredPathParts(
+	r *model.CRD,
+	fp *fieldpath.Path,
+) ([]lateInitPathPart, error) {
+	if fp.Size() == 0 {
+		return nil, fmt.Errorf("path selects the whole Spec")
+	}
+	if _, found := r.SpecFields[fp.Front()]; !found {
+		if !fieldInSpecByName(r, fp.Front()) {
+			return nil, fmt.Errorf("%q is not a Spec field", fp.Front())
+		}
+	}
+	field := lateInitTopLevelField(r, fp.Front())
+	for idx := 1; idx < fp.Size(); idx++ {
+		part := fp.At(idx)
+		if fieldpath.IsSelector(part) {
+			continue
+		}
+		field = lateInitMemberField(field, part)
+		if field == nil {
+			return nil, fmt.Errorf("%q is not a member of its parent field", part)
+		}
+	}
+	parts := lateInitFieldPathParts(r, fp.String())
+	for _, part := range parts {
+		if _, isKey := fieldpath.Key(part.part); isKey && !part.mapValues {
+			return nil, fmt.Errorf("map key %s selects an element of a list", part.part)
+		}
+	}
+	return parts, nil
+}
+
+// fieldInSpecByName returns true if the CRD has a top-level Spec field whose
+// original or Go name is the supplied name
+func fieldInSpecByName(r *model.CRD, name string) bool {
+	for _, f := range r.SpecFields {
+		if f.Names.Original == name || f.Names.Camel == name {
+			return true
+		}
+	}
+	return false
 }
 
 // compareNil outputs Go code that compares two field values for nullability
@@ -443,8 +638,21 @@ func compareScalar(
 	out := ""
 	indent := strings.Repeat("\t", indentLevel)
 
+	normalizers := compareNormalizers(compareConfig)
+	if len(normalizers) > 0 && shape.Type != "string" {
+		return "", fmt.Errorf("field %q: compare.normalize is not supported for shape type %s", fieldPath, shape.Type)
+	}
+
 	switch shape.Type {
-	case "boolean", "string", "character", "byte", "short", "integer", "intEnum", "long", "float", "double":
+	case "string":
+		// if strings.ToLower(*a.ko.Spec.Name) != strings.ToLower(*b.ko.Spec.Name) {
+		out += fmt.Sprintf(
+			"%sif %s != %s {\n",
+			indent,
+			normalizeExpr(normalizers, "*"+firstResVarName),
+			normalizeExpr(normalizers, "*"+secondResVarName),
+		)
+	case "boolean", "character", "byte", "short", "integer", "intEnum", "long", "float", "double":
 		// if *a.ko.Spec.Name != *b.ko.Spec.Name {
 		out += fmt.Sprintf(
 			"%sif *%s != *%s {\n",
@@ -481,6 +689,9 @@ func compareScalar(
 //	if !ackcompare.MapStringStringPEqual(a.ko.Spec.Tags, b.ko.Spec.Tags) {
 //	  delta.Add("Spec.Tags", a.ko.Spec.Tags, b.ko.Spec.Tags)
 //	}
+//
+// When the `compare.normalize` field config is set, the values of both maps
+// are normalized before they are compared. See normalizeExpr.
 func compareMap(
 	cfg *ackgenconfig.Config,
 	r *model.CRD,
@@ -518,8 +729,19 @@ func compareMap(
 
 	valType := shape.ValueRef.Shape.Type
 
-	switch valType {
-	case "string":
+	normalizers := compareNormalizers(compareConfig)
+
+	switch {
+	case len(normalizers) > 0 && valType == "string":
+		// if !ackcompare.MapStringStringPEqual(normalizeStringPMap(a.ko.Spec.Tags, func(v string) string { return strings.ToLower(v) }), normalizeStringPMap(b.ko.Spec.Tags, func(v string) string { return strings.ToLower(v) })) {
+		normalizeFunc := normalizeFuncLiteral(normalizers)
+		out += fmt.Sprintf(
+			"%sif !ackcompare.MapStringStringPEqual(normalizeStringPMap(%s, %s), normalizeStringPMap(%s, %s)) {\n",
+			indent, firstResVarName, normalizeFunc, secondResVarName, normalizeFunc,
+		)
+	case len(normalizers) > 0:
+		return "", fmt.Errorf("field %q: compare.normalize is not supported for map value type %s", fieldPath, valType)
+	case valType == "string":
 		// if !ackcompare.MapStringStringPEqual(a.ko.Spec.Tags, b.ko.Spec.Tags) {
 		out += fmt.Sprintf(
 			"%sif !ackcompare.MapStringStringPEqual(%s, %s) {\n",
@@ -558,6 +780,12 @@ func compareMap(
 //	if !ackcompare.SliceStringPEqual(a.ko.Spec.SecurityGroupIDs, b.ko.Spec.SecurityGroupIDs) {
 //	  delta.Add("Spec.SecurityGroupIDs", a.ko.Spec.SecurityGroupIDs, b.ko.Spec.SecurityGroupIDs)
 //	}
+//
+// The `compare.list_semantics` field config changes how the elements are
+// matched. See compareSliceAsSet and compareSliceByKey.
+//
+// When the `compare.normalize` field config is set, the elements of both
+// slices are normalized before they are compared. See normalizeExpr.
 func compareSlice(
 	cfg *ackgenconfig.Config,
 	r *model.CRD,
@@ -589,6 +817,70 @@ func compareSlice(
 
 	elemType := shape.MemberRef.Shape.Type
 
+	listSemantics := ""
+	if compareConfig != nil {
+		listSemantics = compareConfig.ListSemantics
+	}
+
+	normalizers := compareNormalizers(compareConfig)
+	if len(normalizers) > 0 {
+		if elemType != "string" {
+			return "", fmt.Errorf("field %q: compare.normalize is not supported for list element type %s", fieldPath, elemType)
+		}
+		// ackcompare.SliceStringPEqual ignores the order of elements, so
+		// ordered lists are compared with DeepEqual instead.
+		equalFunc := "ackcompare.SliceStringPEqual"
+		if listSemantics == ackgenconfig.ListSemanticsOrdered {
+			equalFunc = "equality.Semantic.Equalities.DeepEqual"
+		}
+		// if !ackcompare.SliceStringPEqual(normalizeStringPSlice(a.ko.Spec.Aliases, func(v string) string { return strings.ToLower(v) }), normalizeStringPSlice(b.ko.Spec.Aliases, func(v string) string { return strings.ToLower(v) })) {
+		normalizeFunc := normalizeFuncLiteral(normalizers)
+		out += fmt.Sprintf(
+			"%sif !%s(normalizeStringPSlice(%s, %s), normalizeStringPSlice(%s, %s)) {\n",
+			indent, equalFunc, firstResVarName, normalizeFunc, secondResVarName, normalizeFunc,
+		)
+		out += fmt.Sprintf(
+			"%s\t%s.Add(\"%s\", %s, %s)\n",
+			indent, deltaVarName, fieldPath, firstResVarName, secondResVarName,
+		)
+		out += fmt.Sprintf(
+			"%s}\n", indent,
+		)
+		return out, nil
+	}
+
+	switch listSemantics {
+	case ackgenconfig.ListSemanticsSet:
+		if elemType != "string" {
+			return compareSliceAsSet(
+				deltaVarName, firstResVarName, secondResVarName, fieldPath,
+				indentLevel,
+			), nil
+		}
+		// ackcompare.SliceStringPEqual already ignores the order of elements
+	case ackgenconfig.ListSemanticsKeyed:
+		return compareSliceByKey(
+			compareConfig, shape, deltaVarName, firstResVarName,
+			secondResVarName, fieldPath, indentLevel,
+		)
+	case ackgenconfig.ListSemanticsOrdered:
+		if elemType == "string" {
+			// if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.SecurityGroupIDs, b.ko.Spec.SecurityGroupIDs) {
+			out += fmt.Sprintf(
+				"%sif !equality.Semantic.Equalities.DeepEqual(%s, %s) {\n",
+				indent, firstResVarName, secondResVarName,
+			)
+			out += fmt.Sprintf(
+				"%s\t%s.Add(\"%s\", %s, %s)\n",
+				indent, deltaVarName, fieldPath, firstResVarName, secondResVarName,
+			)
+			out += fmt.Sprintf(
+				"%s}\n", indent,
+			)
+			return out, nil
+		}
+	}
+
 	switch elemType {
 	case "string":
 		// if !ackcompare.SliceStringPEqual(a.ko.Spec.SecurityGroupIDs, b.ko.Spec.SecurityGroupIDs) {
@@ -631,6 +923,322 @@ func compareSlice(
 	return out, nil
 }
 
+// HasCompareNormalizers returns true if any of the CRD's field configs has a
+// non-empty compare.normalize, meaning the generated delta code needs the
+// normalization helper functions.
+func HasCompareNormalizers(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+) bool {
+	for _, fieldConfig := range cfg.GetFieldConfigs(r.Names.Original) {
+		if len(compareNormalizers(fieldConfig.Compare)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCompareARNNormalizer returns true if any of the CRD's field configs has
+// the arn_canonical normalizer, meaning the generated delta code needs the
+// account and region of the resources under comparison to normalize ARNs.
+func HasCompareARNNormalizer(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+) bool {
+	for _, fieldConfig := range cfg.GetFieldConfigs(r.Names.Original) {
+		for _, normalizer := range compareNormalizers(fieldConfig.Compare) {
+			if normalizer.Name == ackgenconfig.NormalizerARNCanonical {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compareNormalizers returns the normalizers configured for a field, if any.
+func compareNormalizers(
+	compareConfig *ackgenconfig.CompareFieldConfig,
+) []ackgenconfig.NormalizerConfig {
+	if compareConfig == nil {
+		return nil
+	}
+	return compareConfig.Normalize
+}
+
+// normalizeExpr returns a Go expression that applies the supplied normalizers,
+// in order, to the string expression valExpr. The normalizeXXX functions are
+// emitted in the generated delta.go when HasCompareNormalizers is true.
+//
+// Output code will look something like this:
+//
+//	strings.TrimSuffix(strings.ToLower(*a.ko.Spec.Name), ".")
+func normalizeExpr(
+	normalizers []ackgenconfig.NormalizerConfig,
+	valExpr string,
+) string {
+	out := valExpr
+	for _, normalizer := range normalizers {
+		switch normalizer.Name {
+		case ackgenconfig.NormalizerLowercase:
+			out = fmt.Sprintf("strings.ToLower(%s)", out)
+		case ackgenconfig.NormalizerTrim:
+			out = fmt.Sprintf("strings.TrimSpace(%s)", out)
+		case ackgenconfig.NormalizerTrimSuffix:
+			suffix := ""
+			if normalizer.Argument != nil {
+				suffix = *normalizer.Argument
+			}
+			out = fmt.Sprintf("strings.TrimSuffix(%s, %q)", out, suffix)
+		case ackgenconfig.NormalizerJSONCanonical:
+			out = fmt.Sprintf("normalizeJSONCanonical(%s)", out)
+		case ackgenconfig.NormalizerARNCanonical:
+			out = fmt.Sprintf("normalizeARNCanonical(%s)", out)
+		case ackgenconfig.NormalizerNumericString:
+			out = fmt.Sprintf("normalizeNumericString(%s)", out)
+		case ackgenconfig.NormalizerDuration:
+			out = fmt.Sprintf("normalizeDuration(%s)", out)
+		}
+	}
+	return out
+}
+
+// normalizeFuncLiteral returns a Go function literal that applies the
+// supplied normalizers to a string. It is passed to the normalizeStringPSlice
+// and normalizeStringPMap functions emitted in the generated delta.go.
+//
+// Output code will look something like this:
+//
+//	func(v string) string { return strings.ToLower(v) }
+func normalizeFuncLiteral(
+	normalizers []ackgenconfig.NormalizerConfig,
+) string {
+	return fmt.Sprintf(
+		"func(v string) string { return %s }", normalizeExpr(normalizers, "v"),
+	)
+}
+
+// compareSliceAsSet outputs Go code that compares the elements of two slices
+// regardless of their order and, if an element of the first slice has no
+// equal counterpart in the second slice, adds the difference to a variable
+// representing an `ackcompare.Delta`. Each element of the second slice can
+// only be matched once, so duplicated elements are accounted for.
+//
+// The caller is expected to have already verified that both slices have the
+// same length.
+//
+// Output code will look something like this:
+//
+//	matched := make([]bool, len(b.ko.Spec.Rules))
+//	for _, desiredElem := range a.ko.Spec.Rules {
+//	  found := false
+//	  for i, latestElem := range b.ko.Spec.Rules {
+//	    if !matched[i] && equality.Semantic.Equalities.DeepEqual(desiredElem, latestElem) {
+//	      matched[i] = true
+//	      found = true
+//	      break
+//	    }
+//	  }
+//	  if !found {
+//	    delta.Add("Spec.Rules", a.ko.Spec.Rules, b.ko.Spec.Rules)
+//	    break
+//	  }
+//	}
+func compareSliceAsSet(
+	// String representing the name of the variable that is of type
+	// `*ackcompare.Delta`. We will generate Go code that calls the `Add()`
+	// method of this variable when differences between fields are detected.
+	deltaVarName string,
+	// String representing the name of the variable that represents the first
+	// CR under comparison. This will typically be something like
+	// "a.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	firstResVarName string,
+	// String representing the name of the variable that represents the second
+	// CR under comparison. This will typically be something like
+	// "b.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	secondResVarName string,
+	// String indicating the current field path being evaluated, e.g.
+	// "Author.Name". This does not include the top-level Spec or Status
+	// struct.
+	fieldPath string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+
+	out += fmt.Sprintf("%smatched := make([]bool, len(%s))\n", indent, secondResVarName)
+	out += fmt.Sprintf("%sfor _, desiredElem := range %s {\n", indent, firstResVarName)
+	out += fmt.Sprintf("%s\tfound := false\n", indent)
+	out += fmt.Sprintf("%s\tfor i, latestElem := range %s {\n", indent, secondResVarName)
+	out += fmt.Sprintf(
+		"%s\t\tif !matched[i] && equality.Semantic.Equalities.DeepEqual(desiredElem, latestElem) {\n",
+		indent,
+	)
+	out += fmt.Sprintf("%s\t\t\tmatched[i] = true\n", indent)
+	out += fmt.Sprintf("%s\t\t\tfound = true\n", indent)
+	out += fmt.Sprintf("%s\t\t\tbreak\n", indent)
+	out += fmt.Sprintf("%s\t\t}\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s\tif !found {\n", indent)
+	out += fmt.Sprintf(
+		"%s\t\t%s.Add(\"%s\", %s, %s)\n",
+		indent, deltaVarName, fieldPath, firstResVarName, secondResVarName,
+	)
+	out += fmt.Sprintf("%s\t\tbreak\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+
+	return out
+}
+
+// compareSliceByKey outputs Go code that matches the struct elements of two
+// slices by the value of the `compare.key_member` and compares the matched
+// elements. The first element of the first slice that has no counterpart in
+// the second slice, or differs from its counterpart, is added to a variable
+// representing an `ackcompare.Delta` at the path "<fieldPath>.<key value>", so
+// that callers can tell which element changed while `DifferentAt(fieldPath)`
+// still matches. A list is reported once, so that its differences are not
+// inflated by the number of its elements. If an element of the first slice
+// has no key, the whole slice is reported as different.
+//
+// The caller is expected to have already verified that both slices have the
+// same length.
+//
+// Output code will look something like this:
+//
+//	latestIndexByKey := make(map[string]int, len(b.ko.Spec.Rules))
+//	for i, latestElem := range b.ko.Spec.Rules {
+//	  if latestElem != nil && latestElem.Name != nil {
+//	    latestIndexByKey[*latestElem.Name] = i
+//	  }
+//	}
+//	for _, desiredElem := range a.ko.Spec.Rules {
+//	  if desiredElem == nil || desiredElem.Name == nil {
+//	    delta.Add("Spec.Rules", a.ko.Spec.Rules, b.ko.Spec.Rules)
+//	    break
+//	  }
+//	  latestIndex, ok := latestIndexByKey[*desiredElem.Name]
+//	  if !ok {
+//	    delta.Add("Spec.Rules."+*desiredElem.Name, desiredElem, nil)
+//	    break
+//	  }
+//	  if !equality.Semantic.Equalities.DeepEqual(desiredElem, b.ko.Spec.Rules[latestIndex]) {
+//	    delta.Add("Spec.Rules."+*desiredElem.Name, desiredElem, b.ko.Spec.Rules[latestIndex])
+//	    break
+//	  }
+//	}
+func compareSliceByKey(
+	// struct informing code generator how to compare the field values
+	compareConfig *ackgenconfig.CompareFieldConfig,
+	// struct describing the SDK type of the field being compared
+	shape *awssdkmodel.Shape,
+	// String representing the name of the variable that is of type
+	// `*ackcompare.Delta`. We will generate Go code that calls the `Add()`
+	// method of this variable when differences between fields are detected.
+	deltaVarName string,
+	// String representing the name of the variable that represents the first
+	// CR under comparison. This will typically be something like
+	// "a.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	firstResVarName string,
+	// String representing the name of the variable that represents the second
+	// CR under comparison. This will typically be something like
+	// "b.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	secondResVarName string,
+	// String indicating the current field path being evaluated, e.g.
+	// "Author.Name". This does not include the top-level Spec or Status
+	// struct.
+	fieldPath string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+
+	keyMemberName, err := listKeyMemberName(compareConfig, shape)
+	if err != nil {
+		return "", fmt.Errorf("field %q: %w", fieldPath, err)
+	}
+	latestKey := "latestElem." + keyMemberName
+	desiredKey := "desiredElem." + keyMemberName
+	latestElem := fmt.Sprintf("%s[latestIndex]", secondResVarName)
+
+	out += fmt.Sprintf(
+		"%slatestIndexByKey := make(map[string]int, len(%s))\n",
+		indent, secondResVarName,
+	)
+	out += fmt.Sprintf("%sfor i, latestElem := range %s {\n", indent, secondResVarName)
+	out += fmt.Sprintf("%s\tif latestElem != nil && %s != nil {\n", indent, latestKey)
+	out += fmt.Sprintf("%s\t\tlatestIndexByKey[*%s] = i\n", indent, latestKey)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	out += fmt.Sprintf("%sfor _, desiredElem := range %s {\n", indent, firstResVarName)
+	out += fmt.Sprintf("%s\tif desiredElem == nil || %s == nil {\n", indent, desiredKey)
+	out += fmt.Sprintf(
+		"%s\t\t%s.Add(\"%s\", %s, %s)\n",
+		indent, deltaVarName, fieldPath, firstResVarName, secondResVarName,
+	)
+	out += fmt.Sprintf("%s\t\tbreak\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s\tlatestIndex, ok := latestIndexByKey[*%s]\n", indent, desiredKey)
+	out += fmt.Sprintf("%s\tif !ok {\n", indent)
+	out += fmt.Sprintf(
+		"%s\t\t%s.Add(\"%s.\"+*%s, desiredElem, nil)\n",
+		indent, deltaVarName, fieldPath, desiredKey,
+	)
+	out += fmt.Sprintf("%s\t\tbreak\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf(
+		"%s\tif !equality.Semantic.Equalities.DeepEqual(desiredElem, %s) {\n",
+		indent, latestElem,
+	)
+	out += fmt.Sprintf(
+		"%s\t\t%s.Add(\"%s.\"+*%s, desiredElem, %s)\n",
+		indent, deltaVarName, fieldPath, desiredKey, latestElem,
+	)
+	out += fmt.Sprintf("%s\t\tbreak\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+
+	return out, nil
+}
+
+// listKeyMemberName returns the Go name of the `compare.key_member` in the
+// struct element shape of the supplied list shape. It returns an error if the
+// list elements are not structs or if the key member is not a string member
+// of the element struct.
+func listKeyMemberName(
+	compareConfig *ackgenconfig.CompareFieldConfig,
+	shape *awssdkmodel.Shape,
+) (string, error) {
+	if compareConfig == nil || compareConfig.KeyMember == "" {
+		return "", fmt.Errorf("compare.key_member is required for keyed list semantics")
+	}
+	elemShape := shape.MemberRef.Shape
+	if elemShape == nil || elemShape.Type != "structure" {
+		return "", fmt.Errorf(
+			"keyed list semantics require a list of structs, element type is %q",
+			shape.MemberRef.Shape.Type,
+		)
+	}
+	for _, memberName := range elemShape.MemberNames() {
+		if !strings.EqualFold(memberName, compareConfig.KeyMember) {
+			continue
+		}
+		memberShape := elemShape.MemberRefs[memberName].Shape
+		if memberShape == nil || memberShape.Type != "string" {
+			return "", fmt.Errorf(
+				"compare.key_member %q must be a string member of %s",
+				compareConfig.KeyMember, elemShape.ShapeName,
+			)
+		}
+		return names.New(memberName).Camel, nil
+	}
+	return "", fmt.Errorf(
+		"compare.key_member %q is not a member of %s",
+		compareConfig.KeyMember, elemShape.ShapeName,
+	)
+}
+
 // compareIAMPolicy outputs Go code that compares two IAM policy document
 // strings using semantic comparison that handles IAM-specific semantics like
 // statement ordering independence and Action/Resource as string vs array.
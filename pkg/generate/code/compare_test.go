@@ -14,6 +14,10 @@
 package code_test
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -1236,3 +1240,467 @@ func TestCompareResource_DynamoDB_Table_NestedIAMPolicyAndDocument(t *testing.T)
 	// fields.
 	assert.Contains(got, `			if *a.ko.Spec.StreamSpecification.StreamEnabled != *b.ko.Spec.StreamSpecification.StreamEnabled {`)
 }
+
+func TestCompareResource_S3_Bucket_KeyedListSemantics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "s3", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-list-semantics-keyed.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Bucket")
+	require.NotNil(crd)
+
+	got, err := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+	require.NoError(err)
+
+	// Logging.LoggingEnabled.TargetGrants has compare.list_semantics: keyed
+	// with the Permission key member, so elements are matched by Permission
+	// instead of by position and the first element that differs is reported
+	// under its key.
+	expected := `			if len(a.ko.Spec.Logging.LoggingEnabled.TargetGrants) != len(b.ko.Spec.Logging.LoggingEnabled.TargetGrants) {
+				delta.Add("Spec.Logging.LoggingEnabled.TargetGrants", a.ko.Spec.Logging.LoggingEnabled.TargetGrants, b.ko.Spec.Logging.LoggingEnabled.TargetGrants)
+			} else if len(a.ko.Spec.Logging.LoggingEnabled.TargetGrants) > 0 {
+				latestIndexByKey := make(map[string]int, len(b.ko.Spec.Logging.LoggingEnabled.TargetGrants))
+				for i, latestElem := range b.ko.Spec.Logging.LoggingEnabled.TargetGrants {
+					if latestElem != nil && latestElem.Permission != nil {
+						latestIndexByKey[*latestElem.Permission] = i
+					}
+				}
+				for _, desiredElem := range a.ko.Spec.Logging.LoggingEnabled.TargetGrants {
+					if desiredElem == nil || desiredElem.Permission == nil {
+						delta.Add("Spec.Logging.LoggingEnabled.TargetGrants", a.ko.Spec.Logging.LoggingEnabled.TargetGrants, b.ko.Spec.Logging.LoggingEnabled.TargetGrants)
+						break
+					}
+					latestIndex, ok := latestIndexByKey[*desiredElem.Permission]
+					if !ok {
+						delta.Add("Spec.Logging.LoggingEnabled.TargetGrants."+*desiredElem.Permission, desiredElem, nil)
+						break
+					}
+					if !equality.Semantic.Equalities.DeepEqual(desiredElem, b.ko.Spec.Logging.LoggingEnabled.TargetGrants[latestIndex]) {
+						delta.Add("Spec.Logging.LoggingEnabled.TargetGrants."+*desiredElem.Permission, desiredElem, b.ko.Spec.Logging.LoggingEnabled.TargetGrants[latestIndex])
+						break
+					}
+				}
+			}
+`
+	assert.Contains(got, expected)
+}
+
+func TestCompareResource_S3_Bucket_KeyedListSemantics_Paths(t *testing.T) {
+	require := require.New(t)
+
+	goBin, err := exec.LookPath("go")
+	if testing.Short() || err != nil {
+		t.Skip("compiling the generated code requires the go toolchain")
+	}
+
+	g := testutil.NewModelForServiceWithOptions(t, "s3", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-list-semantics-keyed.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Bucket")
+	require.NotNil(crd)
+
+	got, err := code.CompareResource(
+		crd.Config(), crd, "delta", "a", "b", 1,
+	)
+	require.NoError(err)
+	compareStart := "if len(a.Spec.Logging.LoggingEnabled.TargetGrants) != len(b.Spec.Logging.LoggingEnabled.TargetGrants) {"
+	start := strings.Index(got, compareStart)
+	require.NotEqual(-1, start)
+	indent := got[strings.LastIndex(got[:start], "\n")+1 : start]
+	end := strings.Index(got[start:], "\n"+indent+"}\n")
+	require.NotEqual(-1, end)
+	compareTargetGrants := got[start : start+end+len("\n"+indent+"}\n")]
+
+	program := `package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+type TargetGrant struct {
+	Grantee    *string
+	Permission *string
+}
+
+type LoggingEnabled struct {
+	TargetGrants []*TargetGrant
+}
+
+type BucketLoggingStatus struct {
+	LoggingEnabled *LoggingEnabled
+}
+
+type BucketSpec struct {
+	Logging *BucketLoggingStatus
+}
+
+type Bucket struct {
+	Spec BucketSpec
+}
+
+// newBucket returns a bucket with the supplied permission:grantee grants
+func newBucket(grants ...[2]string) *Bucket {
+	b := &Bucket{Spec: BucketSpec{Logging: &BucketLoggingStatus{LoggingEnabled: &LoggingEnabled{}}}}
+	for _, grant := range grants {
+		permission, grantee := grant[0], grant[1]
+		b.Spec.Logging.LoggingEnabled.TargetGrants = append(
+			b.Spec.Logging.LoggingEnabled.TargetGrants,
+			&TargetGrant{Grantee: &grantee, Permission: &permission},
+		)
+	}
+	return b
+}
+
+func compare(a, b *Bucket) {
+	delta := ackcompare.NewDelta()
+	` + compareTargetGrants + `
+	for _, diff := range delta.Differences {
+		path, _ := json.Marshal(diff.Path)
+		fmt.Print(string(path), " ")
+	}
+	fmt.Println(len(delta.Differences), delta.DifferentAt("Spec.Logging.LoggingEnabled.TargetGrants"))
+}
+
+func main() {
+	compare(
+		newBucket([2]string{"READ", "alice"}, [2]string{"WRITE", "bob"}),
+		newBucket([2]string{"WRITE", "bob"}, [2]string{"READ", "alice"}),
+	)
+	compare(
+		newBucket([2]string{"READ", "alice"}, [2]string{"WRITE", "bob"}),
+		newBucket([2]string{"WRITE", "carol"}, [2]string{"READ", "alice"}),
+	)
+	compare(
+		newBucket([2]string{"READ", "alice"}, [2]string{"WRITE", "bob"}),
+		newBucket([2]string{"READ", "carol"}, [2]string{"FULL_CONTROL", "bob"}),
+	)
+	compare(
+		newBucket([2]string{"READ", "alice"}),
+		newBucket([2]string{"READ", "alice"}, [2]string{"WRITE", "bob"}),
+	)
+}
+`
+	dir := t.TempDir()
+	mainFile := filepath.Join(dir, "main.go")
+	require.NoError(os.WriteFile(mainFile, []byte(program), 0644))
+
+	// Run from this package so the program resolves the runtime module
+	// from this module's requirements.
+	cmd := exec.Command(goBin, "run", mainFile)
+	out, err := cmd.CombinedOutput()
+	require.NoError(err, "%s\n%s", out, program)
+	// The first element that changed is reported under its key, once per
+	// list, and a list whose length changed is reported as a whole.
+	require.Equal(
+		`0 false
+{"Parts":["Spec","Logging","LoggingEnabled","TargetGrants","WRITE"]} 1 true
+{"Parts":["Spec","Logging","LoggingEnabled","TargetGrants","READ"]} 1 true
+{"Parts":["Spec","Logging","LoggingEnabled","TargetGrants"]} 1 true
+`,
+		string(out),
+	)
+}
+
+func TestCompareResource_S3_Bucket_SetListSemantics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "s3", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-list-semantics-set.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Bucket")
+	require.NotNil(crd)
+
+	got, err := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+	require.NoError(err)
+
+	// Logging.LoggingEnabled.TargetGrants has compare.list_semantics: set, so
+	// elements are matched regardless of their order.
+	expected := `			} else if len(a.ko.Spec.Logging.LoggingEnabled.TargetGrants) > 0 {
+				matched := make([]bool, len(b.ko.Spec.Logging.LoggingEnabled.TargetGrants))
+				for _, desiredElem := range a.ko.Spec.Logging.LoggingEnabled.TargetGrants {
+					found := false
+					for i, latestElem := range b.ko.Spec.Logging.LoggingEnabled.TargetGrants {
+						if !matched[i] && equality.Semantic.Equalities.DeepEqual(desiredElem, latestElem) {
+							matched[i] = true
+							found = true
+							break
+						}
+					}
+					if !found {
+						delta.Add("Spec.Logging.LoggingEnabled.TargetGrants", a.ko.Spec.Logging.LoggingEnabled.TargetGrants, b.ko.Spec.Logging.LoggingEnabled.TargetGrants)
+						break
+					}
+				}
+			}
+`
+	assert.Contains(got, expected)
+	assert.NotContains(got, "if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.Logging.LoggingEnabled.TargetGrants, b.ko.Spec.Logging.LoggingEnabled.TargetGrants)")
+}
+
+func TestCompareResource_Lambda_Function_Normalize(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-compare-normalize.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+	assert.True(code.HasCompareNormalizers(crd.Config(), crd))
+	assert.True(code.HasCompareARNNormalizer(crd.Config(), crd))
+
+	got, err := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+	require.NoError(err)
+
+	// Scalar string fields apply the normalizers, in order, to both sides.
+	assert.Contains(got, `		if strings.TrimSuffix(strings.ToLower(*a.ko.Spec.Description), ".") != strings.TrimSuffix(strings.ToLower(*b.ko.Spec.Description), ".") {
+			delta.Add("Spec.Description", a.ko.Spec.Description, b.ko.Spec.Description)
+		}`)
+	assert.Contains(got, `		if strings.TrimSpace(*a.ko.Spec.Handler) != strings.TrimSpace(*b.ko.Spec.Handler) {`)
+	// ARNs are normalized by the function declared by newResourceDelta
+	assert.Contains(got, `		if normalizeARNCanonical(*a.ko.Spec.Role) != normalizeARNCanonical(*b.ko.Spec.Role) {`)
+	// Lists of strings normalize each element.
+	assert.Contains(got, `		if !ackcompare.SliceStringPEqual(normalizeStringPSlice(a.ko.Spec.Layers, func(v string) string { return strings.TrimSpace(v) }), normalizeStringPSlice(b.ko.Spec.Layers, func(v string) string { return strings.TrimSpace(v) })) {
+			delta.Add("Spec.Layers", a.ko.Spec.Layers, b.ko.Spec.Layers)
+		}`)
+	// Maps with string values normalize each value, at nested paths too.
+	assert.Contains(got, `			if !ackcompare.MapStringStringPEqual(normalizeStringPMap(a.ko.Spec.Environment.Variables, func(v string) string { return strings.TrimSpace(v) }), normalizeStringPMap(b.ko.Spec.Environment.Variables, func(v string) string { return strings.TrimSpace(v) })) {
+				delta.Add("Spec.Environment.Variables", a.ko.Spec.Environment.Variables, b.ko.Spec.Environment.Variables)
+			}`)
+}
+
+func TestCompareResource_Lambda_Function_IgnoredPaths(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-field-path-selectors.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	got, err := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+	require.NoError(err)
+	// Both CRs are copied and the ignored values cleared before comparing.
+	assert.Contains(got, `	comparedA, comparedB := a.ko.DeepCopy(), b.ko.DeepCopy()
+	comparedA.Spec.Description = nil
+	comparedB.Spec.Description = nil
+	if comparedA.Spec.Environment != nil {
+		delete(comparedA.Spec.Environment.Variables, "STAGE")
+	}`)
+	assert.Contains(got, `	if comparedB.Spec.FileSystemConfigs != nil {
+		for idx0 := range comparedB.Spec.FileSystemConfigs {
+			if comparedB.Spec.FileSystemConfigs[idx0] != nil {
+				comparedB.Spec.FileSystemConfigs[idx0].LocalMountPath = nil
+			}
+		}
+	}`)
+	// Ignored list elements are removed, not set to nil.
+	assert.Contains(got, `	if len(comparedA.Spec.Layers) > 0 {
+		comparedA.Spec.Layers = append(comparedA.Spec.Layers[:0:0], comparedA.Spec.Layers[1:]...)
+	}`)
+	assert.Contains(got, `delta.Add("Spec.Description", comparedA.Spec.Description, comparedB.Spec.Description)`)
+	assert.NotContains(got, "a.ko.Spec")
+}
+
+func TestCompareResource_Lambda_Function_IgnoredPaths_NotApplied(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-field-path-selectors.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+	crd.Config().Resources["Function"].Compare.Apply = false
+
+	got, err := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+	require.NoError(err)
+	// Without compare.apply, the ignored paths are compared as before.
+	assert.NotContains(got, "comparedA")
+	assert.Contains(got, `delta.Add("Spec.Description", a.ko.Spec.Description, b.ko.Spec.Description)`)
+}
+
+func TestCompareResource_Lambda_Function_IgnoredPaths_Wildcard(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-field-path-selectors.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+	crd.Config().Resources["Function"].Compare.Ignore = []string{
+		"Spec.Layers[*]",
+		`Spec.Environment.Variables[*]`,
+	}
+
+	got, err := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+	require.NoError(err)
+	// Ignoring every element ignores the whole list or map.
+	assert.Contains(got, `	comparedA.Spec.Layers = nil
+	comparedB.Spec.Layers = nil
+	if comparedA.Spec.Environment != nil {
+		comparedA.Spec.Environment.Variables = nil
+	}`)
+	assert.NotContains(got, "range comparedA.Spec.Layers")
+}
+
+// TestCompareResource_Lambda_Function_IgnoredPaths_Run compiles and runs the
+// code ignoring an element of a list of strings, followed by the comparison
+// of the list, against non-empty lists.
+func TestCompareResource_Lambda_Function_IgnoredPaths_Run(t *testing.T) {
+	require := require.New(t)
+
+	goBin, err := exec.LookPath("go")
+	if testing.Short() || err != nil {
+		t.Skip("compiling the generated code requires the go toolchain")
+	}
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-field-path-selectors.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+	crd.Config().Resources["Function"].Compare.Ignore = []string{
+		"Spec.Layers[0]",
+	}
+
+	got, err := code.CompareResource(
+		crd.Config(), crd, "delta", "a", "b", 1,
+	)
+	require.NoError(err)
+	// The ignored paths are cleared first, followed by a blank line.
+	ignore, _, found := strings.Cut(got, "\n\n")
+	require.True(found)
+	start := strings.Index(got, "\tif len(comparedA.Spec.Layers) != len(comparedB.Spec.Layers) {")
+	require.NotEqual(-1, start)
+	end := strings.Index(got[start:], "\n\t}\n")
+	require.NotEqual(-1, end)
+	compareLayers := got[start : start+end+len("\n\t}\n")]
+
+	program := `package main
+
+import (
+	"fmt"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+)
+
+type FunctionSpec struct {
+	Layers []*string
+}
+
+type Function struct {
+	Spec FunctionSpec
+}
+
+func (in *Function) DeepCopy() *Function {
+	out := &Function{}
+	for _, layer := range in.Spec.Layers {
+		layer := *layer
+		out.Spec.Layers = append(out.Spec.Layers, &layer)
+	}
+	return out
+}
+
+func newFunction(layers ...string) *Function {
+	f := &Function{}
+	for _, layer := range layers {
+		layer := layer
+		f.Spec.Layers = append(f.Spec.Layers, &layer)
+	}
+	return f
+}
+
+func compare(a, b *Function) bool {
+	delta := ackcompare.NewDelta()
+` + ignore + "\n" + compareLayers + `	return delta.DifferentAt("Spec.Layers")
+}
+
+func main() {
+	a := newFunction("arn:a", "arn:shared")
+	b := newFunction("arn:b", "arn:shared")
+	fmt.Println(compare(a, b), len(a.Spec.Layers), len(b.Spec.Layers))
+	fmt.Println(compare(newFunction("arn:a", "arn:x"), newFunction("arn:b", "arn:y")))
+	fmt.Println(compare(newFunction(), newFunction("arn:b")))
+}
+`
+	dir := t.TempDir()
+	mainFile := filepath.Join(dir, "main.go")
+	require.NoError(os.WriteFile(mainFile, []byte(program), 0644))
+
+	// Run from this package so the program resolves the runtime module
+	// from this module's requirements.
+	cmd := exec.Command(goBin, "run", mainFile)
+	out, err := cmd.CombinedOutput()
+	require.NoError(err, "%s\n%s", out, program)
+	require.Equal(
+		"false 2 2\ntrue\nfalse\n",
+		string(out),
+	)
+}
+
+func TestCompareResource_Lambda_Function_IgnoredPaths_UnknownField(t *testing.T) {
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-field-path-selectors.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+	crd.Config().Resources["Function"].Compare.Ignore = []string{
+		"Spec.Environment.Unknown",
+	}
+
+	_, err := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+	require.Error(err)
+	require.Contains(err.Error(), `"Unknown" is not a member of its parent field`)
+}
+
+func TestCompareResource_Lambda_Function_NormalizeNonString(t *testing.T) {
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-compare-normalize-non-string.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	_, err := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+	require.Error(err)
+	require.Contains(err.Error(), "compare.normalize is not supported for shape type integer")
+}
@@ -0,0 +1,536 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/expression"
+	"github.com/aws-controllers-k8s/code-generator/pkg/fieldpath"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// exprType is the type of a value in a condition expression
+type exprType string
+
+const (
+	exprTypeString exprType = "string"
+	exprTypeInt    exprType = "int"
+	exprTypeFloat  exprType = "float"
+	exprTypeBool   exprType = "bool"
+	exprTypeList   exprType = "list"
+	exprTypeMap    exprType = "map"
+	exprTypeStruct exprType = "structure"
+	exprTypeOther  exprType = "unsupported"
+)
+
+// exprValue is an operand of a comparison compiled into Go code
+type exprValue struct {
+	// guards are the Go boolean expressions that must all be true for the
+	// value to be set, e.g. "r.ko.Spec.Config != nil"
+	guards []string
+	// expr is the Go expression of the value
+	expr string
+	// goType is the Go type of expr, for operands that are not literals
+	goType string
+	typ    exprType
+	// isLiteral is true if the operand is an untyped Go constant
+	isLiteral bool
+}
+
+// exprBinding binds the elements selected by the [*] wildcard of a field path
+// prefix to a loop variable, within all() and any()
+type exprBinding struct {
+	// prefix is the field path up to, and including, the wildcard, e.g.
+	// "Status.Replicas[*]"
+	prefix string
+	// elemVar is the name of the loop variable
+	elemVar string
+}
+
+// conditionExprCompiler compiles the `expr` of resource conditions into Go
+// code, type-checking the field paths against the shapes of the CRD's fields.
+type conditionExprCompiler struct {
+	crd *model.CRD
+	// rootVarName is the Go expression of the resource's Kubernetes object,
+	// e.g. "r.ko"
+	rootVarName string
+	// counter numbers the variables holding the result of quantifiers
+	counter int
+	binding *exprBinding
+}
+
+// compile returns the Go statements that must precede the Go boolean
+// expression compiled from the supplied condition expression, and that
+// boolean expression. The statements are indented with the supplied
+// indentation.
+//
+// Comparisons involving a field that is not set are false, so that e.g.
+// `Status.Status != "deleting"` is false if Status.Status is nil. Use
+// exists() and empty() to test whether fields are set.
+func (c *conditionExprCompiler) compile(
+	src string,
+	indent string,
+) (string, string, error) {
+	node, err := expression.Parse(src)
+	if err != nil {
+		return "", "", err
+	}
+	pre := ""
+	out, err := c.compileBool(node, indent, &pre)
+	if err != nil {
+		return "", "", fmt.Errorf("expression %q: %w", src, err)
+	}
+	return pre, out, nil
+}
+
+// compileBool returns the Go boolean expression compiled from the supplied
+// node, appending the statements computing the result of quantifiers to pre
+func (c *conditionExprCompiler) compileBool(
+	node expression.Node,
+	indent string,
+	pre *string,
+) (string, error) {
+	switch n := node.(type) {
+	case *expression.Binary:
+		if n.IsComparison() {
+			return c.compileComparison(n)
+		}
+		left, err := c.compileBool(n.Left, indent, pre)
+		if err != nil {
+			return "", err
+		}
+		right, err := c.compileBool(n.Right, indent, pre)
+		if err != nil {
+			return "", err
+		}
+		return paren(left) + " " + n.Op + " " + paren(right), nil
+	case *expression.Not:
+		x, err := c.compileBool(n.X, indent, pre)
+		if err != nil {
+			return "", err
+		}
+		return negate(x), nil
+	case *expression.Literal:
+		if n.Kind != expression.LiteralBool {
+			return "", fmt.Errorf("%s literal %s is not a boolean", n.Kind, n.Value)
+		}
+		return n.Value, nil
+	case *expression.Path:
+		v, err := c.compilePath(n.Path)
+		if err != nil {
+			return "", err
+		}
+		if v.typ != exprTypeBool {
+			return "", fmt.Errorf("field %q of type %s is not a boolean", n.Path, v.typ)
+		}
+		return conjunction(append(v.guards, v.expr+" != nil", "*"+v.expr)), nil
+	case *expression.Call:
+		switch n.Func {
+		case expression.FuncExists, expression.FuncEmpty:
+			return c.compilePresence(n)
+		case expression.FuncAll, expression.FuncAny:
+			return c.compileQuantifier(n, indent, pre)
+		}
+		return "", fmt.Errorf("%s() is not a boolean", n.Func)
+	}
+	return "", fmt.Errorf("%s is not a boolean", node)
+}
+
+// compileComparison returns the Go boolean expression of a comparison
+func (c *conditionExprCompiler) compileComparison(
+	n *expression.Binary,
+) (string, error) {
+	left, err := c.compileValue(n.Left)
+	if err != nil {
+		return "", err
+	}
+	if left.typ != exprTypeString && left.typ != exprTypeInt &&
+		left.typ != exprTypeFloat && left.typ != exprTypeBool {
+		return "", fmt.Errorf("cannot compare %s of type %s", n.Left, left.typ)
+	}
+	if n.Op == "in" {
+		list := n.Right.(*expression.List)
+		alternatives := make([]string, len(list.Elems))
+		for i, elem := range list.Elems {
+			right, err := c.compileValue(elem)
+			if err != nil {
+				return "", err
+			}
+			if err := checkComparable(left, right, "=="); err != nil {
+				return "", fmt.Errorf("%s in %s: %w", n.Left, list, err)
+			}
+			alternatives[i] = left.expr + " == " + right.expr
+		}
+		cmp := strings.Join(alternatives, " || ")
+		if len(alternatives) > 1 && len(left.guards) > 0 {
+			cmp = "(" + cmp + ")"
+		}
+		return conjunction(append(left.guards, cmp)), nil
+	}
+	right, err := c.compileValue(n.Right)
+	if err != nil {
+		return "", err
+	}
+	if err := checkComparable(left, right, n.Op); err != nil {
+		return "", fmt.Errorf("%s %s %s: %w", n.Left, n.Op, n.Right, err)
+	}
+	leftExpr, rightExpr := left.expr, right.expr
+	if !left.isLiteral && !right.isLiteral && left.goType != right.goType {
+		// Both operands are typed, e.g. an int64 field and the int length
+		// of a list, so they are converted to a common Go type.
+		common := "int64"
+		if left.typ == exprTypeFloat || right.typ == exprTypeFloat {
+			common = "float64"
+		}
+		if left.goType != common {
+			leftExpr = common + "(" + leftExpr + ")"
+		}
+		if right.goType != common {
+			rightExpr = common + "(" + rightExpr + ")"
+		}
+	}
+	guards := append(append([]string{}, left.guards...), right.guards...)
+	return conjunction(append(guards, leftExpr+" "+n.Op+" "+rightExpr)), nil
+}
+
+// checkComparable returns an error if the supplied values cannot be compared
+// with the supplied operator
+func checkComparable(left exprValue, right exprValue, op string) error {
+	numeric := func(v exprValue) bool {
+		return v.typ == exprTypeInt || v.typ == exprTypeFloat
+	}
+	switch {
+	case numeric(left) && numeric(right):
+		// A float literal cannot be compared to an integer field
+		if left.typ == exprTypeInt && right.typ == exprTypeFloat && right.isLiteral ||
+			right.typ == exprTypeInt && left.typ == exprTypeFloat && left.isLiteral {
+			return fmt.Errorf("cannot compare an integer to a float literal")
+		}
+		return nil
+	case left.typ != right.typ:
+		return fmt.Errorf("mismatched types %s and %s", left.typ, right.typ)
+	case op != "==" && op != "!=":
+		return fmt.Errorf("operator %s is not supported for type %s", op, left.typ)
+	}
+	return nil
+}
+
+// compileValue returns the compiled operand of a comparison
+func (c *conditionExprCompiler) compileValue(
+	node expression.Node,
+) (exprValue, error) {
+	switch n := node.(type) {
+	case *expression.Literal:
+		typ := map[expression.LiteralKind]exprType{
+			expression.LiteralString: exprTypeString,
+			expression.LiteralInt:    exprTypeInt,
+			expression.LiteralFloat:  exprTypeFloat,
+			expression.LiteralBool:   exprTypeBool,
+		}[n.Kind]
+		return exprValue{expr: n.Value, typ: typ, isLiteral: true}, nil
+	case *expression.Path:
+		v, err := c.compilePath(n.Path)
+		if err != nil {
+			return exprValue{}, err
+		}
+		switch v.typ {
+		case exprTypeString, exprTypeInt, exprTypeFloat, exprTypeBool:
+			// Scalar fields are pointers
+			v.guards = append(v.guards, v.expr+" != nil")
+			v.expr = "*" + v.expr
+		}
+		return v, nil
+	case *expression.Call:
+		if n.Func != expression.FuncLen {
+			return exprValue{}, fmt.Errorf("%s() cannot be compared", n.Func)
+		}
+		arg := n.Args[0].(*expression.Path)
+		v, err := c.compilePath(arg.Path)
+		if err != nil {
+			return exprValue{}, err
+		}
+		switch v.typ {
+		case exprTypeString:
+			v.guards = append(v.guards, v.expr+" != nil")
+			v.expr = "len(*" + v.expr + ")"
+		case exprTypeList, exprTypeMap:
+			v.expr = "len(" + v.expr + ")"
+		default:
+			return exprValue{}, fmt.Errorf("len() is not supported for field %q of type %s", arg.Path, v.typ)
+		}
+		v.typ = exprTypeInt
+		v.goType = "int"
+		return v, nil
+	}
+	return exprValue{}, fmt.Errorf("%s cannot be compared", node)
+}
+
+// compilePresence returns the Go boolean expression of exists() and empty()
+func (c *conditionExprCompiler) compilePresence(
+	n *expression.Call,
+) (string, error) {
+	arg := n.Args[0].(*expression.Path)
+	v, err := c.compilePath(arg.Path)
+	if err != nil {
+		return "", err
+	}
+	if n.Func == expression.FuncExists {
+		return conjunction(append(v.guards, v.expr+" != nil")), nil
+	}
+	switch v.typ {
+	case exprTypeString:
+		return negate(conjunction(append(v.guards, v.expr+" != nil", "*"+v.expr+` != ""`))), nil
+	case exprTypeList, exprTypeMap:
+		return negate(conjunction(append(v.guards, "len("+v.expr+") > 0"))), nil
+	}
+	if len(v.guards) == 0 {
+		return v.expr + " == nil", nil
+	}
+	return negate(conjunction(append(v.guards, v.expr+" != nil"))), nil
+}
+
+// compileQuantifier appends to pre the statements computing whether the
+// predicate of all() or any() is true for every (resp. at least one) element
+// selected by the wildcard of its field paths, and returns the name of the
+// variable holding the result.
+//
+// Sample statements for `all(Status.Replicas[*].ReplicaStatus == "ACTIVE")`:
+//
+//	all0 := true
+//	for _, elem := range r.ko.Status.Replicas {
+//		if !(elem != nil && elem.ReplicaStatus != nil && *elem.ReplicaStatus == "ACTIVE") {
+//			all0 = false
+//			break
+//		}
+//	}
+func (c *conditionExprCompiler) compileQuantifier(
+	n *expression.Call,
+	indent string,
+	pre *string,
+) (string, error) {
+	if c.binding != nil {
+		return "", fmt.Errorf("%s() cannot be nested in all() or any()", n.Func)
+	}
+	prefixes := map[string]bool{}
+	collectWildcardPrefixes(n.Args[0], prefixes)
+	if len(prefixes) != 1 {
+		return "", fmt.Errorf(
+			"the field paths in %s() must select the elements of a single list or map with a [*] wildcard",
+			n.Func,
+		)
+	}
+	var prefix string
+	for p := range prefixes {
+		prefix = p
+	}
+	containerPath := fieldpath.FromString(strings.TrimSuffix(prefix, fieldpath.Wildcard))
+	container, err := c.compilePath(containerPath)
+	if err != nil {
+		return "", err
+	}
+	if container.typ != exprTypeList && container.typ != exprTypeMap {
+		return "", fmt.Errorf("field %q of type %s cannot be iterated", containerPath, container.typ)
+	}
+
+	c.binding = &exprBinding{prefix: prefix, elemVar: "elem"}
+	defer func() { c.binding = nil }()
+	// The predicate of a quantifier cannot contain another quantifier, so
+	// there are no statements to precede it.
+	predicate, err := c.compileBool(n.Args[0], indent, pre)
+	if err != nil {
+		return "", err
+	}
+
+	varName := fmt.Sprintf("%s%d", n.Func, c.counter)
+	c.counter++
+	initial, test := "true", negate(predicate)
+	if n.Func == expression.FuncAny {
+		initial, test = "false", predicate
+	}
+	loopIndent := indent
+	out := fmt.Sprintf("%s%s := %s\n", indent, varName, initial)
+	if len(container.guards) > 0 {
+		out += fmt.Sprintf("%sif %s {\n", indent, conjunction(container.guards))
+		loopIndent += "\t"
+	}
+	out += fmt.Sprintf("%sfor _, elem := range %s {\n", loopIndent, container.expr)
+	out += fmt.Sprintf("%s\tif %s {\n", loopIndent, test)
+	out += fmt.Sprintf("%s\t\t%s = %s\n", loopIndent, varName, map[string]string{"true": "false", "false": "true"}[initial])
+	out += fmt.Sprintf("%s\t\tbreak\n", loopIndent)
+	out += fmt.Sprintf("%s\t}\n", loopIndent)
+	out += fmt.Sprintf("%s}\n", loopIndent)
+	if len(container.guards) > 0 {
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	*pre += out
+	return varName, nil
+}
+
+// collectWildcardPrefixes adds to prefixes the field path prefixes, up to and
+// including their first [*] wildcard, of the field paths in the supplied node
+func collectWildcardPrefixes(node expression.Node, prefixes map[string]bool) {
+	switch n := node.(type) {
+	case *expression.Binary:
+		collectWildcardPrefixes(n.Left, prefixes)
+		collectWildcardPrefixes(n.Right, prefixes)
+	case *expression.Not:
+		collectWildcardPrefixes(n.X, prefixes)
+	case *expression.Call:
+		for _, arg := range n.Args {
+			collectWildcardPrefixes(arg, prefixes)
+		}
+	case *expression.Path:
+		for idx := 0; idx < n.Path.Size(); idx++ {
+			if fieldpath.IsWildcard(n.Path.At(idx)) {
+				prefixes[n.Path.CopyAt(idx).String()] = true
+				return
+			}
+		}
+	}
+}
+
+// compilePath returns the Go expression of the field at the supplied path,
+// along with the guards that must be true for its containing fields to be
+// set. The expression of scalar fields is a pointer.
+func (c *conditionExprCompiler) compilePath(
+	fp *fieldpath.Path,
+) (exprValue, error) {
+	field, err := getTopLevelField(c.crd, fp.String())
+	if err != nil {
+		return exprValue{}, fmt.Errorf("cannot find field for path %q: %w", fp, err)
+	}
+	shapeRef := fieldPathShapeRef(field, fp)
+	if shapeRef == nil || shapeRef.Shape == nil {
+		return exprValue{}, fmt.Errorf("cannot find field for path %q", fp)
+	}
+
+	guards := []string{}
+	rootExpr := fmt.Sprintf("%s.%s", c.rootVarName, fp.Front())
+	rest := fp.Copy()
+	rest.PopFront()
+	for idx := 0; idx < fp.Size(); idx++ {
+		if !fieldpath.IsWildcard(fp.At(idx)) {
+			continue
+		}
+		prefix := fp.CopyAt(idx).String()
+		if c.binding == nil {
+			return exprValue{}, fmt.Errorf("field path %q with a [*] wildcard must be used in all() or any()", fp)
+		}
+		if prefix != c.binding.prefix {
+			return exprValue{}, fmt.Errorf(
+				"field path %q does not select the elements of %q", fp, c.binding.prefix,
+			)
+		}
+		// The path is relative to the loop variable ranging over the
+		// elements selected by the wildcard
+		rootExpr = c.binding.elemVar
+		rest = &fieldpath.Path{}
+		for rIdx := idx + 1; rIdx < fp.Size(); rIdx++ {
+			if fieldpath.IsWildcard(fp.At(rIdx)) {
+				return exprValue{}, fmt.Errorf("field path %q has more than one [*] wildcard", fp)
+			}
+			rest.PushBack(fp.At(rIdx))
+		}
+		if !rest.Empty() {
+			guards = append(guards, c.binding.elemVar+" != nil")
+		}
+		break
+	}
+
+	expr := rootExpr
+	if !rest.Empty() {
+		accesses := fieldPathAccesses(rootExpr, rest)
+		for i, access := range accesses {
+			if access.index >= 0 {
+				guards = append(guards, fmt.Sprintf("len(%s) > %d", access.container, access.index))
+			}
+			if i < len(accesses)-1 {
+				guards = append(guards, access.expr+" != nil")
+			}
+		}
+		expr = accesses[len(accesses)-1].expr
+	}
+
+	v := exprValue{guards: guards, expr: expr}
+	switch shapeRef.Shape.Type {
+	case "string":
+		v.typ, v.goType = exprTypeString, "string"
+	case "integer", "long":
+		v.typ, v.goType = exprTypeInt, "int64"
+	case "float", "double":
+		v.typ, v.goType = exprTypeFloat, "float64"
+	case "boolean":
+		v.typ, v.goType = exprTypeBool, "bool"
+	case "list":
+		v.typ = exprTypeList
+	case "map":
+		v.typ = exprTypeMap
+	case "structure":
+		v.typ = exprTypeStruct
+	default:
+		v.typ = exprTypeOther
+	}
+	return v, nil
+}
+
+// conjunction returns the Go expression of the conjunction of the supplied
+// boolean expressions
+func conjunction(exprs []string) string {
+	return strings.Join(exprs, " && ")
+}
+
+// paren returns the supplied Go expression within parentheses, unless it is a
+// single operand
+func paren(expr string) string {
+	if isSingleOperand(expr) {
+		return expr
+	}
+	return "(" + expr + ")"
+}
+
+// negate returns the Go expression of the negation of the supplied Go boolean
+// expression, removing a double negation
+func negate(expr string) string {
+	if strings.HasPrefix(expr, "!(") && isSingleOperand(expr) {
+		return expr[2 : len(expr)-1]
+	}
+	return "!" + paren(expr)
+}
+
+// isSingleOperand returns true if the supplied Go expression has no binary
+// operator outside of parentheses and string literals, e.g. "elem",
+// `!(a && b)` or `len(m["a b"])`
+func isSingleOperand(expr string) bool {
+	depth := 0
+	inString := false
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case c == ' ' && depth == 0:
+			return false
+		}
+	}
+	return true
+}
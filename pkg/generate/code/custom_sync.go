@@ -168,10 +168,18 @@ func CustomSyncCreate(
 	// reconcile to do.
 	conditions := make([]string, 0, len(fields))
 	for _, f := range fields {
+		// Tags synced by tagging operations are usually accepted by the Create
+		// operation already, in which case there is nothing left to sync.
+		if f.HasTagSync() && createInputHasMember(r, f.Names.Original) {
+			continue
+		}
 		conditions = append(conditions, fmt.Sprintf(
 			"%s.%s.%s != nil", koVarName, specPrefix, f.Names.Camel,
 		))
 	}
+	if len(conditions) == 0 {
+		return ""
+	}
 
 	out := "\n"
 	out += fmt.Sprintf(
@@ -204,3 +212,13 @@ func customSyncFieldPaths(r *model.CRD, fields []*model.Field) []string {
 func customSyncSpecPrefix(r *model.CRD) string {
 	return strings.TrimPrefix(r.Config().PrefixConfig.SpecField, ".")
 }
+
+// createInputHasMember returns true if the Input shape of the resource's Create
+// operation has the named member.
+func createInputHasMember(r *model.CRD, memberName string) bool {
+	if r.Ops.Create == nil || r.Ops.Create.InputRef.Shape == nil {
+		return false
+	}
+	_, found := r.Ops.Create.InputRef.Shape.MemberRefs[memberName]
+	return found
+}
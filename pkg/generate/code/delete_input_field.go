@@ -0,0 +1,290 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	awssdkmodel "github.com/aws-controllers-k8s/code-generator/pkg/api"
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// deleteInputTemplateMarker delimits the placeholders of the value templates
+// of Delete input members once they are executed. See
+// deleteInputValueTemplate.
+const deleteInputTemplateMarker = "\x00"
+
+// setSDKDeleteInputField returns the Go code that sets a member of the Delete
+// operation's Input shape from the sources configured in
+// `delete_operation.input_fields`, tried in the order annotation, Spec field,
+// static value.
+//
+// Sample output:
+//
+//	if v, ok := r.ko.GetAnnotations()["rds.services.k8s.aws/skip-final-snapshot"]; ok {
+//		parsed, err := strconv.ParseBool(v)
+//		if err != nil {
+//			return nil, ackerr.NewTerminalError(fmt.Errorf(
+//				"invalid value %q of annotation %q: %w",
+//				v, "rds.services.k8s.aws/skip-final-snapshot", err,
+//			))
+//		}
+//		res.SkipFinalSnapshot = aws.Bool(parsed)
+//	} else {
+//		res.SkipFinalSnapshot = aws.Bool(false)
+//	}
+func setSDKDeleteInputField(
+	r *model.CRD,
+	// The exact name of the Input shape member
+	memberName string,
+	memberRef *awssdkmodel.ShapeRef,
+	fieldCfg *ackgenconfig.DeleteInputFieldConfig,
+	// The variable name of the resource's Kubernetes object — "r.ko"
+	sourceVarName string,
+	// The variable name of the Input shape — "res"
+	targetVarName string,
+	indentLevel int,
+) (string, error) {
+	memberType := memberRef.Shape.Type
+	switch memberType {
+	case "string", "boolean", "integer", "long":
+	default:
+		return "", fmt.Errorf(
+			"member %q: type %s not supported, must be a string, boolean, integer or long",
+			memberName, memberType,
+		)
+	}
+	if fieldCfg == nil {
+		return "", fmt.Errorf("member %q: no source configured", memberName)
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	targetVarPath := targetVarName + "." + memberName
+	out := ""
+	branch := "if"
+
+	if fieldCfg.Annotation != "" {
+		// if v, ok := r.ko.GetAnnotations()["..."]; ok {
+		out += fmt.Sprintf(
+			"%sif v, ok := %s.GetAnnotations()[%q]; ok {\n",
+			indent, sourceVarName, fieldCfg.Annotation,
+		)
+		expr := "v"
+		parseFn := ""
+		switch memberType {
+		case "boolean":
+			parseFn = "strconv.ParseBool(v)"
+			expr = "parsed"
+		case "integer":
+			parseFn = "strconv.ParseInt(v, 10, 32)"
+			expr = "int32(parsed)"
+		case "long":
+			parseFn = "strconv.ParseInt(v, 10, 64)"
+			expr = "parsed"
+		}
+		if parseFn != "" {
+			out += fmt.Sprintf("%s\tparsed, err := %s\n", indent, parseFn)
+			out += fmt.Sprintf("%s\tif err != nil {\n", indent)
+			out += fmt.Sprintf("%s\t\treturn nil, ackerr.NewTerminalError(fmt.Errorf(\n", indent)
+			out += fmt.Sprintf("%s\t\t\t\"invalid value %%q of annotation %%q: %%w\",\n", indent)
+			out += fmt.Sprintf("%s\t\t\tv, %q, err,\n", indent, fieldCfg.Annotation)
+			out += fmt.Sprintf("%s\t\t))\n", indent)
+			out += fmt.Sprintf("%s\t}\n", indent)
+		}
+		out += fmt.Sprintf(
+			"%s\t%s = %s\n", indent, targetVarPath,
+			deleteInputMemberValue(memberRef, expr),
+		)
+		branch = "} else if"
+	}
+
+	if fieldCfg.SpecField != "" {
+		field, found := r.SpecFields[fieldCfg.SpecField]
+		if !found {
+			return "", fmt.Errorf(
+				"member %q: spec_field %q is not a top-level Spec field",
+				memberName, fieldCfg.SpecField,
+			)
+		}
+		goType := map[string]string{
+			"string":  "*string",
+			"boolean": "*bool",
+			"integer": "*int64",
+			"long":    "*int64",
+		}[memberType]
+		if field.GoType != goType {
+			return "", fmt.Errorf(
+				"member %q: spec_field %q is of type %s, must be %s",
+				memberName, fieldCfg.SpecField, field.GoType, goType,
+			)
+		}
+		fieldVar := fmt.Sprintf("%s.Spec.%s", sourceVarName, field.Names.Camel)
+		expr := "*" + fieldVar
+		if memberType == "integer" {
+			expr = "int32(" + expr + ")"
+		}
+		// } else if r.ko.Spec.ForceDelete != nil {
+		out += fmt.Sprintf("%s%s %s != nil {\n", indent, branch, fieldVar)
+		out += fmt.Sprintf(
+			"%s\t%s = %s\n", indent, targetVarPath,
+			deleteInputMemberValue(memberRef, expr),
+		)
+		branch = "} else if"
+	}
+
+	if fieldCfg.Value != "" {
+		var expr string
+		usesTimestamp := false
+		switch memberType {
+		case "string":
+			var err error
+			expr, usesTimestamp, err = deleteInputValueTemplate(fieldCfg.Value, sourceVarName)
+			if err != nil {
+				return "", fmt.Errorf("member %q: %w", memberName, err)
+			}
+		case "boolean":
+			value, err := strconv.ParseBool(fieldCfg.Value)
+			if err != nil {
+				return "", fmt.Errorf(
+					"member %q: invalid boolean value %q", memberName, fieldCfg.Value,
+				)
+			}
+			expr = strconv.FormatBool(value)
+		case "integer", "long":
+			bitSize := 64
+			if memberType == "integer" {
+				bitSize = 32
+			}
+			value, err := strconv.ParseInt(fieldCfg.Value, 10, bitSize)
+			if err != nil {
+				return "", fmt.Errorf(
+					"member %q: invalid %s value %q", memberName, memberType, fieldCfg.Value,
+				)
+			}
+			expr = strconv.FormatInt(value, 10)
+		}
+		valueIndent := indent
+		if branch != "if" {
+			out += fmt.Sprintf("%s} else {\n", indent)
+			valueIndent += "\t"
+		}
+		if usesTimestamp {
+			// if r.ko.DeletionTimestamp == nil {
+			//     return nil, ackerr.NewTerminalError(...)
+			// }
+			out += fmt.Sprintf("%sif %s.DeletionTimestamp == nil {\n", valueIndent, sourceVarName)
+			out += fmt.Sprintf("%s\treturn nil, ackerr.NewTerminalError(fmt.Errorf(\n", valueIndent)
+			out += fmt.Sprintf(
+				"%s\t\t\"cannot set %s from the deletion timestamp of a resource that is not being deleted\",\n",
+				valueIndent, memberName,
+			)
+			out += fmt.Sprintf("%s\t))\n", valueIndent)
+			out += fmt.Sprintf("%s}\n", valueIndent)
+		}
+		out += fmt.Sprintf(
+			"%s%s = %s\n", valueIndent, targetVarPath,
+			deleteInputMemberValue(memberRef, expr),
+		)
+		if branch == "if" {
+			return out, nil
+		}
+	}
+	out += fmt.Sprintf("%s}\n", indent)
+	return out, nil
+}
+
+// deleteInputMemberValue returns the Go expression assigned to a member of
+// the Delete operation's Input shape from the supplied non-pointer Go
+// expression of type string, bool, int32 or int64.
+func deleteInputMemberValue(
+	memberRef *awssdkmodel.ShapeRef,
+	expr string,
+) string {
+	if memberRef.Shape.IsEnum() {
+		return fmt.Sprintf("svcsdktypes.%s(%s)", memberRef.Shape.ShapeName, expr)
+	}
+	if memberRef.IsNonPointerInSDK() {
+		return expr
+	}
+	switch memberRef.Shape.Type {
+	case "boolean":
+		return "aws.Bool(" + expr + ")"
+	case "integer":
+		return "aws.Int32(" + expr + ")"
+	case "long":
+		return "aws.Int64(" + expr + ")"
+	default:
+		return "aws.String(" + expr + ")"
+	}
+}
+
+// deleteInputValueTemplate returns the Go string expression rendering the
+// supplied value template of a Delete input member, e.g.
+// `{{ .Name }}-final-{{ .Timestamp }}` becomes
+// `r.ko.Name + "-final-" + r.ko.DeletionTimestamp.UTC().Format("20060102150405")`,
+// and whether the expression refers to the deletion timestamp of the
+// resource. The deletion timestamp does not change between retried Delete
+// calls, so neither does the rendered value.
+func deleteInputValueTemplate(
+	value string,
+	// The variable name of the resource's Kubernetes object — "r.ko"
+	sourceVarName string,
+) (string, bool, error) {
+	tpl, err := template.New("value").Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid value template: %w", err)
+	}
+	placeholders := map[string]string{
+		"Name":      sourceVarName + ".Name",
+		"Namespace": sourceVarName + ".Namespace",
+		"Timestamp": sourceVarName + `.DeletionTimestamp.UTC().Format("20060102150405")`,
+	}
+	// Executing the template with markers in place of the placeholders
+	// separates the literal parts of the value from the placeholders.
+	data := struct{ Name, Namespace, Timestamp string }{
+		Name:      deleteInputTemplateMarker + "Name" + deleteInputTemplateMarker,
+		Namespace: deleteInputTemplateMarker + "Namespace" + deleteInputTemplateMarker,
+		Timestamp: deleteInputTemplateMarker + "Timestamp" + deleteInputTemplateMarker,
+	}
+	var rendered strings.Builder
+	if err := tpl.Execute(&rendered, data); err != nil {
+		return "", false, fmt.Errorf("invalid value template: %w", err)
+	}
+	parts := strings.Split(rendered.String(), deleteInputTemplateMarker)
+	exprs := []string{}
+	usesTimestamp := false
+	for i, part := range parts {
+		if i%2 == 0 {
+			if part != "" {
+				exprs = append(exprs, strconv.Quote(part))
+			}
+			continue
+		}
+		expr, found := placeholders[part]
+		if !found {
+			return "", false, fmt.Errorf(
+				"invalid value template %q: placeholders must be used as is", value,
+			)
+		}
+		usesTimestamp = usesTimestamp || part == "Timestamp"
+		exprs = append(exprs, expr)
+	}
+	if len(exprs) == 0 {
+		return `""`, false, nil
+	}
+	return strings.Join(exprs, " + "), usesTimestamp, nil
+}
@@ -15,27 +15,201 @@ package code
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
 	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/fieldpath"
 	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+
+	awssdkmodel "github.com/aws-controllers-k8s/code-generator/pkg/api"
 )
 
-func fieldGoName(r *model.CRD, configName string) string {
+// legacyMapKeyRegexp matches the legacy `..key` notation of a map key in a
+// late initialized field path, e.g. "Tags..env", which is equivalent to
+// `Tags["env"]`
+var legacyMapKeyRegexp = regexp.MustCompile(`\.\.([^.\[\]]+)`)
+
+// lateInitTopLevelField returns the top-level field of the supplied CRD with
+// the supplied name, or nil if there is no such field
+func lateInitTopLevelField(r *model.CRD, configName string) *model.Field {
 	if f, ok := r.SpecFields[configName]; ok {
-		return f.Names.Camel
+		return f
 	}
 	if f, ok := r.Fields[configName]; ok {
-		return f.Names.Camel
+		return f
+	}
+	return nil
+}
+
+// lateInitPathPart is a part of a late initialized field path, resolved
+// against the fields of a CRD
+type lateInitPathPart struct {
+	// part is either the Go name of a field, e.g. "ScanOnPush", or an element
+	// selector, e.g. `["key"]`, "[0]" or "[*]"
+	part string
+	// mapValues is true if the part is a selector of the values of a map
+	// rather than of the elements of a list
+	mapValues bool
+}
+
+// lateInitFieldPathParts returns the parts of the supplied late initialized
+// field path, with every field name replaced by the Go name of the field it
+// resolves to. Field names that do not resolve to a member of their parent
+// field are left as-is.
+func lateInitFieldPathParts(
+	r *model.CRD,
+	configName string,
+) []lateInitPathPart {
+	fp := fieldpath.FromString(
+		legacyMapKeyRegexp.ReplaceAllString(configName, `["$1"]`),
+	)
+	parts := make([]lateInitPathPart, 0, fp.Size())
+	var field *model.Field
+	var shape *awssdkmodel.Shape
+	for idx := 0; idx < fp.Size(); idx++ {
+		part := fp.At(idx)
+		if fieldpath.IsSelector(part) {
+			_, isKey := fieldpath.Key(part)
+			mapValues := isKey
+			if shape != nil {
+				mapValues = shape.Type == "map"
+				switch shape.Type {
+				case "list":
+					shape = shape.MemberRef.Shape
+				case "map":
+					shape = shape.ValueRef.Shape
+				default:
+					shape = nil
+				}
+			}
+			parts = append(parts, lateInitPathPart{part: part, mapValues: mapValues})
+			continue
+		}
+		if idx == 0 {
+			field = lateInitTopLevelField(r, part)
+		} else {
+			field = lateInitMemberField(field, part)
+		}
+		goName := part
+		shape = nil
+		if field != nil {
+			goName = field.Names.Camel
+			if field.ShapeRef != nil {
+				shape = field.ShapeRef.Shape
+			}
+		}
+		parts = append(parts, lateInitPathPart{part: goName})
 	}
-	return configName
+	return parts
+}
+
+// lateInitMemberField returns the member field of the supplied field with the
+// supplied name, or nil if there is no such member field
+func lateInitMemberField(field *model.Field, name string) *model.Field {
+	if field == nil {
+		return nil
+	}
+	if member, ok := field.MemberFields[name]; ok {
+		return member
+	}
+	for memberName, member := range field.MemberFields {
+		if strings.EqualFold(memberName, name) ||
+			strings.EqualFold(member.Names.Original, name) {
+			return member
+		}
+	}
+	return nil
+}
+
+// lateInitFieldGoPath returns the supplied late initialized field path with
+// every field name replaced by its Go name, e.g. "VpcConfig.SecurityGroupIDs"
+// for "VpcConfig.SecurityGroupIds"
+func lateInitFieldGoPath(r *model.CRD, configName string) string {
+	goPath := ""
+	for idx, part := range lateInitFieldPathParts(r, configName) {
+		if idx > 0 && !fieldpath.IsSelector(part.part) {
+			goPath += "."
+		}
+		goPath += part.part
+	}
+	return goPath
+}
+
+// lateInitTraversal returns the opening lines of the nested blocks traversing
+// every part but the last of the supplied late initialized field path in each
+// of the supplied resource variables, the accessor of the last part relative
+// to the resource variables, and the number of opened blocks.
+//
+// Fields and map values are checked for nil in every resource variable, list
+// elements selected by index are checked for existence, and the list elements
+// (or map values) selected by a wildcard are iterated over.
+func lateInitTraversal(
+	parts []lateInitPathPart,
+	koVarNames []string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, string, int) {
+	out := ""
+	accessor := "Spec"
+	depth := 0
+	wildcards := 0
+	// condition returns the conjunction of the supplied format, formatted for
+	// the accessor of each resource variable
+	condition := func(format string) string {
+		conds := make([]string, len(koVarNames))
+		for i, koVarName := range koVarNames {
+			conds[i] = fmt.Sprintf(format, koVarName+"."+accessor)
+		}
+		return strings.Join(conds, " && ")
+	}
+	openBlock := func(line string) {
+		out += fmt.Sprintf("%s%s {\n", strings.Repeat("\t", indentLevel+depth), line)
+		depth++
+	}
+	for idx, part := range parts {
+		if index, ok := fieldpath.Index(part.part); ok {
+			openBlock("if " + condition(fmt.Sprintf("len(%%s) > %d", index)))
+			accessor += part.part
+		} else if fieldpath.IsWildcard(part.part) {
+			if part.mapValues {
+				// Iterate over the values of the first resource variable,
+				// the values missing from the other ones are unset.
+				loopVar := fmt.Sprintf("key%d", wildcards)
+				openBlock(fmt.Sprintf("for %s := range %s.%s", loopVar, koVarNames[0], accessor))
+				accessor += "[" + loopVar + "]"
+			} else {
+				loopVar := fmt.Sprintf("idx%d", wildcards)
+				if len(koVarNames) == 1 {
+					openBlock(fmt.Sprintf("for %s := range %s.%s", loopVar, koVarNames[0], accessor))
+				} else {
+					openBlock(fmt.Sprintf(
+						"for %s := 0; %s; %s++",
+						loopVar, condition(loopVar+" < len(%s)"), loopVar,
+					))
+				}
+				accessor += "[" + loopVar + "]"
+			}
+			wildcards++
+		} else if fieldpath.IsSelector(part.part) {
+			accessor += part.part
+		} else {
+			accessor += "." + part.part
+		}
+		if idx != len(parts)-1 {
+			openBlock("if " + condition("%s != nil"))
+		}
+	}
+	return out, accessor, depth
 }
 
 // FindLateInitializedFieldNames outputs the code to create a sorted slice of fieldNames to
 // late initialize. This slice helps with short circuiting the AWSResourceManager.LateInitialize()
 // method if there are no fields to late initialize.
 //
+// Nested field paths are output with their field names resolved to Go names.
+//
 // Sample Output:
 // var lateInitializeFieldNames = []string{"Name"}
 func FindLateInitializedFieldNames(
@@ -59,7 +233,7 @@ func FindLateInitializedFieldNames(
 	sort.Strings(lateInitFieldNames)
 	out += fmt.Sprintf("%svar %s = []string{", indent, resVarName)
 	for _, fName := range lateInitFieldNames {
-		out += fmt.Sprintf("%q,", fieldGoName(r, fName))
+		out += fmt.Sprintf("%q,", lateInitFieldGoPath(r, fName))
 	}
 	out += "}\n"
 	return out
@@ -67,8 +241,12 @@ func FindLateInitializedFieldNames(
 
 // LateInitializeFromReadOne returns the gocode to set LateInitialization fields from the ReadOne output
 // Field path separated by '.' indicates members in a struct
-// Field path separated by '..' indicates member/key in a map
-// Note: Unlike Map, updating individual element of a list is not supported. LateInitializing complete list is supported.
+// Field path separated by '..' indicates member/key in a map, equivalent to the `map["key"]` selector
+// Selectors in square brackets indicate a list element (e.g. `list[0]`), a map value (e.g. `map["key"]`),
+// or every list element or map value (e.g. `list[*].field`), see fieldpath.Parse
+// Field names in the path are resolved to the Go names of the fields of the CRD.
+// Only unset leaves are late initialized: a leaf is set only if every struct, list element or map value
+// on its path is already set in the latest resource. List elements are matched by index.
 //
 // Sample generator config:
 // fields:
@@ -154,52 +332,26 @@ func LateInitializeFromReadOne(
 	sort.Strings(lateInitFieldNames)
 	out += fmt.Sprintf("%sobservedKo := rm.concreteResource(%s).ko.DeepCopy()\n", indent, sourceResVarName)
 	out += fmt.Sprintf("%slatestKo := rm.concreteResource(%s).ko.DeepCopy()\n", indent, targetResVarName)
-	// TODO(vijat@): Add validation for correct field path in lateInitializedFieldNames
 	for _, fName := range lateInitFieldNames {
-		// split the field name by period
-		// each substring represents a field.
-		fNameParts := strings.Split(fName, ".")
+		parts := lateInitFieldPathParts(r, fName)
 		// fNameIndentLevel tracks the indentation level for every new line added
-		// This variable is incremented when building nested if blocks and decremented when closing those if blocks.
+		// This variable is incremented when building nested blocks and decremented when closing those blocks.
 		fNameIndentLevel := indentLevel
-		// fParentPath keeps track of parent path for any fNamePart
-		fParentPath := ""
-		mapShapedParent := false
-		// for every part except last, perform the nil check
-		// entries in both source and target koVarName should not be nil
-		for i, fNamePart := range fNameParts {
-			if fNamePart == "" {
-				mapShapedParent = true
-				continue
-			}
-			indent := strings.Repeat("\t", fNameIndentLevel)
-			goName := fieldGoName(r, fNamePart)
-			fNamePartAccesor := fmt.Sprintf("Spec%s.%s", fParentPath, goName)
-			if mapShapedParent {
-				fNamePartAccesor = fmt.Sprintf("Spec%s[%q]", fParentPath, fNamePart)
-			}
-			// Handling for all parts except last one
-			if i != len(fNameParts)-1 {
-				out += fmt.Sprintf("%sif observedKo.%s != nil && latestKo.%s != nil {\n", indent, fNamePartAccesor, fNamePartAccesor)
-				// update fParentPath and fNameIndentLevel for next iteration
-				if mapShapedParent {
-					fParentPath = fmt.Sprintf("%s[%q]", fParentPath, fNamePart)
-					mapShapedParent = false
-				} else {
-					fParentPath = fmt.Sprintf("%s.%s", fParentPath, goName)
-				}
-				fNameIndentLevel = fNameIndentLevel + 1
-			} else {
-				// handle last part here
-				// for last part, set the lateInitialized field if user did not specify field value and readOne has server side defaulted value.
-				// i.e. field is not nil in sourceKoVarName but is nil in targetkoVarName
-				out += fmt.Sprintf("%sif observedKo.%s != nil && latestKo.%s == nil {\n", indent, fNamePartAccesor, fNamePartAccesor)
-				fNameIndentLevel = fNameIndentLevel + 1
-				indent = strings.Repeat("\t", fNameIndentLevel)
-				out += fmt.Sprintf("%slatestKo.%s = observedKo.%s\n", indent, fNamePartAccesor, fNamePartAccesor)
-			}
-		}
-		// Close all if blocks with proper indentation
+		// entries in both source and target koVarName should not be nil for
+		// every part except last
+		traversal, leafAccessor, depth := lateInitTraversal(
+			parts, []string{"observedKo", "latestKo"}, fNameIndentLevel,
+		)
+		out += traversal
+		fNameIndentLevel += depth
+		// for last part, set the lateInitialized field if user did not specify field value and readOne has server side defaulted value.
+		// i.e. field is not nil in sourceKoVarName but is nil in targetkoVarName
+		indent := strings.Repeat("\t", fNameIndentLevel)
+		out += fmt.Sprintf("%sif observedKo.%s != nil && latestKo.%s == nil {\n", indent, leafAccessor, leafAccessor)
+		fNameIndentLevel = fNameIndentLevel + 1
+		indent = strings.Repeat("\t", fNameIndentLevel)
+		out += fmt.Sprintf("%slatestKo.%s = observedKo.%s\n", indent, leafAccessor, leafAccessor)
+		// Close all blocks with proper indentation
 		fNameIndentLevel = fNameIndentLevel - 1
 		for fNameIndentLevel >= indentLevel {
 			out += fmt.Sprintf("%s}\n", strings.Repeat("\t", fNameIndentLevel))
@@ -214,6 +366,8 @@ func LateInitializeFromReadOne(
 // If all the fields are not late initialized, this method also returns the requeue delay needed to attempt
 // late initialization again.
 //
+// Field paths are resolved the same way as in LateInitializeFromReadOne.
+//
 // Sample GeneratorConfig:
 // fields:
 //
@@ -299,48 +453,23 @@ func IncompleteLateInitialization(
 	sort.Strings(lateInitFieldNames)
 	out += fmt.Sprintf("%sko := rm.concreteResource(%s).ko.DeepCopy()\n", indent, resVarName)
 	for _, fName := range lateInitFieldNames {
-		// split the field name by period
-		// each substring represents a field.
-		fNameParts := strings.Split(fName, ".")
+		parts := lateInitFieldPathParts(r, fName)
 		// fNameIndentLevel tracks the indentation level for every new line added
-		// This variable is incremented when building nested if blocks and decremented when closing those if blocks.
+		// This variable is incremented when building nested blocks and decremented when closing those blocks.
 		fNameIndentLevel := indentLevel
-		// fParentPath keeps track of parent path for any fNamePart
-		fParentPath := ""
-		mapShapedParent := false
-		for i, fNamePart := range fNameParts {
-			if fNamePart == "" {
-				mapShapedParent = true
-				continue
-			}
-			indent := strings.Repeat("\t", fNameIndentLevel)
-			goName := fieldGoName(r, fNamePart)
-			fNamePartAccesor := fmt.Sprintf("Spec%s.%s", fParentPath, goName)
-			if mapShapedParent {
-				fNamePartAccesor = fmt.Sprintf("Spec%s[%q]", fParentPath, fNamePart)
-			}
-			// Handling for all parts except last one
-			if i != len(fNameParts)-1 {
-				out += fmt.Sprintf("%sif ko.%s != nil {\n", indent, fNamePartAccesor)
-				// update fParentPath and fNameIndentLevel for next iteration
-				if mapShapedParent {
-					fParentPath = fmt.Sprintf("%s[%q]", fParentPath, fNamePart)
-					mapShapedParent = false
-				} else {
-					fParentPath = fmt.Sprintf("%s.%s", fParentPath, goName)
-				}
-				fNameIndentLevel = fNameIndentLevel + 1
-			} else {
-				// handle last part here
-				// for last part, if the late initialized field is still nil, calculate the retry backoff using
-				// acktypes.LateInitializationRetryConfig abstraction and set the incompleteInitialization flag to true
-				out += fmt.Sprintf("%sif ko.%s == nil {\n", indent, fNamePartAccesor)
-				fNameIndentLevel = fNameIndentLevel + 1
-				indent = strings.Repeat("\t", fNameIndentLevel)
-				out += fmt.Sprintf("%sreturn true\n", indent)
-			}
-		}
-		// Close all if blocks with proper indentation
+		traversal, leafAccessor, depth := lateInitTraversal(
+			parts, []string{"ko"}, fNameIndentLevel,
+		)
+		out += traversal
+		fNameIndentLevel += depth
+		// for last part, if the late initialized field is still nil, calculate the retry backoff using
+		// acktypes.LateInitializationRetryConfig abstraction and set the incompleteInitialization flag to true
+		indent := strings.Repeat("\t", fNameIndentLevel)
+		out += fmt.Sprintf("%sif ko.%s == nil {\n", indent, leafAccessor)
+		fNameIndentLevel = fNameIndentLevel + 1
+		indent = strings.Repeat("\t", fNameIndentLevel)
+		out += fmt.Sprintf("%sreturn true\n", indent)
+		// Close all blocks with proper indentation
 		fNameIndentLevel = fNameIndentLevel - 1
 		for fNameIndentLevel >= indentLevel {
 			out += fmt.Sprintf("%s}\n", strings.Repeat("\t", fNameIndentLevel))
@@ -266,3 +266,88 @@ func Test_LateInitializeFromReadOne_UnpackedAttributeField(t *testing.T) {
 	return &resource{latestKo}`
 	assert.Equal(expected, code.LateInitializeFromReadOne(crd.Config(), crd, "observed", "latest", 1))
 }
+
+func Test_LateInitialization_NestedFieldPaths(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{GeneratorConfigFile: "generator-nested-late-initialize.yaml"})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+	expectedFieldNames :=
+		`	var lateInitializeFieldNames = []string{"Environment.Variables[*]","FileSystemConfigs[*].LocalMountPath","ImageConfig.WorkingDirectory","Layers[0]","TracingConfig.Mode",}
+`
+	assert.Equal(expectedFieldNames, code.FindLateInitializedFieldNames(crd.Config(), crd, "lateInitializeFieldNames", 1))
+	expectedReadOne :=
+		`	observedKo := rm.concreteResource(observed).ko.DeepCopy()
+	latestKo := rm.concreteResource(latest).ko.DeepCopy()
+	if observedKo.Spec.Environment != nil && latestKo.Spec.Environment != nil {
+		if observedKo.Spec.Environment.Variables != nil && latestKo.Spec.Environment.Variables != nil {
+			for key0 := range observedKo.Spec.Environment.Variables {
+				if observedKo.Spec.Environment.Variables[key0] != nil && latestKo.Spec.Environment.Variables[key0] == nil {
+					latestKo.Spec.Environment.Variables[key0] = observedKo.Spec.Environment.Variables[key0]
+				}
+			}
+		}
+	}
+	if observedKo.Spec.FileSystemConfigs != nil && latestKo.Spec.FileSystemConfigs != nil {
+		for idx0 := 0; idx0 < len(observedKo.Spec.FileSystemConfigs) && idx0 < len(latestKo.Spec.FileSystemConfigs); idx0++ {
+			if observedKo.Spec.FileSystemConfigs[idx0] != nil && latestKo.Spec.FileSystemConfigs[idx0] != nil {
+				if observedKo.Spec.FileSystemConfigs[idx0].LocalMountPath != nil && latestKo.Spec.FileSystemConfigs[idx0].LocalMountPath == nil {
+					latestKo.Spec.FileSystemConfigs[idx0].LocalMountPath = observedKo.Spec.FileSystemConfigs[idx0].LocalMountPath
+				}
+			}
+		}
+	}
+	if observedKo.Spec.ImageConfig != nil && latestKo.Spec.ImageConfig != nil {
+		if observedKo.Spec.ImageConfig.WorkingDirectory != nil && latestKo.Spec.ImageConfig.WorkingDirectory == nil {
+			latestKo.Spec.ImageConfig.WorkingDirectory = observedKo.Spec.ImageConfig.WorkingDirectory
+		}
+	}
+	if observedKo.Spec.Layers != nil && latestKo.Spec.Layers != nil {
+		if len(observedKo.Spec.Layers) > 0 && len(latestKo.Spec.Layers) > 0 {
+			if observedKo.Spec.Layers[0] != nil && latestKo.Spec.Layers[0] == nil {
+				latestKo.Spec.Layers[0] = observedKo.Spec.Layers[0]
+			}
+		}
+	}
+	if observedKo.Spec.TracingConfig != nil && latestKo.Spec.TracingConfig != nil {
+		if observedKo.Spec.TracingConfig.Mode != nil && latestKo.Spec.TracingConfig.Mode == nil {
+			latestKo.Spec.TracingConfig.Mode = observedKo.Spec.TracingConfig.Mode
+		}
+	}
+	return &resource{latestKo}`
+	assert.Equal(expectedReadOne, code.LateInitializeFromReadOne(crd.Config(), crd, "observed", "latest", 1))
+	// Environment.Variables[*] skips the incomplete check
+	expectedIncomplete :=
+		`	ko := rm.concreteResource(latest).ko.DeepCopy()
+	if ko.Spec.FileSystemConfigs != nil {
+		for idx0 := range ko.Spec.FileSystemConfigs {
+			if ko.Spec.FileSystemConfigs[idx0] != nil {
+				if ko.Spec.FileSystemConfigs[idx0].LocalMountPath == nil {
+					return true
+				}
+			}
+		}
+	}
+	if ko.Spec.ImageConfig != nil {
+		if ko.Spec.ImageConfig.WorkingDirectory == nil {
+			return true
+		}
+	}
+	if ko.Spec.Layers != nil {
+		if len(ko.Spec.Layers) > 0 {
+			if ko.Spec.Layers[0] == nil {
+				return true
+			}
+		}
+	}
+	if ko.Spec.TracingConfig != nil {
+		if ko.Spec.TracingConfig.Mode == nil {
+			return true
+		}
+	}
+	return false`
+	assert.Equal(expectedIncomplete, code.IncompleteLateInitialization(crd.Config(), crd, "latest", 1))
+}
@@ -0,0 +1,127 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// PreDeleteUpdates returns Go code that sets the Spec fields of a resource to
+// the values configured in `delete_operation.pre_delete_updates`, and records
+// in the supplied boolean variable whether any field was changed, i.e.
+// whether the resource must be updated before it is deleted. Fields that were
+// not read back from the AWS resource are left alone, since the deletion
+// waits until the resource is read with the values applied.
+//
+// Sample output:
+//
+//	if ko.Spec.DeletionProtection != nil && *ko.Spec.DeletionProtection {
+//		ko.Spec.DeletionProtection = aws.Bool(false)
+//		updated = true
+//	}
+func PreDeleteUpdates(
+	r *model.CRD,
+	// the variable name of the resource's Kubernetes object — "ko"
+	koVarName string,
+	// the name of the boolean variable set to true when a field is changed
+	updatedVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	updates := r.PreDeleteUpdates()
+	if len(updates) == 0 {
+		return "", nil
+	}
+	if r.Ops.Update == nil && r.Ops.SetAttributes == nil &&
+		!r.HasUpdateOperations() && r.CustomUpdateMethodName() == "" {
+		return "", fmt.Errorf(
+			"resource %q: delete_operation.pre_delete_updates: resource has no update operation",
+			r.Names.Original,
+		)
+	}
+	fieldNames := make([]string, 0, len(updates))
+	for fieldName := range updates {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	for _, fieldName := range fieldNames {
+		field, found := r.SpecFields[fieldName]
+		if !found {
+			return "", fmt.Errorf(
+				"resource %q: delete_operation.pre_delete_updates: %q is not a top-level Spec field",
+				r.Names.Original, fieldName,
+			)
+		}
+		fieldVar := fmt.Sprintf("%s.Spec.%s", koVarName, field.Names.Camel)
+		differs, value, err := preDeleteUpdateValue(field, fieldVar, updates[fieldName])
+		if err != nil {
+			return "", fmt.Errorf(
+				"resource %q: delete_operation.pre_delete_updates: field %q: %w",
+				r.Names.Original, fieldName, err,
+			)
+		}
+		// if ko.Spec.DeletionProtection != nil && *ko.Spec.DeletionProtection {
+		out += fmt.Sprintf("%sif %s != nil && %s {\n", indent, fieldVar, differs)
+		// ko.Spec.DeletionProtection = aws.Bool(false)
+		out += fmt.Sprintf("%s\t%s = %s\n", indent, fieldVar, value)
+		// updated = true
+		out += fmt.Sprintf("%s\t%s = true\n", indent, updatedVarName)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out, nil
+}
+
+// preDeleteUpdateValue returns the Go expression checking whether the
+// supplied non-nil field differs from the supplied configured value, and the
+// Go expression of the value, according to the type of the field.
+func preDeleteUpdateValue(
+	field *model.Field,
+	fieldVar string,
+	rawValue string,
+) (string, string, error) {
+	switch field.GoType {
+	case "*bool":
+		value, err := strconv.ParseBool(rawValue)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid boolean value %q", rawValue)
+		}
+		if value {
+			return "!*" + fieldVar, "aws.Bool(true)", nil
+		}
+		return "*" + fieldVar, "aws.Bool(false)", nil
+	case "*int64":
+		value, err := strconv.ParseInt(rawValue, 10, 64)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid integer value %q", rawValue)
+		}
+		return fmt.Sprintf("*%s != %d", fieldVar, value),
+			fmt.Sprintf("aws.Int64(%d)", value), nil
+	case "*string":
+		return fmt.Sprintf("*%s != %q", fieldVar, rawValue),
+			fmt.Sprintf("aws.String(%q)", rawValue), nil
+	default:
+		return "", "", fmt.Errorf(
+			"type %s not supported, must be a boolean, integer or string",
+			field.GoType,
+		)
+	}
+}
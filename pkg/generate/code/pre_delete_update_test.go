@@ -0,0 +1,57 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestPreDeleteUpdates_RDS_DBCluster(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "rds", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-pre-delete-updates.yaml",
+	})
+	crd := testutil.GetCRDByName(t, g, "DBCluster")
+	require.NotNil(crd)
+
+	expected := `	if ko.Spec.DeletionProtection != nil && *ko.Spec.DeletionProtection {
+		ko.Spec.DeletionProtection = aws.Bool(false)
+		updated = true
+	}
+`
+	got, err := code.PreDeleteUpdates(crd, "ko", "updated", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func TestPreDeleteUpdates_NoUpdates(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "rds")
+	crd := testutil.GetCRDByName(t, g, "DBCluster")
+	require.NotNil(crd)
+
+	got, err := code.PreDeleteUpdates(crd, "ko", "updated", 1)
+	require.NoError(err)
+	assert.Equal("", got)
+}
@@ -0,0 +1,130 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/fieldpath"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// ResourceRequeueAfter returns Go code that returns the delay after which to
+// requeue a resource, and true, for the first of the reconcile.requeue_when
+// rules the resource matches. A rule matches when its field is equal to one of
+// its values, or, for field paths with a wildcard, when every selected element
+// is. Rules with a maximum delay call the requeueBackoff function of the
+// resource manager template.
+//
+// This follows the same pattern as ResourceIsSynced in synced.go.
+//
+// Sample output:
+//
+//	if r.ko.Status.Status != nil && *r.ko.Status.Status == "creating" {
+//		return requeueBackoff(r, time.Duration(60)*time.Second, time.Duration(600)*time.Second), true
+//	}
+//	if r.ko.Status.Status != nil && (*r.ko.Status.Status == "modifying" || *r.ko.Status.Status == "upgrading") {
+//		return time.Duration(10)*time.Second, true
+//	}
+func ResourceRequeueAfter(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// resource variable name
+	resVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	exprCompiler := &conditionExprCompiler{crd: r, rootVarName: resVarName + ".ko"}
+	for i, rule := range cfg.GetReconcileRequeueWhen(r.Names.Original) {
+		if rule.Path == nil || *rule.Path == "" {
+			return "", fmt.Errorf(
+				"resource %q: reconcile.requeue_when[%d]: path must be provided",
+				r.Names.Original, i,
+			)
+		}
+		if len(rule.In) == 0 {
+			return "", fmt.Errorf(
+				"resource %q, path %q: reconcile.requeue_when[%d]: in must be provided",
+				r.Names.Original, *rule.Path, i,
+			)
+		}
+		src, err := requeueRuleExpr(r, rule)
+		if err != nil {
+			return "", fmt.Errorf("resource %q: reconcile.requeue_when[%d]: %w", r.Names.Original, i, err)
+		}
+		pre, expr, err := exprCompiler.compile(src, indent)
+		if err != nil {
+			return "", fmt.Errorf("resource %q: reconcile.requeue_when[%d]: %w", r.Names.Original, i, err)
+		}
+		out += pre
+		// if r.ko.Status.Status != nil && *r.ko.Status.Status == "creating" {
+		out += fmt.Sprintf("%sif %s {\n", indent, expr)
+		delay := fmt.Sprintf("time.Duration(%d)*time.Second", rule.RequeueAfterSeconds)
+		if rule.MaxRequeueAfterSeconds > rule.RequeueAfterSeconds {
+			// return requeueBackoff(r, time.Duration(60)*time.Second, time.Duration(600)*time.Second), true
+			out += fmt.Sprintf(
+				"%s\treturn requeueBackoff(%s, %s, time.Duration(%d)*time.Second), true\n",
+				indent, resVarName, delay, rule.MaxRequeueAfterSeconds,
+			)
+		} else {
+			// return time.Duration(10)*time.Second, true
+			out += fmt.Sprintf("%s\treturn %s, true\n", indent, delay)
+		}
+		// }
+		out += indent + "}\n"
+	}
+	return out, nil
+}
+
+// requeueRuleExpr returns the condition expression equivalent to the path and
+// values of the supplied requeue rule, e.g. `Status.Status in ["creating"]`,
+// or `all(Status.Replicas[*].Status in ["creating"])` for a field path with a
+// wildcard.
+func requeueRuleExpr(
+	r *model.CRD,
+	rule ackgenconfig.RequeueRule,
+) (string, error) {
+	fp, err := fieldpath.Parse(*rule.Path)
+	if err != nil {
+		return "", err
+	}
+	field, err := getTopLevelField(r, *rule.Path)
+	if err != nil {
+		return "", fmt.Errorf("cannot find top-level field for path %q: %w", *rule.Path, err)
+	}
+	shapeRef := fieldPathShapeRef(field, fp)
+	if shapeRef == nil {
+		return "", fmt.Errorf("cannot find field for path %q", *rule.Path)
+	}
+	quote := shapeRef.GoTypeElem() == "string"
+	values := make([]string, len(rule.In))
+	for i, value := range rule.In {
+		if quote {
+			value = strconv.Quote(value)
+		}
+		values[i] = value
+	}
+	src := fmt.Sprintf("%s in [%s]", fp.String(), strings.Join(values, ", "))
+	for idx := 0; idx < fp.Size(); idx++ {
+		if fieldpath.IsWildcard(fp.At(idx)) {
+			return "all(" + src + ")", nil
+		}
+	}
+	return src, nil
+}
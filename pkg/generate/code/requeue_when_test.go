@@ -0,0 +1,59 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestResourceRequeueAfter_NoRules(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "lambda")
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	got, err := code.ResourceRequeueAfter(crd.Config(), crd, "r", 1)
+	require.NoError(err)
+	assert.Equal("", got)
+}
+
+func TestResourceRequeueAfter_Rules(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-requeue-when.yaml",
+	})
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	expected := `	if r.ko.Status.State != nil && *r.ko.Status.State == "Pending" {
+		return requeueBackoff(r, time.Duration(30)*time.Second, time.Duration(300)*time.Second), true
+	}
+	if r.ko.Status.LastUpdateStatus != nil && *r.ko.Status.LastUpdateStatus == "InProgress" {
+		return time.Duration(10)*time.Second, true
+	}
+`
+	got, err := code.ResourceRequeueAfter(crd.Config(), crd, "r", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
@@ -15,9 +15,11 @@ package code
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/fieldpath"
 	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 )
 
@@ -127,7 +129,18 @@ func resourceIsGuarded(
 	}
 
 	out += "\n"
+	exprCompiler := &conditionExprCompiler{crd: r, rootVarName: resVarName + ".ko"}
 	for _, condCfg := range conditions {
+		if condCfg.Expr != nil {
+			indent := strings.Repeat("\t", indentLevel)
+			pre, expr, err := exprCompiler.compile(*condCfg.Expr, indent)
+			if err != nil {
+				return "", fmt.Errorf("resource %q: %s.when: %w", r.Names.Original, configKey, err)
+			}
+			out += pre
+			out += renderExprGuardBlock(*condCfg.Expr, expr, requeueSeconds, opVerb, indentLevel)
+			continue
+		}
 		if condCfg.Path == nil || *condCfg.Path == "" {
 			return "", fmt.Errorf(
 				"resource %q: %s.when condition has empty path",
@@ -141,16 +154,26 @@ func resourceIsGuarded(
 			)
 		}
 
-		_, err := getTopLevelField(r, *condCfg.Path)
+		fp, err := fieldpath.Parse(*condCfg.Path)
+		if err != nil {
+			return "", fmt.Errorf("resource %q: %s.when: %w", r.Names.Original, configKey, err)
+		}
+		field, err := getTopLevelField(r, *condCfg.Path)
 		if err != nil {
 			return "", fmt.Errorf(
 				"resource %q: cannot find field for path %q: %w",
 				r.Names.Original, *condCfg.Path, err,
 			)
 		}
+		if fieldPathShapeRef(field, fp) == nil {
+			return "", fmt.Errorf(
+				"resource %q: cannot find field for path %q",
+				r.Names.Original, *condCfg.Path,
+			)
+		}
 
 		out += renderGuardBlock(
-			resVarName, *condCfg.Path, condCfg.In,
+			resVarName, fp, condCfg.In,
 			requeueSeconds, opVerb, indentLevel,
 		)
 	}
@@ -161,22 +184,53 @@ func resourceIsGuarded(
 // renderGuardBlock produces the Go source code for a single condition check.
 // It generates a nil check on the field pointer, then an InStrings check
 // against the allowed values, returning ackrequeue.NeededAfter if the value
-// is not in the allowed set.
+// is not in the allowed set. Every element selected by a wildcard in the
+// field path is checked, and missing values are skipped.
+//
+// Sample output for the path `Status.Endpoints[*].Status`:
+//
+//	if latest.ko.Status.Endpoints != nil {
+//	    for _, elem0 := range latest.ko.Status.Endpoints {
+//	        if elem0 != nil {
+//	            if elem0.Status != nil {
+//	                if !ackutil.InStrings(*elem0.Status, []string{"ACTIVE"}) {
+//	                    ...
+//	                }
+//	            }
+//	        }
+//	    }
+//	}
 func renderGuardBlock(
 	resVarName string,
-	fieldPath string,
+	fp *fieldpath.Path,
 	allowedValues []string,
 	requeueSeconds int,
 	opVerb string,
 	indentLevel int,
 ) string {
-	indent := strings.Repeat("\t", indentLevel)
-	fullPath := fmt.Sprintf("%s.ko.%s", resVarName, fieldPath)
+	cp := fp.Copy()
+	rootPath := fmt.Sprintf("%s.ko.%s", resVarName, cp.PopFront())
+	accesses := fieldPathAccesses(rootPath, cp)
+	fullPath := accesses[len(accesses)-1].expr
 
 	valuesSlice := fmt.Sprintf(`[]string{"%s"}`, strings.Join(allowedValues, `", "`))
 
 	out := ""
-	out += fmt.Sprintf("%sif %s != nil {\n", indent, fullPath)
+	opened := 0
+	open := func(format string, args ...interface{}) {
+		indent := strings.Repeat("\t", indentLevel+opened)
+		out += indent + fmt.Sprintf(format, args...) + " {\n"
+		opened++
+	}
+	for _, access := range accesses {
+		if access.wildcard {
+			open("for _, %s := range %s", access.expr, access.container)
+		} else if access.index >= 0 {
+			open("if len(%s) > %d", access.container, access.index)
+		}
+		open("if %s != nil", access.expr)
+	}
+	indent := strings.Repeat("\t", indentLevel+opened-1)
 	out += fmt.Sprintf("%s\tif !ackutil.InStrings(*%s, %s) {\n", indent, fullPath, valuesSlice)
 	out += fmt.Sprintf("%s\t\treturn nil, ackrequeue.NeededAfter(\n", indent)
 	out += fmt.Sprintf("%s\t\t\tfmt.Errorf(\"resource is in %%s state, cannot be %s\",\n", indent, opVerb)
@@ -184,6 +238,31 @@ func renderGuardBlock(
 	out += fmt.Sprintf("%s\t\t\ttime.Duration(%d)*time.Second,\n", indent, requeueSeconds)
 	out += fmt.Sprintf("%s\t\t)\n", indent)
 	out += fmt.Sprintf("%s\t}\n", indent)
+	for opened > 0 {
+		opened--
+		out += fmt.Sprintf("%s}\n", strings.Repeat("\t", indentLevel+opened))
+	}
+	return out
+}
+
+// renderExprGuardBlock produces the Go source code checking the Go boolean
+// expression compiled from the supplied condition expression, returning
+// ackrequeue.NeededAfter if it is false.
+func renderExprGuardBlock(
+	src string,
+	expr string,
+	requeueSeconds int,
+	opVerb string,
+	indentLevel int,
+) string {
+	indent := strings.Repeat("\t", indentLevel)
+	out := ""
+	out += fmt.Sprintf("%sif %s {\n", indent, negate(expr))
+	out += fmt.Sprintf("%s\treturn nil, ackrequeue.NeededAfter(\n", indent)
+	out += fmt.Sprintf("%s\t\tfmt.Errorf(\"resource does not satisfy %%s, cannot be %s\",\n", indent, opVerb)
+	out += fmt.Sprintf("%s\t\t\t%s),\n", indent, strconv.Quote(src))
+	out += fmt.Sprintf("%s\t\ttime.Duration(%d)*time.Second,\n", indent, requeueSeconds)
+	out += fmt.Sprintf("%s\t)\n", indent)
 	out += fmt.Sprintf("%s}\n", indent)
 	return out
 }
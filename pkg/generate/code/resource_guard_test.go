@@ -221,3 +221,124 @@ func TestResourceIsUpdateable_InvalidFieldPath(t *testing.T) {
 	require.Error(err)
 	require.Contains(err.Error(), "cannot find field")
 }
+
+func TestResourceIsUpdateable_IndexSelector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-field-path-selectors.yaml",
+	})
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	expected := "\n" + `	if latest.ko.Spec.Layers != nil {
+		if len(latest.ko.Spec.Layers) > 0 {
+			if latest.ko.Spec.Layers[0] != nil {
+				if !ackutil.InStrings(*latest.ko.Spec.Layers[0], []string{"arn:aws:lambda:us-west-2:123456789012:layer:base:1"}) {
+					return nil, ackrequeue.NeededAfter(
+						fmt.Errorf("resource is in %s state, cannot be updated",
+							*latest.ko.Spec.Layers[0]),
+						time.Duration(30)*time.Second,
+					)
+				}
+			}
+		}
+	}
+`
+	got, err := code.ResourceIsUpdateable(
+		crd.Config(), crd, "latest", 1,
+	)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func TestResourceIsDeletable_WildcardSelector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-field-path-selectors.yaml",
+	})
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	expected := "\n" + `	if r.ko.Spec.FileSystemConfigs != nil {
+		for _, elem0 := range r.ko.Spec.FileSystemConfigs {
+			if elem0 != nil {
+				if elem0.LocalMountPath != nil {
+					if !ackutil.InStrings(*elem0.LocalMountPath, []string{"/mnt/data"}) {
+						return nil, ackrequeue.NeededAfter(
+							fmt.Errorf("resource is in %s state, cannot be deleted",
+								*elem0.LocalMountPath),
+							time.Duration(30)*time.Second,
+						)
+					}
+				}
+			}
+		}
+	}
+`
+	got, err := code.ResourceIsDeletable(
+		crd.Config(), crd, "r", 1,
+	)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func TestResourceIsUpdateable_ConditionExpr(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-condition-exprs.yaml",
+	})
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	expected := "\n" + `	if !((latest.ko.Spec.Publish != nil && *latest.ko.Spec.Publish) || (latest.ko.Spec.TracingConfig != nil)) {
+		return nil, ackrequeue.NeededAfter(
+			fmt.Errorf("resource does not satisfy %s, cannot be updated",
+				"Spec.Publish || exists(Spec.TracingConfig)"),
+			time.Duration(30)*time.Second,
+		)
+	}
+`
+	got, err := code.ResourceIsUpdateable(
+		crd.Config(), crd, "latest", 1,
+	)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func TestResourceIsDeletable_ConditionExpr(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-condition-exprs.yaml",
+	})
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	expected := "\n" + `	any0 := false
+	for _, elem := range r.ko.Spec.Layers {
+		if elem != nil && *elem == "arn:aws:lambda:us-west-2:123456789012:layer:base:1" {
+			any0 = true
+			break
+		}
+	}
+	if !any0 {
+		return nil, ackrequeue.NeededAfter(
+			fmt.Errorf("resource does not satisfy %s, cannot be deleted",
+				"any(Spec.Layers[*] == \"arn:aws:lambda:us-west-2:123456789012:layer:base:1\")"),
+			time.Duration(30)*time.Second,
+		)
+	}
+`
+	got, err := code.ResourceIsDeletable(
+		crd.Config(), crd, "r", 1,
+	)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
@@ -30,6 +30,11 @@ var (
 	// indexVarFmt stores the format string which takes an integer and creates
 	// the name of a variable used for the index part of a for-each loop
 	indexVarFmt = "f%didx"
+
+	// keyVarFmt stores the format string which takes an integer and creates
+	// the name of a variable used for the key part of a for-each loop over a
+	// map
+	keyVarFmt = "f%dkey"
 )
 
 // ReferenceFieldsValidation returns the go code to validate reference field and
@@ -58,7 +63,7 @@ func ReferenceFieldsValidation(
 	sourceVarName string,
 	indentLevel int,
 ) (string, error) {
-	isListOfRefs := field.ShapeRef.Shape.Type == "list"
+	isListOfRefs := field.ShapeRef.Shape.Type == "list" || field.ShapeRef.Shape.Type == "map"
 
 	refFieldName, err := field.GetReferenceFieldName()
 	if err != nil {
@@ -179,6 +184,22 @@ func ReferenceFieldsValidation(
 //		}
 //	}
 //
+// Sample output (resolving a map of references):
+//
+//	for f0key, f0iter := range ko.Spec.StageVariableRefs {
+//		if f0iter != nil && f0iter.From != nil {
+//			...
+//			obj := &lambdaapitypes.Function{}
+//			if err := getReferencedResourceState_Function(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+//				return hasReferences, err
+//			}
+//			if ko.Spec.StageVariables == nil {
+//				ko.Spec.StageVariables = map[string]*string{}
+//			}
+//			ko.Spec.StageVariables[f0key] = (*string)(obj.Spec.Name)
+//		}
+//	}
+//
 // Sample output (resolving nested lists of structs containing references):
 //
 //	if ko.Spec.Notification != nil {
@@ -209,10 +230,13 @@ func ReferenceFieldsValidation(
 //	}
 func ResolveReferencesForField(field *model.Field, sourceVarName string, indentLevel int) (string, error) {
 	isListOfRefs := field.ShapeRef.Shape.Type == "list"
+	isMapOfRefs := field.ShapeRef.Shape.Type == "map"
 
 	resRefElemType := field.ShapeRef.GoType()
 	if isListOfRefs {
 		resRefElemType = field.ShapeRef.Shape.MemberRef.GoType()
+	} else if isMapOfRefs {
+		resRefElemType = field.ShapeRef.Shape.ValueRef.GoType()
 	}
 
 	refFieldPath, err := field.ReferenceFieldPath()
@@ -227,13 +251,19 @@ func ResolveReferencesForField(field *model.Field, sourceVarName string, indentL
 			outPrefix := ""
 			outSuffix := ""
 
-			// If the reference field is a list of primitives, iterate through each.
-			// We need to duplicate some code from above, here, because `*Ref` fields
-			// aren't registered as fields, so we can't use the same common logic
-			if isListOfRefs {
+			// If the reference field is a list or map of primitives, iterate
+			// through each. We need to duplicate some code from above, here,
+			// because `*Ref` fields aren't registered as fields, so we can't use
+			// the same common logic
+			keyVarName := fmt.Sprintf(keyVarFmt, listDepth)
+			if isListOfRefs || isMapOfRefs {
 				iterVarName := fmt.Sprintf(iterVarFmt, listDepth)
 
-				outPrefix += fmt.Sprintf("%sfor _, %s := range %s {\n", strings.Repeat("\t", innerIndentLevel), iterVarName, fieldAccessPrefix)
+				outPrefix += fmt.Sprintf("%sfor %s, %s := range %s {\n", strings.Repeat("\t", innerIndentLevel),
+					lo.Ternary(isMapOfRefs, keyVarName, "_"),
+					iterVarName,
+					fieldAccessPrefix,
+				)
 				outSuffix = fmt.Sprintf("%s}\n%s", strings.Repeat("\t", innerIndentLevel), outSuffix)
 				fieldAccessPrefix = iterVarName
 
@@ -254,32 +284,45 @@ func ResolveReferencesForField(field *model.Field, sourceVarName string, indentL
 			outPrefix += fmt.Sprintf("%s\treturn hasReferences, fmt.Errorf(\"provided resource reference is nil or empty: %s\")\n", innerIndent, refFieldPath)
 			outPrefix += fmt.Sprintf("%s}\n", innerIndent)
 
-			outPrefix += fmt.Sprintf("%snamespace, err := ackrt.ResolveCrossNamespaceReference(\n", innerIndent)
-			outPrefix += fmt.Sprintf("%s\tctx,\n", innerIndent)
-			outPrefix += fmt.Sprintf("%s\trm.cfg.EnableCrossNamespace,\n", innerIndent)
-			outPrefix += fmt.Sprintf("%s\t&ko.Status.Conditions,\n", innerIndent)
-			outPrefix += fmt.Sprintf("%s\tackrt.CrossNamespaceRefKindResource,\n", innerIndent)
-			outPrefix += fmt.Sprintf("%s\tko.ObjectMeta.GetNamespace(),\n", innerIndent)
-			outPrefix += fmt.Sprintf("%s\tarr.Namespace,\n", innerIndent)
-			outPrefix += fmt.Sprintf("%s\t*arr.Name,\n", innerIndent)
-			outPrefix += fmt.Sprintf("%s)\n", innerIndent)
-			outPrefix += fmt.Sprintf("%sif err != nil {\n", innerIndent)
-			outPrefix += fmt.Sprintf("%s\treturn hasReferences, err\n", innerIndent)
-			outPrefix += fmt.Sprintf("%s}\n", innerIndent)
-
-			outPrefix += getReferencedStateForField(field, innerIndentLevel)
-
 			concreteValueAccessor, err := buildIndexBasedFieldAccessor(field, sourceVarName, indexVarFmt)
 			if err != nil {
 				return "", err
 			}
+
+			value := ""
+			if field.ReferencesKubernetesObject() {
+				if resRefElemType != "*string" {
+					return "", fmt.Errorf(
+						"field %q references a Kubernetes object and must be a string or a list or map of strings, not %s",
+						field.Path, resRefElemType,
+					)
+				}
+				outPrefix += getReferencedObjectValueForField(field, refFieldPath, innerIndentLevel)
+				value = "&value"
+			} else {
+				// Cluster-scoped resources are not in any namespace, so there is
+				// no cross-namespace reference to resolve.
+				if field.ReferencesClusterScopedResource() {
+					outPrefix += getReferencedStateForField(field, `""`, innerIndentLevel)
+				} else {
+					outPrefix += resolveCrossNamespaceReference(innerIndentLevel)
+					outPrefix += getReferencedStateForField(field, "namespace", innerIndentLevel)
+				}
+				value = fmt.Sprintf("(%s)(obj.%s)", resRefElemType, field.FieldConfig.References.Path)
+			}
+
 			if isListOfRefs {
 				outPrefix += fmt.Sprintf("%sif %s == nil {\n", innerIndent, concreteValueAccessor)
 				outPrefix += fmt.Sprintf("%s\t%s = make([]%s, 0, 1)\n", innerIndent, concreteValueAccessor, resRefElemType)
 				outPrefix += fmt.Sprintf("%s}\n", innerIndent)
-				outPrefix += fmt.Sprintf("%s%s = append(%s, (%s)(obj.%s))\n", innerIndent, concreteValueAccessor, concreteValueAccessor, resRefElemType, field.FieldConfig.References.Path)
+				outPrefix += fmt.Sprintf("%s%s = append(%s, %s)\n", innerIndent, concreteValueAccessor, concreteValueAccessor, value)
+			} else if isMapOfRefs {
+				outPrefix += fmt.Sprintf("%sif %s == nil {\n", innerIndent, concreteValueAccessor)
+				outPrefix += fmt.Sprintf("%s\t%s = map[string]%s{}\n", innerIndent, concreteValueAccessor, resRefElemType)
+				outPrefix += fmt.Sprintf("%s}\n", innerIndent)
+				outPrefix += fmt.Sprintf("%s%s[%s] = %s\n", innerIndent, concreteValueAccessor, keyVarName, value)
 			} else {
-				outPrefix += fmt.Sprintf("%s%s = (%s)(obj.%s)\n", innerIndent, concreteValueAccessor, resRefElemType, field.FieldConfig.References.Path)
+				outPrefix += fmt.Sprintf("%s%s = %s\n", innerIndent, concreteValueAccessor, value)
 			}
 
 			return outPrefix + outSuffix, nil
@@ -303,13 +346,13 @@ func ResolveReferencesForField(field *model.Field, sourceVarName string, indentL
 //		}
 //	}
 func ClearResolvedReferencesForField(field *model.Field, targetVarName string, indentLevel int) (string, error) {
-	isListOfRefs := field.ShapeRef.Shape.Type == "list"
+	isListOfRefs := field.ShapeRef.Shape.Type == "list" || field.ShapeRef.Shape.Type == "map"
 
 	iterOut, err := iterReferenceValues(field, indentLevel, targetVarName, true,
 		func(fieldAccessPrefix string, _, innerIndentLevel int) (innerOut string, err error) {
 			innerIndent := strings.Repeat("\t", innerIndentLevel)
 
-			// If we are dealing with a list of references, then we don't need to
+			// If we are dealing with a list (or map) of references, then we don't need to
 			// iterate over all of the references individually. We know that if the list
 			// has >0 elements, then the entire concrete value list should be made nil.
 			// To deal with this, we should iterate only to the parent of the list and
@@ -343,9 +386,9 @@ func ClearResolvedReferencesForField(field *model.Field, targetVarName string, i
 //
 // The inner render callback is passed a `fieldAccessPrefix`, which is the name
 // of a variable which can be used to access the ref field within the nested
-// lists and structs, a `listDepth`, which represents the number of lists that
-// the ref field is inside, and an `indentLevel` which represents the layers of
-// indentation the code has reached.
+// lists, maps and structs, a `listDepth`, which represents the number of lists
+// and maps that the ref field is inside, and an `indentLevel` which represents
+// the layers of indentation the code has reached.
 func iterReferenceValues(
 	field *model.Field,
 	indentLevel int,
@@ -372,8 +415,8 @@ func iterReferenceValues(
 
 	fieldAccessPrefix := fmt.Sprintf("%s%s", sourceVarName, r.Config().PrefixConfig.SpecField)
 
-	fpDepth := 0
-	for fpDepth = 0; fpDepth < fp.Size()-1; fpDepth++ {
+	curIndentLevel := indentLevel
+	for fpDepth := 0; fpDepth < fp.Size()-1; fpDepth++ {
 		curFP := fp.CopyAt(fpDepth).String()
 		cur, ok := r.Fields[curFP]
 		if !ok {
@@ -383,26 +426,30 @@ func iterReferenceValues(
 			)
 		}
 
-		ref := cur.ShapeRef
-
-		indent := strings.Repeat("\t", indentLevel+fpDepth)
-
-		switch ref.Shape.Type {
-		case ("map"):
-			return "", fmt.Errorf(
-				"resource %q, field %q: references cannot be within a map",
-				r.Kind, field.Path,
-			)
-		case ("structure"):
-			fieldAccessPrefix = fmt.Sprintf("%s.%s", fieldAccessPrefix, fp.At(fpDepth))
+		shape := cur.ShapeRef.Shape
+		fieldAccessPrefix = fmt.Sprintf("%s.%s", fieldAccessPrefix, fp.At(fpDepth))
 
+		if shape.Type == "structure" {
+			indent := strings.Repeat("\t", curIndentLevel)
 			outPrefix += fmt.Sprintf("%sif %s != nil {\n", indent, fieldAccessPrefix)
 			outSuffix = fmt.Sprintf("%s}\n%s", indent, outSuffix)
-		case ("list"):
+			curIndentLevel++
+			continue
+		}
+
+		// Lists and maps may be nested within each other (for example, a
+		// list of lists of structs), so we range over each level until we
+		// reach the struct containing the next part of the path.
+		for shape.Type == "list" || shape.Type == "map" {
+			indent := strings.Repeat("\t", curIndentLevel)
 			iterVarName := fmt.Sprintf(iterVarFmt, currentListDepth)
 			idxVarName := fmt.Sprintf(indexVarFmt, currentListDepth)
-
-			fieldAccessPrefix = fmt.Sprintf("%s.%s", fieldAccessPrefix, fp.At(fpDepth))
+			if shape.Type == "map" {
+				idxVarName = fmt.Sprintf(keyVarFmt, currentListDepth)
+				shape = shape.ValueRef.Shape
+			} else {
+				shape = shape.MemberRef.Shape
+			}
 
 			outPrefix += fmt.Sprintf("%sfor %s, %s := range %s {\n", indent,
 				lo.Ternary(shouldRenderIndexes, idxVarName, "_"),
@@ -415,11 +462,12 @@ func iterReferenceValues(
 			fieldAccessPrefix = iterVarName
 
 			currentListDepth++
+			curIndentLevel++
 		}
 	}
 
 	fieldAccessPrefix = fmt.Sprintf("%s.%s", fieldAccessPrefix, refFieldName.Camel)
-	innerIndentLevel := indentLevel + fpDepth
+	innerIndentLevel := curIndentLevel
 
 	innerPrefix, err := innerRender(fieldAccessPrefix, currentListDepth, innerIndentLevel)
 	if err != nil {
@@ -430,12 +478,13 @@ func iterReferenceValues(
 }
 
 // buildNestedFieldAccessor generates Go code that accesses an inner struct,
-// using slice indexes where necessary.
+// using slice indexes and map keys where necessary.
 //
 // `indexVarFmt` should be a format string that takes a single integer and
 // returns the name of a variable which holds the index for the n-th parent
 // slice. For example, f%didx will be used to create f0idx, f1idx, etc. for the
-// parent slices in the accessors.
+// parent slices in the accessors. Parent maps are accessed using the keys
+// named by `keyVarFmt` instead.
 //
 // By default, this method will iterate through every field in the field path.
 // Supplying a `parentOffset` will only iterate through the first `fp.Size() -
@@ -444,8 +493,6 @@ func buildIndexBasedFieldAccessorWithOffset(field *model.Field, sourceVarName, i
 	r := field.CRD
 	fp := fieldpath.FromString(field.Path)
 
-	isList := field.ShapeRef.Shape.Type == "list"
-
 	fieldNamePrefix := ""
 	nestedFieldDepth := 0
 	for idx := 0; idx < fp.Size()-parentOffset; idx++ {
@@ -459,16 +506,23 @@ func buildIndexBasedFieldAccessorWithOffset(field *model.Field, sourceVarName, i
 		fieldName := curFP.Pop()
 		indexList := ""
 
-		if cur.ShapeRef.Shape.Type == "list" {
-
-			// We want to access indexes when iterating through lists of
-			// structs. If we find a list at the end of the field path, then we
-			// know the initial field must be a list. We want to pass that back
-			// as a full array, rather than accessing the individual values.
-			// This only applies for when there is no offset, since any offset >
-			// 0 will cut off the initial field from the path
-			if idx != (fp.Size()-1) && !isList {
-				indexList = fmt.Sprintf("[%s]", fmt.Sprintf(indexVarFmt, nestedFieldDepth))
+		// We want to access indexes (or keys) when iterating through lists
+		// and maps of structs, including lists and maps nested within each
+		// other. If we find a list or map at the end of the field path, we
+		// want to pass that back as a full list or map, rather than accessing
+		// the individual values. This only applies for when there is no
+		// offset, since any offset > 0 will cut off the initial field from
+		// the path
+		if idx != (fp.Size() - 1) {
+			shape := cur.ShapeRef.Shape
+			for shape.Type == "list" || shape.Type == "map" {
+				if shape.Type == "map" {
+					indexList += fmt.Sprintf("[%s]", fmt.Sprintf(keyVarFmt, nestedFieldDepth))
+					shape = shape.ValueRef.Shape
+				} else {
+					indexList += fmt.Sprintf("[%s]", fmt.Sprintf(indexVarFmt, nestedFieldDepth))
+					shape = shape.MemberRef.Shape
+				}
 				nestedFieldDepth++
 			}
 		}
@@ -485,19 +539,98 @@ func buildIndexBasedFieldAccessor(field *model.Field, sourceVarName, indexVarFmt
 	return buildIndexBasedFieldAccessorWithOffset(field, sourceVarName, indexVarFmt, 0)
 }
 
-// getReferencedStateForField returns Go code that makes a call to
-// `getReferencedResourceState_*` (using the referenced field resource) and sets
-// the response into an object (of the referenced type) called `obj`
-func getReferencedStateForField(field *model.Field, indentLevel int) string {
+// resolveCrossNamespaceReference returns Go code that calls
+// `ackrt.ResolveCrossNamespaceReference` to determine the namespace of the
+// referenced resource and stores it in a variable called `namespace`
+func resolveCrossNamespaceReference(indentLevel int) string {
 	out := ""
 	indent := strings.Repeat("\t", indentLevel)
 
-	if field.FieldConfig.References.ServiceName == "" {
-		out += fmt.Sprintf("%sobj := &svcapitypes.%s{}\n", indent, field.FieldConfig.References.Resource)
-	} else {
-		out += fmt.Sprintf("%sobj := &%sapitypes.%s{}\n", indent, field.ReferencedServiceName(), field.FieldConfig.References.Resource)
+	out += fmt.Sprintf("%snamespace, err := ackrt.ResolveCrossNamespaceReference(\n", indent)
+	out += fmt.Sprintf("%s\tctx,\n", indent)
+	out += fmt.Sprintf("%s\trm.cfg.EnableCrossNamespace,\n", indent)
+	out += fmt.Sprintf("%s\t&ko.Status.Conditions,\n", indent)
+	out += fmt.Sprintf("%s\tackrt.CrossNamespaceRefKindResource,\n", indent)
+	out += fmt.Sprintf("%s\tko.ObjectMeta.GetNamespace(),\n", indent)
+	out += fmt.Sprintf("%s\tarr.Namespace,\n", indent)
+	out += fmt.Sprintf("%s\t*arr.Name,\n", indent)
+	out += fmt.Sprintf("%s)\n", indent)
+	out += fmt.Sprintf("%sif err != nil {\n", indent)
+	out += fmt.Sprintf("%s\treturn hasReferences, err\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+
+	return out
+}
+
+// getReferencedObjectValueForField returns Go code that determines the
+// namespace of the Kubernetes object referenced by the field and makes a call
+// to `getReferencedObjectValue`, setting the value found at the reference's
+// JSONPath into a variable called `value`. Unless the reference allows
+// cross-namespace lookups, the referenced object must be in the namespace of
+// the referencing resource.
+//
+// Sample output:
+//
+//	namespace := ko.ObjectMeta.GetNamespace()
+//	if arr.Namespace != nil && *arr.Namespace != "" && *arr.Namespace != namespace {
+//		return hasReferences, fmt.Errorf("cross-namespace references are not allowed: DescriptionRef")
+//	}
+//	value, err := getReferencedObjectValue(
+//		ctx,
+//		apiReader,
+//		schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"},
+//		*arr.Name,
+//		namespace,
+//		"{.data.description}",
+//	)
+//	if err != nil {
+//		return hasReferences, err
+//	}
+func getReferencedObjectValueForField(field *model.Field, refFieldPath string, indentLevel int) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	refCfg := field.FieldConfig.References
+
+	namespace := "namespace"
+	switch {
+	case field.ReferencesClusterScopedResource():
+		namespace = `""`
+	case refCfg.AllowCrossNamespace:
+		out += resolveCrossNamespaceReference(indentLevel)
+	default:
+		out += fmt.Sprintf("%snamespace := ko.ObjectMeta.GetNamespace()\n", indent)
+		out += fmt.Sprintf("%sif arr.Namespace != nil && *arr.Namespace != \"\" && *arr.Namespace != namespace {\n", indent)
+		out += fmt.Sprintf("%s\treturn hasReferences, fmt.Errorf(\"cross-namespace references are not allowed: %s\")\n", indent, refFieldPath)
+		out += fmt.Sprintf("%s}\n", indent)
 	}
-	out += fmt.Sprintf("%sif err := getReferencedResourceState_%s(ctx, apiReader, obj, *arr.Name, namespace); err != nil {\n", indent, field.FieldConfig.References.Resource)
+	out += fmt.Sprintf("%svalue, err := getReferencedObjectValue(\n", indent)
+	out += fmt.Sprintf("%s\tctx,\n", indent)
+	out += fmt.Sprintf("%s\tapiReader,\n", indent)
+	out += fmt.Sprintf(
+		"%s\tschema.GroupVersionKind{Group: %q, Version: %q, Kind: %q},\n",
+		indent, refCfg.Group, refCfg.Version, refCfg.Kind,
+	)
+	out += fmt.Sprintf("%s\t*arr.Name,\n", indent)
+	out += fmt.Sprintf("%s\t%s,\n", indent, namespace)
+	out += fmt.Sprintf("%s\t%q,\n", indent, refCfg.JSONPath)
+	out += fmt.Sprintf("%s)\n", indent)
+	out += fmt.Sprintf("%sif err != nil {\n", indent)
+	out += fmt.Sprintf("%s\treturn hasReferences, err\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+
+	return out
+}
+
+// getReferencedStateForField returns Go code that makes a call to
+// `getReferencedResourceState_*` (using the referenced field resource and the
+// namespace expression) and sets the response into an object (of the
+// referenced type) called `obj`
+func getReferencedStateForField(field *model.Field, namespace string, indentLevel int) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+
+	out += fmt.Sprintf("%sobj := &%s.%s{}\n", indent, field.ReferencedAPITypesAlias(), field.FieldConfig.References.Resource)
+	out += fmt.Sprintf("%sif err := getReferencedResourceState_%s(ctx, apiReader, obj, *arr.Name, %s); err != nil {\n", indent, field.FieldConfig.References.Resource, namespace)
 	out += fmt.Sprintf("%s\treturn hasReferences, err\n", indent)
 	out += fmt.Sprintf("%s}\n", indent)
 
@@ -624,3 +624,422 @@ func Test_ClearResolvedReferencesForField_SingleReference_WithinMultipleSlices(t
 	require.NoError(err)
 	assert.Equal(expected, got)
 }
+
+func Test_ResolveReferencesForField_ClusterScopedReference(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-cluster-scoped-reference.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Integration")
+	require.NotNil(crd)
+	expected :=
+		`	if ko.Spec.APIRef != nil && ko.Spec.APIRef.From != nil {
+		hasReferences = true
+		arr := ko.Spec.APIRef.From
+		if arr.Name == nil || *arr.Name == "" {
+			return hasReferences, fmt.Errorf("provided resource reference is nil or empty: APIRef")
+		}
+		obj := &svcapitypes.API{}
+		if err := getReferencedResourceState_API(ctx, apiReader, obj, *arr.Name, ""); err != nil {
+			return hasReferences, err
+		}
+		ko.Spec.APIID = (*string)(obj.Status.APIID)
+	}
+`
+
+	field := crd.Fields["APIID"]
+	require.True(field.ReferencesClusterScopedResource())
+	got, err := code.ResolveReferencesForField(field, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func Test_ResolveReferencesForField_ReferenceToOtherAPIGroup(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-api-groups.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Integration")
+	require.NotNil(crd)
+	expected :=
+		`	if ko.Spec.ConnectionRef != nil && ko.Spec.ConnectionRef.From != nil {
+		hasReferences = true
+		arr := ko.Spec.ConnectionRef.From
+		if arr.Name == nil || *arr.Name == "" {
+			return hasReferences, fmt.Errorf("provided resource reference is nil or empty: ConnectionRef")
+		}
+		namespace, err := ackrt.ResolveCrossNamespaceReference(
+			ctx,
+			rm.cfg.EnableCrossNamespace,
+			&ko.Status.Conditions,
+			ackrt.CrossNamespaceRefKindResource,
+			ko.ObjectMeta.GetNamespace(),
+			arr.Namespace,
+			*arr.Name,
+		)
+		if err != nil {
+			return hasReferences, err
+		}
+		obj := &coreapitypes.PrivateLink{}
+		if err := getReferencedResourceState_PrivateLink(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+			return hasReferences, err
+		}
+		ko.Spec.ConnectionID = (*string)(obj.Status.VPCLinkID)
+	}
+`
+
+	field := crd.Fields["ConnectionID"]
+	got, err := code.ResolveReferencesForField(field, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func Test_ResolveReferencesForField_KubernetesObjectReference(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-object-reference.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Integration")
+	require.NotNil(crd)
+	require.True(crd.HasKubernetesObjectReferenceFields())
+	require.False(crd.HasACKResourceReferenceFields())
+	expected :=
+		`	if ko.Spec.DescriptionRef != nil && ko.Spec.DescriptionRef.From != nil {
+		hasReferences = true
+		arr := ko.Spec.DescriptionRef.From
+		if arr.Name == nil || *arr.Name == "" {
+			return hasReferences, fmt.Errorf("provided resource reference is nil or empty: DescriptionRef")
+		}
+		namespace := ko.ObjectMeta.GetNamespace()
+		if arr.Namespace != nil && *arr.Namespace != "" && *arr.Namespace != namespace {
+			return hasReferences, fmt.Errorf("cross-namespace references are not allowed: DescriptionRef")
+		}
+		value, err := getReferencedObjectValue(
+			ctx,
+			apiReader,
+			schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"},
+			*arr.Name,
+			namespace,
+			"{.data.description}",
+		)
+		if err != nil {
+			return hasReferences, err
+		}
+		ko.Spec.Description = &value
+	}
+`
+
+	field := crd.Fields["Description"]
+	require.True(field.ReferencesKubernetesObject())
+	assert.Equal("ConfigMaps", field.ReferencedResourceNamePlural())
+	got, err := code.ResolveReferencesForField(field, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+
+	expected =
+		`	if ko.Spec.CredentialsRef != nil && ko.Spec.CredentialsRef.From != nil {
+		hasReferences = true
+		arr := ko.Spec.CredentialsRef.From
+		if arr.Name == nil || *arr.Name == "" {
+			return hasReferences, fmt.Errorf("provided resource reference is nil or empty: CredentialsRef")
+		}
+		namespace, err := ackrt.ResolveCrossNamespaceReference(
+			ctx,
+			rm.cfg.EnableCrossNamespace,
+			&ko.Status.Conditions,
+			ackrt.CrossNamespaceRefKindResource,
+			ko.ObjectMeta.GetNamespace(),
+			arr.Namespace,
+			*arr.Name,
+		)
+		if err != nil {
+			return hasReferences, err
+		}
+		value, err := getReferencedObjectValue(
+			ctx,
+			apiReader,
+			schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ServiceAccount"},
+			*arr.Name,
+			namespace,
+			"{.metadata.annotations.eks\\.amazonaws\\.com/role-arn}",
+		)
+		if err != nil {
+			return hasReferences, err
+		}
+		ko.Spec.CredentialsARN = &value
+	}
+`
+
+	field = crd.Fields["CredentialsARN"]
+	got, err = code.ResolveReferencesForField(field, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func Test_ReferenceFieldsValidation_MapOfReferences(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-map-reference.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Stage")
+	require.NotNil(crd)
+	expected :=
+		`	if len(ko.Spec.StageVariableRefs) > 0 && len(ko.Spec.StageVariables) > 0 {
+		return ackerr.ResourceReferenceAndIDNotSupportedFor("StageVariables", "StageVariableRefs")
+	}
+`
+	field := crd.Fields["StageVariables"]
+	got, err := code.ReferenceFieldsValidation(field, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func Test_ResolveReferencesForField_MapOfReferences(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-map-reference.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Stage")
+	require.NotNil(crd)
+	expected :=
+		`	for f0key, f0iter := range ko.Spec.StageVariableRefs {
+		if f0iter != nil && f0iter.From != nil {
+			hasReferences = true
+			arr := f0iter.From
+			if arr.Name == nil || *arr.Name == "" {
+				return hasReferences, fmt.Errorf("provided resource reference is nil or empty: StageVariableRefs")
+			}
+			namespace, err := ackrt.ResolveCrossNamespaceReference(
+				ctx,
+				rm.cfg.EnableCrossNamespace,
+				&ko.Status.Conditions,
+				ackrt.CrossNamespaceRefKindResource,
+				ko.ObjectMeta.GetNamespace(),
+				arr.Namespace,
+				*arr.Name,
+			)
+			if err != nil {
+				return hasReferences, err
+			}
+			obj := &lambdaapitypes.Function{}
+			if err := getReferencedResourceState_Function(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+				return hasReferences, err
+			}
+			if ko.Spec.StageVariables == nil {
+				ko.Spec.StageVariables = map[string]*string{}
+			}
+			ko.Spec.StageVariables[f0key] = (*string)(obj.Spec.Name)
+		}
+	}
+`
+
+	field := crd.Fields["StageVariables"]
+	got, err := code.ResolveReferencesForField(field, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func Test_ClearResolvedReferencesForField_MapOfReferences(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-map-reference.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Stage")
+	require.NotNil(crd)
+	expected :=
+		`	if len(ko.Spec.StageVariableRefs) > 0 {
+		ko.Spec.StageVariables = nil
+	}
+`
+
+	field := crd.Fields["StageVariables"]
+	got, err := code.ClearResolvedReferencesForField(field, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func Test_ResolveReferencesForField_SingleReference_WithinMap(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "emr-serverless",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-nested-reference.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Application")
+	require.NotNil(crd)
+
+	// the Go template has the appropriate nil checks to ensure the parent path exists
+	expected :=
+		`	for f0key, f0iter := range ko.Spec.WorkerTypeSpecifications {
+		if f0iter.ImageConfiguration != nil {
+			if f0iter.ImageConfiguration.ImageURIRef != nil && f0iter.ImageConfiguration.ImageURIRef.From != nil {
+				hasReferences = true
+				arr := f0iter.ImageConfiguration.ImageURIRef.From
+				if arr.Name == nil || *arr.Name == "" {
+					return hasReferences, fmt.Errorf("provided resource reference is nil or empty: WorkerTypeSpecifications.ImageConfiguration.ImageURIRef")
+				}
+				namespace, err := ackrt.ResolveCrossNamespaceReference(
+					ctx,
+					rm.cfg.EnableCrossNamespace,
+					&ko.Status.Conditions,
+					ackrt.CrossNamespaceRefKindResource,
+					ko.ObjectMeta.GetNamespace(),
+					arr.Namespace,
+					*arr.Name,
+				)
+				if err != nil {
+					return hasReferences, err
+				}
+				obj := &ecrapitypes.Repository{}
+				if err := getReferencedResourceState_Repository(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+					return hasReferences, err
+				}
+				ko.Spec.WorkerTypeSpecifications[f0key].ImageConfiguration.ImageURI = (*string)(obj.Status.RepositoryURI)
+			}
+		}
+	}
+`
+
+	field := crd.Fields["WorkerTypeSpecifications.ImageConfiguration.ImageURI"]
+	got, err := code.ResolveReferencesForField(field, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func Test_ClearResolvedReferencesForField_SingleReference_WithinMap(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "emr-serverless",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-nested-reference.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Application")
+	require.NotNil(crd)
+
+	// the Go template has the appropriate nil checks to ensure the parent path exists
+	expected :=
+		`	for f0key, f0iter := range ko.Spec.WorkerTypeSpecifications {
+		if f0iter.ImageConfiguration != nil {
+			if f0iter.ImageConfiguration.ImageURIRef != nil {
+				ko.Spec.WorkerTypeSpecifications[f0key].ImageConfiguration.ImageURI = nil
+			}
+		}
+	}
+`
+
+	field := crd.Fields["WorkerTypeSpecifications.ImageConfiguration.ImageURI"]
+	got, err := code.ClearResolvedReferencesForField(field, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func Test_ResolveReferencesForField_SingleReference_WithinNestedSlices(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "codedeploy",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-nested-reference.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "DeploymentGroup")
+	require.NotNil(crd)
+
+	// the Go template has the appropriate nil checks to ensure the parent path exists
+	expected :=
+		`	if ko.Spec.EC2TagSet != nil {
+		for f0idx, f0iter := range ko.Spec.EC2TagSet.EC2TagSetList {
+			for f1idx, f1iter := range f0iter {
+				if f1iter.ValueRef != nil && f1iter.ValueRef.From != nil {
+					hasReferences = true
+					arr := f1iter.ValueRef.From
+					if arr.Name == nil || *arr.Name == "" {
+						return hasReferences, fmt.Errorf("provided resource reference is nil or empty: EC2TagSet.EC2TagSetList.ValueRef")
+					}
+					namespace, err := ackrt.ResolveCrossNamespaceReference(
+						ctx,
+						rm.cfg.EnableCrossNamespace,
+						&ko.Status.Conditions,
+						ackrt.CrossNamespaceRefKindResource,
+						ko.ObjectMeta.GetNamespace(),
+						arr.Namespace,
+						*arr.Name,
+					)
+					if err != nil {
+						return hasReferences, err
+					}
+					obj := &ec2apitypes.VPC{}
+					if err := getReferencedResourceState_VPC(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+						return hasReferences, err
+					}
+					ko.Spec.EC2TagSet.EC2TagSetList[f0idx][f1idx].Value = (*string)(obj.Status.VPCID)
+				}
+			}
+		}
+	}
+`
+
+	field := crd.Fields["EC2TagSet.EC2TagSetList.Value"]
+	got, err := code.ResolveReferencesForField(field, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func Test_ClearResolvedReferencesForField_SingleReference_WithinNestedSlices(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "codedeploy",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-nested-reference.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "DeploymentGroup")
+	require.NotNil(crd)
+
+	// the Go template has the appropriate nil checks to ensure the parent path exists
+	expected :=
+		`	if ko.Spec.EC2TagSet != nil {
+		for f0idx, f0iter := range ko.Spec.EC2TagSet.EC2TagSetList {
+			for f1idx, f1iter := range f0iter {
+				if f1iter.ValueRef != nil {
+					ko.Spec.EC2TagSet.EC2TagSetList[f0idx][f1idx].Value = nil
+				}
+			}
+		}
+	}
+`
+
+	field := crd.Fields["EC2TagSet.EC2TagSetList.Value"]
+	got, err := code.ClearResolvedReferencesForField(field, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
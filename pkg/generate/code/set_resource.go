@@ -104,9 +104,52 @@ func SetResource(
 		op = r.Ops.Update
 	case model.OpTypeDelete:
 		op = r.Ops.Delete
+	case model.OpTypeReplace:
+		op = r.Ops.Replace
 	default:
 		return "", nil
 	}
+	return setResourceForOperation(
+		cfg, r, opType, op, sourceVarName, targetVarName, indentLevel,
+	)
+}
+
+// SetResourceForUpdateOperation returns the Go code that sets a CRD's field
+// values from the Output shape of one of the several SDK operations
+// configured to update a resource in `update_operation.operations`. The
+// fields are set the same way SetResource sets them from the Output shape of
+// the resource's Update operation.
+func SetResourceForUpdateOperation(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// The update operation to read the Output shape of
+	op *awssdkmodel.Operation,
+	// String representing the name of the variable that we will grab the
+	// Output shape from. This will likely be "resp".
+	sourceVarName string,
+	// String representing the name of the variable that we will be **setting**
+	// with values we get from the Output shape. This will likely be "ko".
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	return setResourceForOperation(
+		cfg, r, model.OpTypeUpdate, op, sourceVarName, targetVarName,
+		indentLevel,
+	)
+}
+
+// setResourceForOperation returns the Go code that sets a CRD's field values
+// from the Output shape of the supplied operation. See SetResource.
+func setResourceForOperation(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	opType model.OpType,
+	op *awssdkmodel.Operation,
+	sourceVarName string,
+	targetVarName string,
+	indentLevel int,
+) (string, error) {
 	if op == nil {
 		return "", nil
 	}
@@ -241,7 +284,7 @@ func SetResource(
 			continue
 		}
 
-		onlySetChangedFieldsOnUpdate := op == r.Ops.Update && r.OnlySetChangedFieldsOnUpdate()
+		onlySetChangedFieldsOnUpdate := opType == model.OpTypeUpdate && op == r.Ops.Update && r.OnlySetChangedFieldsOnUpdate()
 		if onlySetChangedFieldsOnUpdate && inSpec {
 			fieldJSONPath := fmt.Sprintf("%s.%s", cfg.PrefixConfig.SpecField[1:], f.Names.Camel)
 			out += fmt.Sprintf(
@@ -1061,7 +1104,8 @@ func SetResourceGetAttributes(
 // If it detects the operation uses an ARN to identify the resource it will read
 // it from the metadata status field. Otherwise it will use any field with a
 // name that matches the primary identifier from the operation, pulling from
-// top-level spec or status fields.
+// top-level spec or status fields. The fields of a composite primary key are
+// split from `NameOrID` with the resource's `primary_key_separator`.
 //
 // An example of code with no additional keys:
 //
@@ -1172,14 +1216,24 @@ func SetResourceIdentifiers(
 	if r.IsARNPrimaryKey() {
 		return arnOut, nil
 	}
-	primaryField, err := r.GetPrimaryKeyField()
+	primaryFields, err := r.GetPrimaryKeyFields()
 	if err != nil {
 		return "", err
 	}
 
+	var primaryField *model.Field
 	var primaryCRField, primaryShapeField string
-	isPrimarySet := primaryField != nil
-	if isPrimarySet {
+	isPrimarySet := len(primaryFields) > 0
+	if len(primaryFields) > 1 {
+		compositeOut, err := setResourceIdentifierCompositeKey(
+			cfg, r, op, primaryFields, sourceVarName, targetVarName, indentLevel,
+		)
+		if err != nil {
+			return "", err
+		}
+		primaryKeyOut += compositeOut
+	} else if isPrimarySet {
+		primaryField = primaryFields[0]
 		memberPath, _ := findFieldInCR(cfg, r, primaryField.Names.Original)
 		targetVarPath := fmt.Sprintf("%s%s", targetVarName, memberPath)
 		primaryKeyOut += setResourceIdentifierPrimaryIdentifier(cfg, r,
@@ -1236,7 +1290,7 @@ func SetResourceIdentifiers(
 		)
 
 		// Check to see if we've already set the field as the primary identifier
-		if isPrimarySet && fieldName == primaryField.Names.Camel {
+		if primaryField != nil && fieldName == primaryField.Names.Camel {
 			continue
 		}
 
@@ -1250,7 +1304,7 @@ func SetResourceIdentifiers(
 		}
 
 		memberPath, targetField := findFieldInCR(cfg, r, searchField)
-		if targetField == nil || (isPrimarySet && targetField == primaryField) {
+		if targetField == nil || (isPrimarySet && isPrimaryKeyField(primaryFields, targetField)) {
 			continue
 		}
 
@@ -1414,14 +1468,29 @@ func PopulateResourceFromAnnotation(
 	if r.IsARNPrimaryKey() {
 		return primaryKeyConditionalOut + arnOut, nil
 	}
-	primaryField, err := r.GetPrimaryKeyField()
+	primaryFields, err := r.GetPrimaryKeyFields()
 	if err != nil {
 		return "", err
 	}
 
+	var primaryField *model.Field
 	var primaryCRField, primaryShapeField string
-	isPrimarySet := primaryField != nil
-	if isPrimarySet {
+	isPrimarySet := len(primaryFields) > 0
+	if len(primaryFields) > 1 {
+		// Every field of a composite primary key is required
+		for i, field := range primaryFields {
+			memberPath, _ := findFieldInCR(cfg, r, field.Names.Original)
+			requiredFieldVarName := fmt.Sprintf("primaryKey%d", i)
+			primaryKeyOut += requiredFieldGuardContructor(requiredFieldVarName, sourceVarName, field.Names.CamelLower, indentLevel)
+			primaryKeyOut += setResourceIdentifierPrimaryIdentifierAnn(
+				"&"+requiredFieldVarName,
+				field,
+				fmt.Sprintf("%s%s", targetVarName, memberPath),
+				indentLevel,
+			)
+		}
+	} else if isPrimarySet {
+		primaryField = primaryFields[0]
 		memberPath, _ := findFieldInCR(cfg, r, primaryField.Names.Original)
 		primaryKeyOut += requiredFieldGuardContructor("primaryKey", sourceVarName, primaryField.Names.CamelLower, indentLevel)
 		targetVarPath := fmt.Sprintf("%s%s", targetVarName, memberPath)
@@ -1480,7 +1549,7 @@ func PopulateResourceFromAnnotation(
 		)
 
 		// Check to see if we've already set the field as the primary identifier
-		if isPrimarySet && fieldName == primaryField.Names.Camel {
+		if primaryField != nil && fieldName == primaryField.Names.Camel {
 			continue
 		}
 
@@ -1494,7 +1563,7 @@ func PopulateResourceFromAnnotation(
 		}
 
 		memberPath, targetField := findFieldInCR(cfg, r, searchField)
-		if targetField == nil || (isPrimarySet && targetField == primaryField) {
+		if targetField == nil || (isPrimarySet && isPrimaryKeyField(primaryFields, targetField)) {
 			continue
 		}
 
@@ -1532,6 +1601,98 @@ func PopulateResourceFromAnnotation(
 	return out + primaryKeyOut + additionalKeyOut, nil
 }
 
+// setResourceIdentifierCompositeKey returns the Go code that sets the fields
+// of the composite primary key of a resource from the values joined, in the
+// order of the fields, in the identifier `NameOrID` field. An error is
+// returned if a field is not a member of the Input shape of the operation
+// reading the resource.
+//
+//	primaryKeys := strings.Split(identifier.NameOrID, "/")
+//	if len(primaryKeys) != 2 || primaryKeys[0] == "" || primaryKeys[1] == "" {
+//		return ackerrors.NewTerminalError(fmt.Errorf(
+//			"identifier %q must have the format %q",
+//			identifier.NameOrID, "domainName/apiMappingID",
+//		))
+//	}
+//	r.ko.Spec.DomainName = &primaryKeys[0]
+//	r.ko.Status.APIMappingID = &primaryKeys[1]
+func setResourceIdentifierCompositeKey(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// The operation reading the resource from its identifiers
+	op *awssdkmodel.Operation,
+	// The fields of the composite primary key, in order
+	primaryFields []*model.Field,
+	// The struct that we access the `NameOrID` field from
+	sourceVarName string,
+	// The variable name that we want to set the fields of
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	inputFields := map[*model.Field]bool{}
+	for _, memberName := range op.InputRef.Shape.MemberNames() {
+		fieldName := cfg.GetResourceFieldName(
+			r.Names.Original,
+			op.ExportedName,
+			memberName,
+		)
+		if _, field := findFieldInCR(cfg, r, fieldName); field != nil {
+			inputFields[field] = true
+		}
+	}
+
+	indent := strings.Repeat("\t", indentLevel)
+	checks := []string{fmt.Sprintf("len(primaryKeys) != %d", len(primaryFields))}
+	format := []string{}
+	for i, field := range primaryFields {
+		if !inputFields[field] {
+			return "", fmt.Errorf(
+				"resource %q: composite primary key field %q is not a member of the Input shape of %s",
+				r.Names.Original, field.Path, op.ExportedName,
+			)
+		}
+		checks = append(checks, fmt.Sprintf("primaryKeys[%d] == \"\"", i))
+		format = append(format, field.Names.CamelLower)
+	}
+	separator := r.PrimaryKeySeparator()
+
+	// The last field takes the rest of the identifier, so that its value may
+	// contain the separator, e.g. the path of an API Gateway resource.
+	out := fmt.Sprintf(
+		"%sprimaryKeys := strings.SplitN(%s.NameOrID, %q, %d)\n",
+		indent, sourceVarName, separator, len(primaryFields),
+	)
+	out += fmt.Sprintf("%sif %s {\n", indent, strings.Join(checks, " || "))
+	out += fmt.Sprintf("%s\treturn ackerrors.NewTerminalError(fmt.Errorf(\n", indent)
+	out += fmt.Sprintf("%s\t\t\"identifier %%q must have the format %%q\",\n", indent)
+	out += fmt.Sprintf(
+		"%s\t\t%s.NameOrID, %q,\n", indent, sourceVarName,
+		strings.Join(format, separator),
+	)
+	out += fmt.Sprintf("%s\t))\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	for i, field := range primaryFields {
+		memberPath, _ := findFieldInCR(cfg, r, field.Names.Original)
+		out += fmt.Sprintf(
+			"%s%s%s.%s = &primaryKeys[%d]\n",
+			indent, targetVarName, memberPath, field.Path, i,
+		)
+	}
+	return out, nil
+}
+
+// isPrimaryKeyField returns true if the supplied field is one of the supplied
+// fields of the primary key.
+func isPrimaryKeyField(primaryFields []*model.Field, field *model.Field) bool {
+	for _, primaryField := range primaryFields {
+		if primaryField == field {
+			return true
+		}
+	}
+	return false
+}
+
 // findFieldInCR will search for a given field, by its name, in a CR and returns
 // the member path and Field type if one is found.
 func findFieldInCR(
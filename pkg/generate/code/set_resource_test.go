@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
 	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
 	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
@@ -1668,6 +1669,49 @@ func TestSetResource_RDS_DBSubnetGroup_SetResourceIdentifiers(t *testing.T) {
 	assert.Equal(expected, got)
 }
 
+func TestSetResource_APIGWV2_ApiMapping_ARNTemplate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-arn-template.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "ApiMapping")
+	require.NotNil(crd)
+
+	expectedARN := `	if ko.Status.ACKResourceMetadata == nil {
+		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+	}
+	if ko.Status.ACKResourceMetadata.ARN == nil && ko.Spec.DomainName != nil && ko.Status.ApiMappingId != nil {
+		arn := ackv1alpha1.AWSResourceName(fmt.Sprintf(
+			"arn:%s:apigateway:%s::/domainnames/%s/apimappings/%s",
+			rm.awsPartition, rm.awsRegion, *ko.Spec.DomainName, *ko.Status.ApiMappingId,
+		))
+		ko.Status.ACKResourceMetadata.ARN = &arn
+	}
+`
+	got, err := code.SetResourceARNFromTemplate(crd.Config(), crd, "ko", 1)
+	require.NoError(err)
+	assert.Equal(expectedARN, got)
+
+	// The ARN is set by sdkCreate and sdkFind, whatever the output shape of
+	// their operation, not by the code setting the resource from the output.
+	for _, opType := range []model.OpType{model.OpTypeCreate, model.OpTypeGet} {
+		got, err := code.SetResource(crd.Config(), crd, opType, "resp", "ko", 1)
+		require.NoError(err)
+		assert.NotContains(got, "ACKResourceMetadata.ARN == nil")
+	}
+
+	// Placeholders must name string fields of the resource
+	crd.Config().Resources["ApiMapping"] = ackgenconfig.ResourceConfig{
+		ARNTemplate: "arn:{partition}:apigateway:{region}::/domainnames/{Spec.Domain}",
+	}
+	_, err = code.SetResourceARNFromTemplate(crd.Config(), crd, "ko", 1)
+	require.Error(err)
+	assert.Contains(err.Error(), `placeholder "Spec.Domain": field "Domain" not found`)
+}
+
 func TestSetResource_APIGWV2_ApiMapping_SetResourceIdentifiers(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -1693,6 +1737,37 @@ func TestSetResource_APIGWV2_ApiMapping_SetResourceIdentifiers(t *testing.T) {
 	assert.Equal(expected, got)
 }
 
+func TestSetResource_APIGWV2_ApiMapping_SetResourceIdentifiers_CompositeKey(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-composite-primary-key.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "ApiMapping")
+	require.NotNil(crd)
+
+	expected := `
+	if identifier.NameOrID == "" {
+		return ackerrors.MissingNameIdentifier
+	}
+	primaryKeys := strings.SplitN(identifier.NameOrID, ":", 2)
+	if len(primaryKeys) != 2 || primaryKeys[0] == "" || primaryKeys[1] == "" {
+		return ackerrors.NewTerminalError(fmt.Errorf(
+			"identifier %q must have the format %q",
+			identifier.NameOrID, "domainName:apiMappingId",
+		))
+	}
+	r.ko.Spec.DomainName = &primaryKeys[0]
+	r.ko.Status.ApiMappingId = &primaryKeys[1]
+
+`
+	got, err := code.SetResourceIdentifiers(crd.Config(), crd, "identifier", "r.ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
 func TestSetResource_SageMaker_ModelPackage_SetResourceIdentifiers(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -1882,6 +1957,35 @@ func TestSetResource_APIGWV2_ApiMapping_PopulateResourceFromAnnotation(t *testin
 	assert.Equal(expected, got)
 }
 
+func TestSetResource_APIGWV2_ApiMapping_PopulateResourceFromAnnotation_CompositeKey(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-composite-primary-key.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "ApiMapping")
+	require.NotNil(crd)
+
+	expected := `
+	primaryKey0, ok := fields["domainName"]
+	if !ok {
+		return ackerrors.NewTerminalError(fmt.Errorf("required field missing: domainName"))
+	}
+	r.ko.Spec.DomainName = &primaryKey0
+	primaryKey1, ok := fields["apiMappingId"]
+	if !ok {
+		return ackerrors.NewTerminalError(fmt.Errorf("required field missing: apiMappingId"))
+	}
+	r.ko.Status.ApiMappingId = &primaryKey1
+
+`
+	got, err := code.PopulateResourceFromAnnotation(crd.Config(), crd, "fields", "r.ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
 func TestSetResource_IAM_Role_NestedSetConfig(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -106,9 +106,55 @@ func SetSDK(
 		op = r.Ops.Update
 	case model.OpTypeDelete:
 		op = r.Ops.Delete
+	case model.OpTypeReplace:
+		op = r.Ops.Replace
 	default:
 		return "", nil
 	}
+	return setSDKForOperation(
+		cfg, r, op, opType, sourceVarName, targetVarName, "", indentLevel,
+	)
+}
+
+// SetSDKForUpdateOperation returns the Go code that sets the Input shape of
+// one of the several SDK operations configured to update a resource in
+// `update_operation.operations`. The Input shape is populated from the
+// resource the same way SetSDK populates the Input shape of the resource's
+// Update operation.
+func SetSDKForUpdateOperation(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// The update operation to set the Input shape for
+	op *awssdkmodel.Operation,
+	// String representing the name of the variable that we will grab the Input
+	// shape from. This will likely be "r.ko".
+	sourceVarName string,
+	// String representing the name of the variable that we will be **setting**
+	// with values from the resource. This will likely be "res".
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	return setSDKForOperation(
+		cfg, r, op, model.OpTypeUpdate, sourceVarName, targetVarName, "",
+		indentLevel,
+	)
+}
+
+// setSDKForOperation returns the Go code that sets the Input shape of the
+// supplied operation from the resource. See SetSDK.
+func setSDKForOperation(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	op *awssdkmodel.Operation,
+	opType model.OpType,
+	sourceVarName string,
+	targetVarName string,
+	// Name of an Input shape member to leave unset, if any. The caller is
+	// responsible for setting it.
+	skipMemberName string,
+	indentLevel int,
+) (string, error) {
 	if op == nil {
 		return "", nil
 	}
@@ -217,11 +263,61 @@ func SetSDK(
 		)
 	}
 
+	// Members of the Delete operation's Input shape that are not fields of
+	// the resource may be set from the annotations of the resource, its Spec
+	// or static values.
+	var deleteInputFields map[string]*ackgenconfig.DeleteInputFieldConfig
+	if opType == model.OpTypeDelete {
+		deleteInputFields = r.DeleteInputFields()
+		memberNames := make([]string, 0, len(deleteInputFields))
+		for memberName := range deleteInputFields {
+			memberNames = append(memberNames, memberName)
+		}
+		sort.Strings(memberNames)
+		for _, memberName := range memberNames {
+			if _, found := inputShape.MemberRefs[memberName]; !found {
+				return "", fmt.Errorf(
+					"resource %q: delete_operation.input_fields: member %q not found in shape %q. available: %s",
+					r.Names.Original, memberName, inputShape.ShapeName,
+					strings.Join(inputShape.MemberNames(), ", "),
+				)
+			}
+		}
+	}
+
 	opConfig, override := cfg.GetOverrideValues(op.ExportedName)
 	for memberIndex, memberName := range inputShape.MemberNames() {
 		if r.UnpacksAttributesMap() && memberName == "Attributes" {
 			continue
 		}
+		if memberName == skipMemberName {
+			continue
+		}
+		// The same request is sent to create and to update a resource through
+		// a Replace operation. Reusing an idempotency token from the Spec for
+		// a request with different parameters makes the API reject the call,
+		// so the token is left unset, and the SDK fills it in for each call.
+		if opType == model.OpTypeReplace {
+			tokenRef := inputShape.MemberRefs[memberName]
+			if tokenRef.IdempotencyToken || tokenRef.Shape.IdempotencyToken {
+				continue
+			}
+		}
+
+		if fieldCfg, found := deleteInputFields[memberName]; found {
+			fieldOut, err := setSDKDeleteInputField(
+				r, memberName, inputShape.MemberRefs[memberName], fieldCfg,
+				sourceVarName, targetVarName, indentLevel,
+			)
+			if err != nil {
+				return "", fmt.Errorf(
+					"resource %q: delete_operation.input_fields: %w",
+					r.Names.Original, err,
+				)
+			}
+			out += fieldOut
+			continue
+		}
 
 		if override {
 			value, ok := opConfig[memberName]
@@ -364,7 +460,7 @@ func SetSDK(
 		//     res.VpnMemberships = f0
 		// }
 
-		omitUnchangedFieldsOnUpdate := op == r.Ops.Update && r.OmitUnchangedFieldsOnUpdate()
+		omitUnchangedFieldsOnUpdate := opType == model.OpTypeUpdate && r.OmitUnchangedFieldsOnUpdate()
 		if omitUnchangedFieldsOnUpdate && inSpec && !inputShape.IsRequired(memberName) {
 			fieldJSONPath := fmt.Sprintf("%s.%s", cfg.PrefixConfig.SpecField[1:], f.Names.Camel)
 			out += fmt.Sprintf(
@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
 	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
 	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
@@ -827,6 +828,35 @@ func TestSetSDK_ECR_Repository_Create(t *testing.T) {
 	assert.Equal(expected, got)
 }
 
+func TestSetSDK_ECR_LifecyclePolicy_Replace(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-upsert.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "LifecyclePolicy")
+	require.NotNil(crd)
+
+	// The PutLifecyclePolicy operation both creates and updates the resource,
+	// so its Input shape is set from the whole Spec.
+	got, err := code.SetSDK(crd.Config(), crd, model.OpTypeReplace, "r.ko", "res", 1)
+	require.NoError(err)
+	assert.Contains(got, `
+	if r.ko.Spec.LifecyclePolicyText != nil {
+		res.LifecyclePolicyText = r.ko.Spec.LifecyclePolicyText
+	}
+`)
+	assert.Contains(got, `
+	if r.ko.Spec.RepositoryName != nil {
+		res.RepositoryName = r.ko.Spec.RepositoryName
+	}
+`)
+	assert.NotContains(got, "delta.DifferentAt")
+}
+
 // func TestSetSDK_Elasticache_ReplicationGroup_Create(t *testing.T) {
 // 	assert := assert.New(t)
 // 	require := require.New(t)
@@ -2004,6 +2034,67 @@ func TestSetSDK_S3_Bucket_Delete(t *testing.T) {
 	assert.Equal(expected, got)
 }
 
+func TestSetSDK_RDS_DBCluster_Delete_InputFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "rds", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-pre-delete-updates.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "DBCluster")
+	require.NotNil(crd)
+
+	expected := `
+	if r.ko.Spec.DBClusterIdentifier != nil {
+		res.DBClusterIdentifier = r.ko.Spec.DBClusterIdentifier
+	}
+	if v, ok := r.ko.GetAnnotations()["rds.services.k8s.aws/delete-automated-backups"]; ok {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, ackerr.NewTerminalError(fmt.Errorf(
+				"invalid value %q of annotation %q: %w",
+				v, "rds.services.k8s.aws/delete-automated-backups", err,
+			))
+		}
+		res.DeleteAutomatedBackups = aws.Bool(parsed)
+	}
+	if v, ok := r.ko.GetAnnotations()["rds.services.k8s.aws/final-snapshot-identifier"]; ok {
+		res.FinalDBSnapshotIdentifier = aws.String(v)
+	} else {
+		if r.ko.DeletionTimestamp == nil {
+			return nil, ackerr.NewTerminalError(fmt.Errorf(
+				"cannot set FinalDBSnapshotIdentifier from the deletion timestamp of a resource that is not being deleted",
+			))
+		}
+		res.FinalDBSnapshotIdentifier = aws.String(r.ko.Name + "-final-" + r.ko.DeletionTimestamp.UTC().Format("20060102150405"))
+	}
+	if v, ok := r.ko.GetAnnotations()["rds.services.k8s.aws/skip-final-snapshot"]; ok {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, ackerr.NewTerminalError(fmt.Errorf(
+				"invalid value %q of annotation %q: %w",
+				v, "rds.services.k8s.aws/skip-final-snapshot", err,
+			))
+		}
+		res.SkipFinalSnapshot = aws.Bool(parsed)
+	} else {
+		res.SkipFinalSnapshot = aws.Bool(false)
+	}
+`
+	got, err := code.SetSDK(crd.Config(), crd, model.OpTypeDelete, "r.ko", "res", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+
+	// Members are checked against the Delete operation's Input shape.
+	crd.Config().Resources["DBCluster"].DeleteOperation.InputFields["ForceDelete"] = &ackgenconfig.DeleteInputFieldConfig{
+		Value: "true",
+	}
+	_, err = code.SetSDK(crd.Config(), crd, model.OpTypeDelete, "r.ko", "res", 1)
+	require.Error(err)
+	assert.Contains(err.Error(), `member "ForceDelete" not found in shape "DeleteDBClusterInput"`)
+}
+
 func TestSetSDK_SNS_Topic_Create(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -6961,6 +7052,31 @@ func TestSetSDK_EKS_Nodegroup_Create(t *testing.T) {
 	assert.Equal(expected, got)
 }
 
+func TestSetSDK_EKS_Addon_Replace_IdempotencyToken(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "eks",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-replace-operation.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Addon")
+	require.NotNil(crd)
+
+	// The same CreateAddon request creates and updates the resource, so the
+	// idempotency token is left for the SDK to fill in for each call...
+	got, err := code.SetSDK(crd.Config(), crd, model.OpTypeReplace, "r.ko", "res", 1)
+	require.NoError(err)
+	assert.NotContains(got, "ClientRequestToken")
+	assert.Contains(got, "res.AddonName = r.ko.Spec.AddonName")
+
+	// ...while any other operation still sets it from the Spec.
+	got, err = code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1)
+	require.NoError(err)
+	assert.Contains(got, "res.ClientRequestToken = r.ko.Spec.ClientRequestToken")
+}
+
 // TestSetSDK_LambdaMicrovms_MicrovmImage_Create_ValidateField is a regression
 // test for the code-generator's renameCollidingFields pass. The "Validate"
 // member name was historically renamed to "Validate_" to avoid colliding with
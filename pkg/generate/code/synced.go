@@ -62,7 +62,22 @@ func ResourceIsSynced(
 		return out, nil
 	}
 
+	exprCompiler := &conditionExprCompiler{crd: r, rootVarName: resVarName}
 	for _, condCfg := range resConfig.Synced.When {
+		if condCfg.Expr != nil {
+			pre, expr, err := exprCompiler.compile(*condCfg.Expr, "\t")
+			if err != nil {
+				return "", fmt.Errorf("resource %q: %w", r.Names.Original, err)
+			}
+			out += pre
+			// if !(r.ko.Status.ProgressPercent != nil && *r.ko.Status.ProgressPercent == 100) {
+			out += fmt.Sprintf("\tif %s {\n", negate(expr))
+			// return false, nil
+			out += "\t\treturn false, nil\n"
+			// }
+			out += "\t}\n"
+			continue
+		}
 		if condCfg.Path == nil || *condCfg.Path == "" {
 			return "", fmt.Errorf(
 				"resource %q: received an empty sync condition path — 'SyncCondition.Path' must be provided",
@@ -75,7 +90,10 @@ func ResourceIsSynced(
 				r.Names.Original, *condCfg.Path,
 			)
 		}
-		fp := fieldpath.FromString(*condCfg.Path)
+		fp, err := fieldpath.Parse(*condCfg.Path)
+		if err != nil {
+			return "", fmt.Errorf("resource %q: %w", r.Names.Original, err)
+		}
 		field, err := getTopLevelField(r, *condCfg.Path)
 		if err != nil {
 			return "", fmt.Errorf(
@@ -83,20 +101,19 @@ func ResourceIsSynced(
 				r.Names.Original, *condCfg.Path, err,
 			)
 		}
+		shapeRef := fieldPathShapeRef(field, fp)
+		if shapeRef == nil {
+			return "", fmt.Errorf(
+				"resource %q: cannot find field for path %q",
+				r.Names.Original, *condCfg.Path,
+			)
+		}
 		candidatesVarName := fmt.Sprintf("%sCandidates", field.Names.CamelLower)
-		if fp.Size() == 2 {
-			s, err := scalarFieldEqual(resVarName, candidatesVarName, field.ShapeRef.GoTypeElem(), condCfg)
-			if err != nil {
-				return "", err
-			}
-			out += s
-		} else {
-			s, err := fieldPathSafeEqual(resVarName, candidatesVarName, field, condCfg)
-			if err != nil {
-				return "", err
-			}
-			out += s
+		s, err := fieldPathSafeEqual(resVarName, candidatesVarName, shapeRef.GoTypeElem(), fp, condCfg)
+		if err != nil {
+			return "", err
 		}
+		out += s
 	}
 
 	return out, nil
@@ -130,19 +147,14 @@ func getTopLevelField(r *model.CRD, fieldPath string) (*model.Field, error) {
 
 // scalarFieldEqual returns Go code that compares a scalar field to a given set of values.
 func scalarFieldEqual(
-	resVarName string,
+	// Go expression of the (non-nil) field value
+	valueExpr string,
 	candidatesVarName string,
 	goType string,
 	condCfg ackgenconfig.SyncedCondition,
+	indent string,
 ) (string, error) {
 	out := ""
-	fieldPath := fmt.Sprintf("%s.%s", resVarName, *condCfg.Path)
-	// if r.ko.Status.Status == nil
-	out += fmt.Sprintf("\tif %s == nil {\n", fieldPath)
-	// return false, nil
-	out += "\t\treturn false, nil\n"
-	// }
-	out += "\t}\n"
 	valuesSlice := ""
 	switch goType {
 	case "string":
@@ -163,61 +175,162 @@ func scalarFieldEqual(
 
 	// candidates1 := []string{"AVAILABLE", "ACTIVE"}
 	out += fmt.Sprintf(
-		"\t%s := %v\n",
+		"%s%s := %v\n",
+		indent,
 		candidatesVarName,
 		valuesSlice,
 	)
 	// 	if !ackutil.InStrings(*r.ko.Status.State, candidates1) {
 	out += fmt.Sprintf(
-		"\tif !ackutil.InStrings(*%s, %s) {\n",
-		fieldPath,
+		"%sif !ackutil.InStrings(*%s, %s) {\n",
+		indent,
+		valueExpr,
 		candidatesVarName,
 	)
 
 	// return false, nil
-	out += "\t\treturn false, nil\n"
+	out += indent + "\treturn false, nil\n"
 	// }
-	out += "\t}\n"
+	out += indent + "}\n"
 	return out, nil
 }
 
-// fieldPathSafeEqual returns go code that safely compares a resource field to value
+// fieldPathSafeEqual returns go code that safely compares a resource field to
+// value. Every selected element of a list or map must be equal to one of the
+// values when the field path contains a wildcard.
+//
+//	Sample output for the path `Status.Endpoints[*].Status`:
+//
+//		if r.ko.Status.Endpoints == nil {
+//			return false, nil
+//		}
+//		for _, elem0 := range r.ko.Status.Endpoints {
+//			if elem0 == nil {
+//				return false, nil
+//			}
+//			if elem0.Status == nil {
+//				return false, nil
+//			}
+//			endpointsCandidates := []string{"AVAILABLE"}
+//			if !ackutil.InStrings(*elem0.Status, endpointsCandidates) {
+//				return false, nil
+//			}
+//		}
 func fieldPathSafeEqual(
 	resVarName string,
 	candidatesVarName string,
-	field *model.Field,
+	goType string,
+	fp *fieldpath.Path,
 	condCfg ackgenconfig.SyncedCondition,
 ) (string, error) {
 	out := ""
-	rootPath := fmt.Sprintf("%s.%s", resVarName, strings.Split(*condCfg.Path, ".")[0])
-	knownShapesPath := strings.Join(strings.Split(*condCfg.Path, ".")[1:], ".")
-
-	fp := fieldpath.FromString(knownShapesPath)
-	shapes := fp.IterShapeRefs(field.ShapeRef)
-
-	subFieldPath := rootPath
-	for index, shape := range shapes {
-		if index == len(shapes)-1 {
-			// We would check for nil in scalarFieldEqual method so no need to loop anymore
-			break
-		} else {
-			subFieldPath += "." + shape.Shape.ShapeName
+	indent := "\t"
+	cp := fp.Copy()
+	rootPath := fmt.Sprintf("%s.%s", resVarName, cp.PopFront())
+	accesses := fieldPathAccesses(rootPath, cp)
+	for _, access := range accesses {
+		if access.wildcard {
+			// for _, elem0 := range r.ko.Status.Endpoints {
+			out += fmt.Sprintf("%sfor _, %s := range %s {\n", indent, access.expr, access.container)
+			indent += "\t"
+		} else if access.index >= 0 {
+			// if len(r.ko.Spec.Rules) <= 0 {
+			out += fmt.Sprintf("%sif len(%s) <= %d {\n", indent, access.container, access.index)
+			// return false, nil
+			out += indent + "\treturn false, nil\n"
+			// }
+			out += indent + "}\n"
 		}
 		// if r.ko.Spec.ProvisionedThroughput == nil
-		out += fmt.Sprintf("\tif %s == nil {\n", subFieldPath)
+		out += fmt.Sprintf("%sif %s == nil {\n", indent, access.expr)
 		// return false, nil
-		out += "\t\treturn false, nil\n"
+		out += indent + "\treturn false, nil\n"
 		// }
-		out += "\t}\n"
+		out += indent + "}\n"
 	}
-	s, err := scalarFieldEqual(resVarName, candidatesVarName, shapes[len(shapes)-1].GoTypeElem(), condCfg)
+	s, err := scalarFieldEqual(accesses[len(accesses)-1].expr, candidatesVarName, goType, condCfg, indent)
 	if err != nil {
 		return "", err
 	}
 	out += s
+	for indent != "\t" {
+		indent = indent[1:]
+		out += indent + "}\n"
+	}
 	return out, nil
 }
 
+// fieldPathAccess is one step of the generated Go code accessing the value at
+// a field path
+type fieldPathAccess struct {
+	// expr is the Go expression of the value of the part of the field path.
+	// For a wildcard it is the name of the loop variable ranging over the
+	// elements of the container.
+	expr string
+	// container is the Go expression of the list or map containing the
+	// value, if the part is an element selector
+	container string
+	// index is the list index of an index selector, or -1
+	index int
+	// wildcard is true if the part selects every element of the container
+	wildcard bool
+}
+
+// fieldPathAccesses returns the steps of the Go code accessing the value at
+// the supplied field path, relative to the supplied Go expression, e.g.
+// "r.ko.Status" and "Endpoints[*].Address". Index and key selectors are
+// rendered as Go index expressions, and the elements selected by wildcards
+// are named elem0, elem1, etc. by nesting level.
+func fieldPathAccesses(
+	rootExpr string,
+	fp *fieldpath.Path,
+) []fieldPathAccess {
+	accesses := []fieldPathAccess{}
+	cur := rootExpr
+	wildcards := 0
+	for idx := 0; idx < fp.Size(); idx++ {
+		part := fp.At(idx)
+		access := fieldPathAccess{index: -1}
+		switch {
+		case fieldpath.IsWildcard(part):
+			access.container = cur
+			access.expr = fmt.Sprintf("elem%d", wildcards)
+			access.wildcard = true
+			wildcards++
+		case fieldpath.IsSelector(part):
+			access.container = cur
+			access.expr = cur + part
+			if index, ok := fieldpath.Index(part); ok {
+				access.index = index
+			}
+		default:
+			access.expr = cur + "." + part
+		}
+		cur = access.expr
+		accesses = append(accesses, access)
+	}
+	return accesses
+}
+
+// fieldPathShapeRef returns the ShapeRef of the value at the supplied field
+// path (e.g. "Status.Endpoints[*].Address") of the supplied top-level field
+// (e.g. "Endpoints"), or nil if there is no such value
+func fieldPathShapeRef(
+	field *model.Field,
+	fp *fieldpath.Path,
+) *awssdkmodel.ShapeRef {
+	if field.ShapeRef == nil || fp.Size() < 2 {
+		return nil
+	}
+	// The first part of the path matched by Path.ShapeRef is the name of the
+	// top-level field's shape, not the name of the field.
+	shapePath := fieldpath.FromString(field.ShapeRef.ShapeName)
+	for idx := 2; idx < fp.Size(); idx++ {
+		shapePath.PushBack(fp.At(idx))
+	}
+	return shapePath.ShapeRef(field.ShapeRef)
+}
+
 func fieldPathContainsMapOrArray(fieldPath string, shapeRef *awssdkmodel.ShapeRef) bool {
 	fp := fieldpath.FromString(fieldPath)
 	sr := fp.ShapeRef(shapeRef)
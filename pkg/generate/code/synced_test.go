@@ -103,3 +103,104 @@ func TestSyncedDynamodbTable(t *testing.T) {
 	require.NoError(err)
 	assert.Equal(expectedSyncedConditions, got)
 }
+
+func TestSyncedLambdaFunction_FieldPathSelectors(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-field-path-selectors.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	expectedSyncedConditions := `
+	if r.ko.Spec.FileSystemConfigs == nil {
+		return false, nil
+	}
+	for _, elem0 := range r.ko.Spec.FileSystemConfigs {
+		if elem0 == nil {
+			return false, nil
+		}
+		if elem0.ARN == nil {
+			return false, nil
+		}
+		fileSystemConfigsCandidates := []string{"arn:aws:elasticfilesystem:us-west-2:123456789012:access-point/fsap-1"}
+		if !ackutil.InStrings(*elem0.ARN, fileSystemConfigsCandidates) {
+			return false, nil
+		}
+	}
+	if r.ko.Spec.Environment == nil {
+		return false, nil
+	}
+	if r.ko.Spec.Environment.Variables == nil {
+		return false, nil
+	}
+	if r.ko.Spec.Environment.Variables["STAGE"] == nil {
+		return false, nil
+	}
+	environmentCandidates := []string{"prod"}
+	if !ackutil.InStrings(*r.ko.Spec.Environment.Variables["STAGE"], environmentCandidates) {
+		return false, nil
+	}
+`
+	got, err := code.ResourceIsSynced(
+		crd.Config(), crd, "r.ko", 1,
+	)
+	require.NoError(err)
+	assert.Equal(expectedSyncedConditions, got)
+}
+
+func TestSyncedLambdaFunction_ConditionExprs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-condition-exprs.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	expectedSyncedConditions := `
+	if !((r.ko.Status.State != nil && (*r.ko.Status.State == "Active" || *r.ko.Status.State == "Inactive")) && (r.ko.Status.LastUpdateStatus != nil && *r.ko.Status.LastUpdateStatus != "")) {
+		return false, nil
+	}
+	if !((r.ko.Status.CodeSize != nil && *r.ko.Status.CodeSize > 0) || (len(r.ko.Spec.Layers) >= 2)) {
+		return false, nil
+	}
+	all0 := true
+	for _, elem := range r.ko.Spec.FileSystemConfigs {
+		if !(elem != nil && elem.LocalMountPath != nil && *elem.LocalMountPath != "/tmp") {
+			all0 = false
+			break
+		}
+	}
+	if !all0 {
+		return false, nil
+	}
+`
+	got, err := code.ResourceIsSynced(
+		crd.Config(), crd, "r.ko", 1,
+	)
+	require.NoError(err)
+	assert.Equal(expectedSyncedConditions, got)
+}
+
+func TestSyncedLambdaFunction_ConditionExprTypeError(t *testing.T) {
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-condition-expr-type-error.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	_, err := code.ResourceIsSynced(
+		crd.Config(), crd, "r.ko", 1,
+	)
+	require.Error(err)
+	require.Contains(err.Error(), `Status.CodeSize == "big": mismatched types int and string`)
+}
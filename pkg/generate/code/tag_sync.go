@@ -0,0 +1,335 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws-controllers-k8s/code-generator/pkg/api"
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// TagSyncUpdate returns the body of the sync method of a resource configured
+// with `tags.sync_operations`. The generated code converts the desired and
+// latest tags with the convertToOrderedACKTags function generated into
+// tags.go, and calls the untag operation for the keys of the latest tags that
+// are no longer desired, then the tag operation for the desired tags that are
+// missing or have a different value in the latest tags.
+//
+// Sample output, for ECR's Repository with a batch_size of 10:
+//
+//	if latest.ko.Status.ACKResourceMetadata == nil || latest.ko.Status.ACKResourceMetadata.ARN == nil {
+//		return fmt.Errorf("cannot sync tags: resource ARN is not set")
+//	}
+//	arn := string(*latest.ko.Status.ACKResourceMetadata.ARN)
+//	desiredTags, _ := convertToOrderedACKTags(desired.ko.Spec.Tags)
+//	latestTags, _ := convertToOrderedACKTags(latest.ko.Spec.Tags)
+//	...
+//	for start := 0; start < len(toAdd); start += 10 {
+//		end := start + 10
+//		if end > len(toAdd) {
+//			end = len(toAdd)
+//		}
+//		input := &svcsdk.TagResourceInput{}
+//		input.ResourceArn = &arn
+//		for _, k := range toAdd[start:end] {
+//			input.Tags = append(input.Tags, svcsdktypes.Tag{
+//				Key:   aws.String(k),
+//				Value: aws.String(desiredTags[k]),
+//			})
+//		}
+//		_, err = rm.sdkapi.TagResource(ctx, input)
+//		rm.metrics.RecordAPICall("UPDATE", "TagResource", err)
+//		if err != nil {
+//			return err
+//		}
+//	}
+//	return nil
+func TagSyncUpdate(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// desired resource variable name — "desired" for the sync method
+	desiredVarName string,
+	// latest resource variable name — "latest" for the sync method
+	latestVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	ts, err := r.TagSync()
+	if err != nil || ts == nil {
+		return "", err
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	fieldPath := cfg.PrefixConfig.SpecField + "." + ts.Field.Names.Camel
+
+	out := tagSyncARN(
+		cfg, latestVarName+".ko",
+		`fmt.Errorf("cannot sync tags: resource ARN is not set")`, indent,
+	)
+	out += fmt.Sprintf(
+		"%sdesiredTags, _ := convertToOrderedACKTags(%s.ko%s)\n",
+		indent, desiredVarName, fieldPath,
+	)
+	out += fmt.Sprintf(
+		"%slatestTags, _ := convertToOrderedACKTags(%s.ko%s)\n",
+		indent, latestVarName, fieldPath,
+	)
+	out += fmt.Sprintf("%stoAdd := []string{}\n", indent)
+	out += fmt.Sprintf("%sfor k, v := range desiredTags {\n", indent)
+	out += fmt.Sprintf(
+		"%s\tif lv, found := latestTags[k]; !found || lv != v {\n", indent,
+	)
+	out += fmt.Sprintf("%s\t\ttoAdd = append(toAdd, k)\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	// AWS-managed tags cannot be removed, and are preserved in the desired
+	// tags by the runtime anyway.
+	out += fmt.Sprintf("%stoRemove := []string{}\n", indent)
+	out += fmt.Sprintf("%sfor k := range latestTags {\n", indent)
+	out += fmt.Sprintf(
+		"%s\tif _, found := desiredTags[k]; !found && !strings.HasPrefix(k, \"aws:\") {\n",
+		indent,
+	)
+	out += fmt.Sprintf("%s\t\ttoRemove = append(toRemove, k)\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+
+	setTagKeys := fmt.Sprintf(
+		"%s\tinput.%s = toRemove[start:end]\n", indent, ts.TagKeysMember,
+	)
+	out += tagSyncBatches(ts, ts.UntagOp, "toRemove", setTagKeys, indent)
+	setTags := ""
+	if ts.Tags.IsMap() {
+		setTags += fmt.Sprintf(
+			"%s\tinput.%s = map[string]string{}\n", indent, ts.Tags.Name,
+		)
+		setTags += fmt.Sprintf("%s\tfor _, k := range toAdd[start:end] {\n", indent)
+		setTags += fmt.Sprintf(
+			"%s\t\tinput.%s[k] = desiredTags[k]\n", indent, ts.Tags.Name,
+		)
+		setTags += fmt.Sprintf("%s\t}\n", indent)
+	} else {
+		setTags += fmt.Sprintf("%s\tfor _, k := range toAdd[start:end] {\n", indent)
+		setTags += fmt.Sprintf(
+			"%s\t\tinput.%s = append(input.%s, svcsdktypes.%s{\n",
+			indent, ts.Tags.Name, ts.Tags.Name, ts.Tags.Shape.MemberRef.ShapeName,
+		)
+		// Align the values the way gofmt does.
+		width := len(ts.Tags.KeyMember)
+		if len(ts.Tags.ValueMember) > width {
+			width = len(ts.Tags.ValueMember)
+		}
+		setTags += fmt.Sprintf(
+			"%s\t\t\t%-*s aws.String(k),\n",
+			indent, width+1, ts.Tags.KeyMember+":",
+		)
+		setTags += fmt.Sprintf(
+			"%s\t\t\t%-*s aws.String(desiredTags[k]),\n",
+			indent, width+1, ts.Tags.ValueMember+":",
+		)
+		setTags += fmt.Sprintf("%s\t\t})\n", indent)
+		setTags += fmt.Sprintf("%s\t}\n", indent)
+	}
+	out += tagSyncBatches(ts, ts.TagOp, "toAdd", setTags, indent)
+	out += fmt.Sprintf("%sreturn nil\n", indent)
+	return out, nil
+}
+
+// tagSyncARN returns Go code that sets an "arn" variable from the ARN of the
+// supplied resource, returning the supplied value when the ARN is not set.
+func tagSyncARN(
+	cfg *ackgenconfig.Config,
+	koVarName string,
+	returnValue string,
+	indent string,
+) string {
+	metadata := koVarName + cfg.PrefixConfig.StatusField + ".ACKResourceMetadata"
+	out := fmt.Sprintf(
+		"%sif %s == nil || %s.ARN == nil {\n", indent, metadata, metadata,
+	)
+	out += fmt.Sprintf("%s\treturn %s\n", indent, returnValue)
+	out += fmt.Sprintf("%s}\n", indent)
+	out += fmt.Sprintf("%sarn := string(*%s.ARN)\n", indent, metadata)
+	return out
+}
+
+// tagSyncBatches returns Go code that calls the supplied tag or untag
+// operation for the tag keys in the named variable, one batch at a time. The
+// supplied code sets the tags of the current batch on the "input" variable.
+func tagSyncBatches(
+	ts *model.TagSync,
+	op *awssdkmodel.Operation,
+	varName string,
+	setBatch string,
+	indent string,
+) string {
+	opName := op.ExportedName
+	batchSize := fmt.Sprintf("len(%s)", varName)
+	if ts.BatchSize > 0 {
+		batchSize = fmt.Sprintf("%d", ts.BatchSize)
+	}
+	out := fmt.Sprintf(
+		"%sfor start := 0; start < len(%s); start += %s {\n",
+		indent, varName, batchSize,
+	)
+	out += fmt.Sprintf("%s\tend := start + %s\n", indent, batchSize)
+	out += fmt.Sprintf("%s\tif end > len(%s) {\n", indent, varName)
+	out += fmt.Sprintf("%s\t\tend = len(%s)\n", indent, varName)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf(
+		"%s\tinput := &svcsdk.%s{}\n", indent, op.InputRef.Shape.ShapeName,
+	)
+	out += fmt.Sprintf(
+		"%s\tinput.%s = &arn\n", indent, ts.ARNMembers[opName],
+	)
+	out += setBatch
+	out += fmt.Sprintf("%s\t_, err = rm.sdkapi.%s(ctx, input)\n", indent, opName)
+	out += fmt.Sprintf(
+		"%s\trm.metrics.RecordAPICall(\"UPDATE\", %q, err)\n", indent, opName,
+	)
+	out += fmt.Sprintf("%s\tif err != nil {\n", indent)
+	out += fmt.Sprintf("%s\t\treturn err\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
+// TagSyncRead returns the body of the read method of a resource configured
+// with `tags.sync_operations` and a list operation. The generated code calls
+// the list operation and sets the tag field with the fromACKTags function
+// generated into tags.go, preserving the order of the keys already in the
+// field. Every page of a paginated list operation is read, the same way as
+// for the read operation of a child collection.
+//
+// Sample output, for EKS's Cluster:
+//
+//	if ko.Status.ACKResourceMetadata == nil || ko.Status.ACKResourceMetadata.ARN == nil {
+//		return nil
+//	}
+//	arn := string(*ko.Status.ACKResourceMetadata.ARN)
+//	input := &svcsdk.ListTagsForResourceInput{}
+//	input.ResourceArn = &arn
+//	tags := map[string]string{}
+//	var resp *svcsdk.ListTagsForResourceOutput
+//	resp, err = rm.sdkapi.ListTagsForResource(ctx, input)
+//	rm.metrics.RecordAPICall("READ_ONE", "ListTagsForResource", err)
+//	if err != nil {
+//		return err
+//	}
+//	for k, v := range resp.Tags {
+//		tags[k] = v
+//	}
+//	_, keyOrder := convertToOrderedACKTags(ko.Spec.Tags)
+//	if len(tags) > 0 || len(ko.Spec.Tags) > 0 {
+//		ko.Spec.Tags = fromACKTags(tags, keyOrder)
+//	}
+//	return nil
+func TagSyncRead(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// String representing the name of the variable holding the CRD struct to
+	// set. This will likely be "ko".
+	koVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	ts, err := r.TagSync()
+	if err != nil || ts == nil || ts.ListOp == nil {
+		return "", err
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	targetField := koVarName + cfg.PrefixConfig.SpecField + "." + ts.Field.Names.Camel
+	opName := ts.ListOp.ExportedName
+
+	// Without an ARN, the resource has not been found yet and there are no
+	// tags to read.
+	out := tagSyncARN(cfg, koVarName, "nil", indent)
+	out += fmt.Sprintf(
+		"%sinput := &svcsdk.%s{}\n", indent, ts.ListOp.InputRef.Shape.ShapeName,
+	)
+	out += fmt.Sprintf("%sinput.%s = &arn\n", indent, ts.ARNMembers[opName])
+	out += fmt.Sprintf("%stags := map[string]string{}\n", indent)
+	listVarName := "resp." + ts.ListTags.Name
+	readPage := func(pageIndentLevel int) (string, error) {
+		pageIndent := strings.Repeat("\t", pageIndentLevel)
+		if ts.ListTags.IsMap() {
+			out := fmt.Sprintf("%sfor k, v := range %s {\n", pageIndent, listVarName)
+			out += fmt.Sprintf("%s\ttags[k] = v\n", pageIndent)
+			out += fmt.Sprintf("%s}\n", pageIndent)
+			return out, nil
+		}
+		out := fmt.Sprintf("%sfor _, t := range %s {\n", pageIndent, listVarName)
+		out += fmt.Sprintf(
+			"%s\tif t.%s != nil {\n", pageIndent, ts.ListTags.KeyMember,
+		)
+		out += fmt.Sprintf(
+			"%s\t\ttags[*t.%s] = aws.ToString(t.%s)\n",
+			pageIndent, ts.ListTags.KeyMember, ts.ListTags.ValueMember,
+		)
+		out += fmt.Sprintf("%s\t}\n", pageIndent)
+		out += fmt.Sprintf("%s}\n", pageIndent)
+		return out, nil
+	}
+	pages, err := readPages(r, ts.ListOp, readPage, indentLevel)
+	if err != nil {
+		return "", err
+	}
+	out += pages
+	out += fmt.Sprintf(
+		"%s_, keyOrder := convertToOrderedACKTags(%s)\n", indent, targetField,
+	)
+	// Leave an empty desired field alone when no tags are observed, so that a
+	// nil and an empty field do not show up as a difference.
+	out += fmt.Sprintf(
+		"%sif len(tags) > 0 || len(%s) > 0 {\n", indent, targetField,
+	)
+	out += fmt.Sprintf(
+		"%s\t%s = fromACKTags(tags, keyOrder)\n", indent, targetField,
+	)
+	out += fmt.Sprintf("%s}\n", indent)
+	out += fmt.Sprintf("%sreturn nil\n", indent)
+	return out, nil
+}
+
+// TagSyncReads returns the Go code, for sdkFind, that sets the tag field of a
+// resource configured with `tags.sync_operations` and a list operation.
+//
+// Sample output:
+//
+//	if err = rm.readTags(ctx, ko); err != nil {
+//		return nil, err
+//	}
+func TagSyncReads(
+	r *model.CRD,
+	// String representing the name of the variable holding the CRD struct to
+	// set. This will likely be "ko".
+	koVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	ts, err := r.TagSync()
+	if err != nil || ts == nil || ts.ListOp == nil {
+		return "", err
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	out := fmt.Sprintf(
+		"\n%sif err = rm.read%s(ctx, %s); err != nil {\n",
+		indent, ts.Field.Names.Camel, koVarName,
+	)
+	out += fmt.Sprintf("%s\treturn nil, err\n", indent)
+	out += fmt.Sprintf("%s}", indent)
+	return out, nil
+}
@@ -0,0 +1,169 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func crdWithTagSync(t *testing.T, svc string, crdName string) *model.CRD {
+	g := testutil.NewModelForServiceWithOptions(t, svc,
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-tag-sync.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, crdName)
+	require.NotNil(t, crd)
+	return crd
+}
+
+func TestTagSyncUpdate_ECR_Repository(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	crd := crdWithTagSync(t, "ecr", "Repository")
+
+	got, err := code.TagSyncUpdate(crd.Config(), crd, "desired", "latest", 1)
+	require.NoError(err)
+
+	// The tags are diffed through the ACK tag conversions...
+	assert.Contains(got, `	arn := string(*latest.ko.Status.ACKResourceMetadata.ARN)
+	desiredTags, _ := convertToOrderedACKTags(desired.ko.Spec.Tags)
+	latestTags, _ := convertToOrderedACKTags(latest.ko.Spec.Tags)
+`)
+	// ...the removed keys are untagged in batches...
+	assert.Contains(got, `	for start := 0; start < len(toRemove); start += 10 {
+		end := start + 10
+		if end > len(toRemove) {
+			end = len(toRemove)
+		}
+		input := &svcsdk.UntagResourceInput{}
+		input.ResourceArn = &arn
+		input.TagKeys = toRemove[start:end]
+		_, err = rm.sdkapi.UntagResource(ctx, input)
+`)
+	// ...and the added or changed tags are tagged as a list of structures.
+	assert.Contains(got, `		input := &svcsdk.TagResourceInput{}
+		input.ResourceArn = &arn
+		for _, k := range toAdd[start:end] {
+			input.Tags = append(input.Tags, svcsdktypes.Tag{
+				Key:   aws.String(k),
+				Value: aws.String(desiredTags[k]),
+			})
+		}
+		_, err = rm.sdkapi.TagResource(ctx, input)
+		rm.metrics.RecordAPICall("UPDATE", "TagResource", err)
+`)
+}
+
+func TestTagSyncUpdate_EKS_Cluster(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	crd := crdWithTagSync(t, "eks", "Cluster")
+
+	got, err := code.TagSyncUpdate(crd.Config(), crd, "desired", "latest", 1)
+	require.NoError(err)
+
+	// Without a batch_size, all the tags are passed in a single call.
+	assert.Contains(got, `	for start := 0; start < len(toAdd); start += len(toAdd) {`)
+	assert.Contains(got, `		input.Tags = map[string]string{}
+		for _, k := range toAdd[start:end] {
+			input.Tags[k] = desiredTags[k]
+		}
+`)
+}
+
+func TestTagSyncRead_ECR_Repository(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	crd := crdWithTagSync(t, "ecr", "Repository")
+
+	got, err := code.TagSyncRead(crd.Config(), crd, "ko", 1)
+	require.NoError(err)
+	assert.Contains(got, `	var resp *svcsdk.ListTagsForResourceOutput
+	resp, err = rm.sdkapi.ListTagsForResource(ctx, input)
+	rm.metrics.RecordAPICall("READ_ONE", "ListTagsForResource", err)
+`)
+	assert.Contains(got, `	for _, t := range resp.Tags {
+		if t.Key != nil {
+			tags[*t.Key] = aws.ToString(t.Value)
+		}
+	}
+	_, keyOrder := convertToOrderedACKTags(ko.Spec.Tags)
+	if len(tags) > 0 || len(ko.Spec.Tags) > 0 {
+		ko.Spec.Tags = fromACKTags(tags, keyOrder)
+	}
+`)
+
+	reads, err := code.TagSyncReads(crd, "ko", 1)
+	require.NoError(err)
+	assert.Equal(`
+	if err = rm.readTags(ctx, ko); err != nil {
+		return nil, err
+	}`, reads)
+}
+
+func TestTagSyncRead_Backup_BackupVault_Paginated(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	crd := crdWithTagSync(t, "backup", "BackupVault")
+
+	// ListTags is paginated with the NextToken member, so the tags of every
+	// page are read before the field is set.
+	got, err := code.TagSyncRead(crd.Config(), crd, "ko", 1)
+	require.NoError(err)
+	assert.Contains(got, `	tags := map[string]string{}
+	for {
+		var resp *svcsdk.ListTagsOutput
+		resp, err = rm.sdkapi.ListTags(ctx, input)
+		rm.metrics.RecordAPICall("READ_ONE", "ListTags", err)
+		if err != nil {
+			return err
+		}
+		for k, v := range resp.Tags {
+			tags[k] = v
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+	_, keyOrder := convertToOrderedACKTags(ko.Spec.Tags)
+`)
+}
+
+func TestTagSync_CustomSyncDispatch(t *testing.T) {
+	assert := assert.New(t)
+
+	crd := crdWithTagSync(t, "ecr", "Repository")
+
+	// The tag field is synced from sdkUpdate like a custom_sync field...
+	assert.Contains(code.CustomSyncUpdate(crd, "desired", "latest", "delta", 1), `
+	if delta.DifferentAt("Spec.Tags") {
+		err = rm.syncTags(ctx, desired, latest)
+`)
+	// ...but CreateRepository already accepts the tags, so there is nothing
+	// left to sync after create.
+	assert.Empty(code.CustomSyncCreate(crd, "ko", 1))
+}
@@ -0,0 +1,90 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// UpdateOperations returns Go code that invokes, in the configured order, each
+// of the SDK operations configured in `update_operation.operations` whose
+// Spec field paths differ in the delta.
+//
+// Each operation is invoked through a generated `update<OperationName>` method
+// on the resource manager, which sets the supplied object from the operation's
+// response. An error returned by an operation stops the update, and the object
+// set from the responses of the operations that succeeded is returned along
+// with the error, so that the ACK runtime saves its Status. Operations are not
+// retried individually: the next reconciliation invokes again every operation
+// whose Spec field paths still differ.
+//
+// Sample output:
+//
+//	if delta.DifferentAt("Spec.ImageTagMutability") {
+//	    err = rm.updatePutImageTagMutability(ctx, desired, delta, ko)
+//	    if err != nil {
+//	        return &resource{ko}, err
+//	    }
+//	}
+//	if delta.DifferentAt("Spec.ImageScanningConfiguration") {
+//	    err = rm.updatePutImageScanningConfiguration(ctx, desired, delta, ko)
+//	    if err != nil {
+//	        return &resource{ko}, err
+//	    }
+//	}
+func UpdateOperations(
+	r *model.CRD,
+	// desired resource variable name — "desired" for sdkUpdate
+	desiredVarName string,
+	// delta variable name — "delta" for sdkUpdate
+	deltaVarName string,
+	// variable name of the object set from the operations' responses — "ko"
+	// for sdkUpdate
+	koVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) (string, error) {
+	updateOps, err := r.UpdateOperations()
+	if err != nil {
+		return "", err
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	specPrefix := strings.TrimPrefix(r.Config().PrefixConfig.SpecField, ".")
+
+	out := "\n"
+	for _, updateOp := range updateOps {
+		conditions := make([]string, 0, len(updateOp.FieldPaths))
+		for _, fieldPath := range updateOp.FieldPaths {
+			conditions = append(conditions, fmt.Sprintf(
+				"%s.DifferentAt(%q)", deltaVarName, specPrefix+"."+fieldPath,
+			))
+		}
+		out += fmt.Sprintf(
+			"%sif %s {\n", indent, strings.Join(conditions, " || "),
+		)
+		out += fmt.Sprintf(
+			"%s\terr = rm.update%s(ctx, %s, %s, %s)\n",
+			indent, updateOp.Operation.ExportedName, desiredVarName,
+			deltaVarName, koVarName,
+		)
+		out += fmt.Sprintf("%s\tif err != nil {\n", indent)
+		out += fmt.Sprintf("%s\t\treturn &resource{%s}, err\n", indent, koVarName)
+		out += fmt.Sprintf("%s\t}\n", indent)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out, nil
+}
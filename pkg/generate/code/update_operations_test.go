@@ -0,0 +1,148 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestUpdateOperations_S3_Bucket(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "s3",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-update-operations.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Bucket")
+	require.NotNil(crd)
+	require.True(crd.HasUpdateOperations())
+
+	// Operations are invoked in the order they are configured, not sorted, and
+	// an error returns the object set from the operations that succeeded.
+	expected := `
+	if delta.DifferentAt("Spec.Logging") {
+		err = rm.updatePutBucketLogging(ctx, desired, delta, ko)
+		if err != nil {
+			return &resource{ko}, err
+		}
+	}
+	if delta.DifferentAt("Spec.Tagging") {
+		err = rm.updatePutBucketTagging(ctx, desired, delta, ko)
+		if err != nil {
+			return &resource{ko}, err
+		}
+	}
+`
+	got, err := code.UpdateOperations(crd, "desired", "delta", "ko", 1)
+	require.NoError(err)
+	assert.Equal(expected, got)
+}
+
+func TestSetSDKForUpdateOperation_S3_Bucket(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "s3",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-update-operations.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Bucket")
+	require.NotNil(crd)
+
+	updateOps, err := crd.UpdateOperations()
+	require.NoError(err)
+	require.Len(updateOps, 2)
+	assert.Equal("PutBucketLogging", updateOps[0].Operation.ExportedName)
+	assert.Equal("put_bucket_logging", updateOps[0].Names.Snake)
+	assert.Equal([]string{"Logging"}, updateOps[0].FieldPaths)
+
+	got, err := code.SetSDKForUpdateOperation(
+		crd.Config(), crd, updateOps[0].Operation, "r.ko", "res", 1,
+	)
+	require.NoError(err)
+	// The Bucket member is renamed to the Name field for PutBucketLogging.
+	assert.Contains(got, `	if r.ko.Spec.Name != nil {
+		res.Bucket = r.ko.Spec.Name
+	}
+`)
+	assert.Contains(got, `	if r.ko.Spec.Logging != nil {
+		f1 := &svcsdktypes.BucketLoggingStatus{}
+`)
+	assert.Contains(got, "		res.BucketLoggingStatus = f1\n")
+}
+
+func TestSetResourceForUpdateOperation_Route53_HostedZone(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "route53",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-async-operations.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "HostedZone")
+	require.NotNil(crd)
+
+	updateOps, err := crd.UpdateOperations()
+	require.NoError(err)
+	require.Len(updateOps, 2)
+
+	// The UpdateHostedZoneComment response wraps the updated HostedZone.
+	got, err := code.SetResourceForUpdateOperation(
+		crd.Config(), crd, updateOps[0].Operation, "resp", "ko", 1,
+	)
+	require.NoError(err)
+	assert.Contains(got, `	if resp.HostedZone.Name != nil {
+		ko.Spec.Name = resp.HostedZone.Name
+	} else {
+		ko.Spec.Name = nil
+	}
+`)
+}
+
+func TestUpdateOperations_S3_Bucket_InvalidFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "s3",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-update-operations.yaml",
+		})
+
+	crd := testutil.GetCRDByName(t, g, "Bucket")
+	require.NotNil(crd)
+	opCfgs := crd.Config().GetUpdateOperations("Bucket")
+	require.Len(opCfgs, 2)
+
+	// Status fields cannot be updated.
+	opCfgs[1].Fields = []string{"Location"}
+	_, err := crd.UpdateOperations()
+	require.Error(err)
+	assert.Contains(err.Error(), `update operation "PutBucketTagging": Spec field "Location" not found`)
+
+	// Changes to the Tagging field would never be sent to AWS.
+	opCfgs[1].Fields = []string{"Logging"}
+	_, err = crd.UpdateOperations()
+	require.Error(err)
+	assert.Contains(err.Error(), "Spec fields Tagging are not owned by any update operation")
+}
@@ -134,7 +134,10 @@ func Crossplane(
 		funcMap,
 	)
 
-	metaVars := m.MetaVars()
+	metaVars, err := m.MetaVars()
+	if err != nil {
+		return nil, err
+	}
 	detectedCRDVersions := make(map[string]bool)
 	for _, crd := range crds {
 		v, err := crd.GetStorageVersion(metaVars.APIVersion)
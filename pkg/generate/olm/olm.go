@@ -58,6 +58,10 @@ func BundleAssets(
 	if err != nil {
 		return nil, err
 	}
+	metaVars, err := m.MetaVars()
+	if err != nil {
+		return nil, err
+	}
 
 	// Remove any `v` index that may have been included
 	strippedVersion := strings.TrimPrefix(releaseVersion, "v")
@@ -69,7 +73,7 @@ func BundleAssets(
 		},
 		strippedVersion,
 		time.Now().Format("2006-01-02 15:04:05"),
-		m.MetaVars(),
+		metaVars,
 		commonMeta,
 		serviceConfig,
 		crds,
@@ -88,7 +92,7 @@ func BundleAssets(
 
 	csvBaseOutPath := fmt.Sprintf(
 		"config/manifests/bases/ack-%s-controller.clusterserviceversion.yaml",
-		m.MetaVars().ControllerName)
+		metaVars.ControllerName)
 	if err := ts.Add(csvBaseOutPath, "config/manifests/bases/clusterserviceversion.yaml.tpl", olmVars); err != nil {
 		return nil, err
 	}
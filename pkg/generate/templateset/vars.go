@@ -47,4 +47,20 @@ type MetaVars struct {
 	ClientStructTypeName string
 	//CRDNames contains all crds names lowercased and in plural
 	CRDNames []string
+	// NamespacedCRDNames contains the names of the namespaced crds lowercased
+	// and in plural
+	NamespacedCRDNames []string
+	// ClusterScopedCRDNames contains the names of the cluster-scoped crds
+	// lowercased and in plural
+	ClusterScopedCRDNames []string
+	// APIGroups contains the service's API group followed by the API groups
+	// that CRDs are placed in with the `api_group` resource config
+	APIGroups []string
+	// APIGroupPackages contains the names of the Go packages, relative to the
+	// apis/$API_VERSION package, containing the types of CRDs placed in an
+	// API group other than the service's
+	APIGroupPackages []string
+	// CRDManifestNames contains the base names of the CRD manifests generated
+	// by controller-gen, e.g. "ecr.services.k8s.aws_repositories"
+	CRDManifestNames []string
 }
@@ -0,0 +1,245 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws-controllers-k8s/code-generator/pkg/api"
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
+)
+
+// asyncOperationTypes are the types of the operations of a resource that may
+// complete asynchronously, in the order their configs are processed.
+var asyncOperationTypes = []string{"Create", "Update", "Delete"}
+
+// AsyncOperation describes an operation of a resource that completes
+// asynchronously, and the SDK operation polled for its status. See
+// ackgenconfig.AsyncOperationConfig.
+type AsyncOperation struct {
+	// OpType is the type of the operation: Create, Update or Delete
+	OpType string
+	// Operations are the SDK operations completing asynchronously: the
+	// resource's Create, Update or Delete operation, which is its Replace
+	// operation for an upsert resource, or, for the Update of a resource
+	// configured with `update_operations`, those of its update operations
+	// whose Output shape contains the operation ID
+	Operations []*awssdkmodel.Operation
+	// IDPaths maps the ExportedName of each of the Operations to the exact
+	// names of the members, from the operation's Output shape down, leading
+	// to the operation ID
+	IDPaths map[string][]string
+	// StatusField is the Status field storing the operation ID
+	StatusField *Field
+	// StatusOp is the SDK operation polled for the status of the operation
+	StatusOp *awssdkmodel.Operation
+	// StatusIDMember is the exact name of the member of the StatusOp's Input
+	// shape receiving the operation ID
+	StatusIDMember string
+	// StatusPath contains the exact names of the members, from the StatusOp's
+	// Output shape down, leading to the status of the operation
+	StatusPath []string
+	// StatusShapeRef is the ShapeRef of the status of the operation
+	StatusShapeRef *awssdkmodel.ShapeRef
+	// CompletedWhen are the statuses of a successfully completed operation
+	CompletedWhen []string
+	// FailedWhen are the statuses of a failed operation
+	FailedWhen []string
+}
+
+// HasAsyncOperations returns true if any of the Create, Update or Delete
+// operations of the CRD completes asynchronously.
+func (r *CRD) HasAsyncOperations() bool {
+	for _, opType := range asyncOperationTypes {
+		if r.cfg.GetAsyncOperation(r.Names.Original, opType) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// AsyncOperations returns the operations of the CRD that complete
+// asynchronously, in Create, Update, Delete order, with their SDK operations
+// and members resolved. An error is returned if an operation or member does
+// not exist in the SDK or if the Status field storing the operation ID is not
+// a string field of the CRD.
+func (r *CRD) AsyncOperations() ([]*AsyncOperation, error) {
+	res := []*AsyncOperation{}
+	for _, opType := range asyncOperationTypes {
+		asyncCfg := r.cfg.GetAsyncOperation(r.Names.Original, opType)
+		if asyncCfg == nil {
+			continue
+		}
+		asyncOp, err := r.newAsyncOperation(opType, asyncCfg)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"resource %q: async_operations.%s: %w",
+				r.Names.Original, opType, err,
+			)
+		}
+		res = append(res, asyncOp)
+	}
+	return res, nil
+}
+
+// newAsyncOperation returns the AsyncOperation of the supplied type for the
+// supplied config, resolving the SDK operations and members it names.
+func (r *CRD) newAsyncOperation(
+	opType string,
+	asyncCfg *ackgenconfig.AsyncOperationConfig,
+) (*AsyncOperation, error) {
+	asyncOp := &AsyncOperation{
+		OpType:        opType,
+		IDPaths:       map[string][]string{},
+		CompletedWhen: asyncCfg.CompletedWhen,
+		FailedWhen:    asyncCfg.FailedWhen,
+	}
+	if opType == "Update" && r.HasUpdateOperations() {
+		updateOps, err := r.UpdateOperations()
+		if err != nil {
+			return nil, err
+		}
+		// Only some of the update operations may complete asynchronously.
+		for _, updateOp := range updateOps {
+			op := updateOp.Operation
+			if op.OutputRef.Shape == nil {
+				continue
+			}
+			idPath, err := asyncOperationIDPath(op, asyncCfg.IDPath)
+			if err != nil {
+				continue
+			}
+			asyncOp.Operations = append(asyncOp.Operations, op)
+			asyncOp.IDPaths[op.ExportedName] = idPath
+		}
+		if len(asyncOp.Operations) == 0 {
+			return nil, fmt.Errorf(
+				"no update operation has a string member %q in its Output shape",
+				asyncCfg.IDPath,
+			)
+		}
+	} else {
+		var op *awssdkmodel.Operation
+		switch opType {
+		case "Create":
+			op = r.Ops.Create
+		case "Update":
+			op = r.Ops.Update
+		case "Delete":
+			op = r.Ops.Delete
+		}
+		if op == nil || op.OutputRef.Shape == nil {
+			return nil, fmt.Errorf("resource has no %s operation with an Output shape", opType)
+		}
+		idPath, err := asyncOperationIDPath(op, asyncCfg.IDPath)
+		if err != nil {
+			return nil, err
+		}
+		asyncOp.Operations = []*awssdkmodel.Operation{op}
+		asyncOp.IDPaths[op.ExportedName] = idPath
+	}
+
+	statusField, err := r.stringStatusField(asyncCfg.StatusField)
+	if err != nil {
+		return nil, fmt.Errorf("status_field: %w", err)
+	}
+	asyncOp.StatusField = statusField
+
+	statusOp, found := r.sdkAPI.API.Operations[asyncCfg.StatusOperation]
+	if !found {
+		return nil, fmt.Errorf(
+			"status_operation %q not found in SDK", asyncCfg.StatusOperation,
+		)
+	}
+	if statusOp.InputRef.Shape == nil || statusOp.OutputRef.Shape == nil {
+		return nil, fmt.Errorf(
+			"status_operation %q must have Input and Output shapes",
+			asyncCfg.StatusOperation,
+		)
+	}
+	asyncOp.StatusOp = statusOp
+	statusIDMember, statusIDRef, err := shapeMember(
+		statusOp.InputRef.Shape, asyncCfg.StatusIDMember,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("operation %q: %w", statusOp.ExportedName, err)
+	}
+	if statusIDRef.Shape.Type != "string" || statusIDRef.Shape.IsEnum() {
+		return nil, fmt.Errorf(
+			"operation %q: member %q must be a string",
+			statusOp.ExportedName, statusIDMember,
+		)
+	}
+	asyncOp.StatusIDMember = statusIDMember
+	asyncOp.StatusPath, asyncOp.StatusShapeRef, err = shapeMemberPath(
+		statusOp.OutputRef.Shape, asyncCfg.StatusPath,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("operation %q: %w", statusOp.ExportedName, err)
+	}
+	if asyncOp.StatusShapeRef.Shape.Type != "string" {
+		return nil, fmt.Errorf(
+			"operation %q: member %q must be a string",
+			statusOp.ExportedName, asyncCfg.StatusPath,
+		)
+	}
+	return asyncOp, nil
+}
+
+// asyncOperationIDPath returns the exact names of the members, from the
+// supplied operation's Output shape down, of the supplied dot-notation path to
+// the operation ID, which must be a string.
+func asyncOperationIDPath(
+	op *awssdkmodel.Operation,
+	path string,
+) ([]string, error) {
+	idPath, idRef, err := shapeMemberPath(op.OutputRef.Shape, path)
+	if err != nil {
+		return nil, fmt.Errorf("operation %q: %w", op.ExportedName, err)
+	}
+	if idRef.Shape.Type != "string" || idRef.Shape.IsEnum() {
+		return nil, fmt.Errorf(
+			"operation %q: member %q must be a string", op.ExportedName, path,
+		)
+	}
+	return idPath, nil
+}
+
+// shapeMemberPath returns the exact names of the members of the supplied
+// shape, and of its nested structures, matching case-insensitively the
+// elements of the supplied dot-notation path, along with the ShapeRef of the
+// last member.
+func shapeMemberPath(
+	shape *awssdkmodel.Shape,
+	path string,
+) ([]string, *awssdkmodel.ShapeRef, error) {
+	var memberNames []string
+	var memberRef *awssdkmodel.ShapeRef
+	for _, elem := range strings.Split(path, ".") {
+		if shape == nil || shape.Type != "structure" {
+			return nil, nil, fmt.Errorf(
+				"path %q does not select a member of nested structures", path,
+			)
+		}
+		memberName, ref, err := shapeMember(shape, elem)
+		if err != nil {
+			return nil, nil, err
+		}
+		memberNames = append(memberNames, memberName)
+		memberRef = ref
+		shape = ref.Shape
+	}
+	return memberNames, memberRef, nil
+}
@@ -26,6 +26,16 @@ type Attr struct {
 	Shape       *awssdkmodel.Shape
 	GoTag       string
 	IsImmutable bool
+	// ListType is the value of the `+listType` kubebuilder marker emitted
+	// for the attribute, if any.
+	ListType string
+	// ListMapKey is the value of the `+listMapKey` kubebuilder marker
+	// emitted for the attribute, if any.
+	ListMapKey string
+	// IsRequired is true if the attribute is marked required in the CRD
+	// schema, like the key member of the elements of a `+listType=map` list,
+	// which the apiserver requires to be set.
+	IsRequired bool
 }
 
 func NewAttr(
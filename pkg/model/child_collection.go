@@ -0,0 +1,182 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws-controllers-k8s/code-generator/pkg/api"
+)
+
+// ChildCollection describes a top-level Spec list field whose elements are
+// added to and removed from the resource by dedicated SDK operations. See
+// ackgenconfig.ChildCollectionConfig.
+type ChildCollection struct {
+	// Field is the Spec list field holding the children
+	Field *Field
+	// AddOp is the SDK operation adding children to the resource
+	AddOp *awssdkmodel.Operation
+	// AddOpType is either OpTypeAddChild or OpTypeAddChildren
+	AddOpType OpType
+	// RemoveOp is the SDK operation removing children from the resource
+	RemoveOp *awssdkmodel.Operation
+	// RemoveOpType is either OpTypeRemoveChild or OpTypeRemoveChildren
+	RemoveOpType OpType
+	// InputMember is the name of the member of the add and remove operations'
+	// Input shapes that receives the children
+	InputMember string
+	// BatchSize is the maximum number of children passed to a single call of
+	// an OpTypeAddChildren or OpTypeRemoveChildren operation. Zero means all
+	// the children are passed in a single call.
+	BatchSize int
+	// ReadOp is the SDK operation returning the children of the resource, if
+	// any
+	ReadOp *awssdkmodel.Operation
+	// ReadOutputPath is the path, within the ReadOp's Output shape, of the
+	// list of children
+	ReadOutputPath string
+}
+
+// IsBatched returns true if the add and remove operations receive a list of
+// children, meaning they are OpTypeAddChildren and OpTypeRemoveChildren
+// operations.
+func (cc *ChildCollection) IsBatched() bool {
+	return cc.AddOpType == OpTypeAddChildren
+}
+
+// Operations returns the add and remove operations of the child collection.
+func (cc *ChildCollection) Operations() []*awssdkmodel.Operation {
+	return []*awssdkmodel.Operation{cc.AddOp, cc.RemoveOp}
+}
+
+// PayloadMethodName returns the name of the generated resource manager method
+// building the Input shape of the supplied add, remove or read operation. The
+// name includes the child collection's field so that it does not collide with
+// the methods of another child collection calling the same operation or with
+// the methods built for the resource's own operations.
+func (cc *ChildCollection) PayloadMethodName(op *awssdkmodel.Operation) string {
+	return "newChild" + cc.Field.Names.Camel + op.ExportedName + "Payload"
+}
+
+// ChildCollectionFields returns the CRD's top-level Spec fields that are
+// configured with `child_collection`, in a deterministic order.
+func (r *CRD) ChildCollectionFields() []*Field {
+	res := []*Field{}
+	for _, fieldName := range r.SpecFieldNames() {
+		f := r.SpecFields[fieldName]
+		if f.HasChildCollection() {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// ChildCollections returns the child collections of the CRD, in a
+// deterministic order, with their SDK operations resolved. An error is
+// returned if an operation does not exist in the SDK or if the operations'
+// Input shapes are not compatible with the field.
+func (r *CRD) ChildCollections() ([]*ChildCollection, error) {
+	res := []*ChildCollection{}
+	for _, f := range r.ChildCollectionFields() {
+		cc, err := r.newChildCollection(f)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"resource %q, field %q: child_collection: %w",
+				r.Names.Original, f.Names.Camel, err,
+			)
+		}
+		res = append(res, cc)
+	}
+	return res, nil
+}
+
+// newChildCollection returns the ChildCollection for the supplied field,
+// resolving the SDK operations named in the field's config.
+func (r *CRD) newChildCollection(f *Field) (*ChildCollection, error) {
+	ccCfg := f.FieldConfig.ChildCollection
+	if f.ShapeRef == nil || f.ShapeRef.Shape == nil || f.ShapeRef.Shape.Type != "list" {
+		return nil, fmt.Errorf("field must be a list")
+	}
+	inputMember := ccCfg.InputMember
+	if inputMember == "" {
+		inputMember = f.Names.Original
+	}
+	cc := &ChildCollection{
+		Field:          f,
+		BatchSize:      ccCfg.BatchSize,
+		ReadOutputPath: ccCfg.ReadOutputPath,
+	}
+
+	var err error
+	var addBatched, removeBatched bool
+	cc.AddOp, cc.InputMember, addBatched, err = r.childCollectionOp(ccCfg.AddOperation, inputMember)
+	if err != nil {
+		return nil, err
+	}
+	cc.RemoveOp, _, removeBatched, err = r.childCollectionOp(ccCfg.RemoveOperation, inputMember)
+	if err != nil {
+		return nil, err
+	}
+	if addBatched != removeBatched {
+		return nil, fmt.Errorf(
+			"member %q must either be a list in the Input shapes of both %q and %q or in neither",
+			inputMember, cc.AddOp.ExportedName, cc.RemoveOp.ExportedName,
+		)
+	}
+	if addBatched {
+		cc.AddOpType, cc.RemoveOpType = OpTypeAddChildren, OpTypeRemoveChildren
+	} else {
+		cc.AddOpType, cc.RemoveOpType = OpTypeAddChild, OpTypeRemoveChild
+		if f.ShapeRef.Shape.MemberRef.Shape.Type != "string" {
+			return nil, fmt.Errorf(
+				"operations receiving a single child are only supported for lists of strings",
+			)
+		}
+	}
+
+	if ccCfg.ReadOperation != "" {
+		readOp, found := r.sdkAPI.API.Operations[ccCfg.ReadOperation]
+		if !found {
+			return nil, fmt.Errorf("operation %q not found in SDK", ccCfg.ReadOperation)
+		}
+		cc.ReadOp = readOp
+	}
+	return cc, nil
+}
+
+// childCollectionOp returns the named SDK operation, the exact name of the
+// supplied member of its Input shape, matched case-insensitively, and whether
+// that member is a list.
+func (r *CRD) childCollectionOp(
+	opName string,
+	inputMember string,
+) (*awssdkmodel.Operation, string, bool, error) {
+	op, found := r.sdkAPI.API.Operations[opName]
+	if !found {
+		return nil, "", false, fmt.Errorf("operation %q not found in SDK", opName)
+	}
+	if op.InputRef.Shape == nil {
+		return nil, "", false, fmt.Errorf("operation %q has no Input shape", opName)
+	}
+	for _, memberName := range op.InputRef.Shape.MemberNames() {
+		if strings.EqualFold(memberName, inputMember) {
+			memberRef := op.InputRef.Shape.MemberRefs[memberName]
+			return op, memberName, memberRef.Shape.Type == "list", nil
+		}
+	}
+	return nil, "", false, fmt.Errorf(
+		"member %q not found in Input shape of operation %q", inputMember, opName,
+	)
+}
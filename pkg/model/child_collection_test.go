@@ -0,0 +1,83 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+// TestChildCollections_IAM_Role verifies that the model resolves a child
+// collection's operations and that its field is synced like a custom_sync
+// field.
+func TestChildCollections_IAM_Role(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "iam",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-child-collections.yaml",
+		})
+
+	crds, err := g.GetCRDs()
+	require.NoError(err)
+
+	crd := getCRDByName("Role", crds)
+	require.NotNil(crd)
+
+	ccs, err := crd.ChildCollections()
+	require.NoError(err)
+	require.Len(ccs, 1)
+
+	cc := ccs[0]
+	assert.Equal("Policies", cc.Field.Names.Camel)
+	assert.Equal("AttachRolePolicy", cc.AddOp.ExportedName)
+	assert.Equal("DetachRolePolicy", cc.RemoveOp.ExportedName)
+	assert.Equal(model.OpTypeAddChild, cc.AddOpType)
+	assert.Equal(model.OpTypeRemoveChild, cc.RemoveOpType)
+	assert.False(cc.IsBatched())
+	assert.Equal("PolicyArn", cc.InputMember)
+	require.NotNil(cc.ReadOp)
+	assert.Equal("ListAttachedRolePolicies", cc.ReadOp.ExportedName)
+
+	// Child collection fields are synced through the custom_sync dispatch.
+	policies := crd.SpecFields["Policies"]
+	require.NotNil(policies)
+	assert.True(policies.HasChildCollection())
+	assert.True(policies.HasCustomSync())
+	assert.Equal("syncPolicies", policies.CustomSyncMethodName())
+	assert.Len(crd.CustomSyncFields(), 1)
+}
+
+// TestChildCollectionsInvalid_InputMember rejects a child collection whose
+// input_member is not a member of the add and remove operations' Input shapes.
+func TestChildCollectionsInvalid_InputMember(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "iam",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-child-collections-bad-member.yaml",
+		})
+
+	_, err := g.GetCRDs()
+	require.Error(err)
+	assert.Contains(err.Error(), `resource "Role", field "Policies": child_collection`)
+	assert.Contains(err.Error(), `member "PolicyDocument" not found in Input shape of operation "AttachRolePolicy"`)
+}
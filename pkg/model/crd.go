@@ -37,6 +37,11 @@ type Ops struct {
 	Delete        *awssdkmodel.Operation
 	GetAttributes *awssdkmodel.Operation
 	SetAttributes *awssdkmodel.Operation
+	// Replace is the operation that both creates and updates the resource,
+	// for instance a `CreateOrUpdate*` operation. When the resource has no
+	// Create operation, Create (and Update, if the resource has no Update
+	// operation) is set to the Replace operation. See CRD.IsUpsert.
+	Replace *awssdkmodel.Operation
 }
 
 // IterOps returns a slice of Operations for a resource
@@ -60,6 +65,18 @@ func (ops Ops) IterOps() []*awssdkmodel.Operation {
 	return res
 }
 
+// UpdateOperation is one of several SDK operations that together update a
+// resource. See ackgenconfig.UpdateOperationConfig.Operations.
+type UpdateOperation struct {
+	// Operation is the SDK operation invoked to update the resource
+	Operation *awssdkmodel.Operation
+	// Names contains the names of the SDK operation, used to name the hook
+	// points of the operation, e.g. `sdk_update_put_bucket_policy_post_request`
+	Names names.Names
+	// FieldPaths are the Spec field paths owned by the operation
+	FieldPaths []string
+}
+
 // CRD describes a single top-level resource in an AWS service API
 type CRD struct {
 	sdkAPI *SDKAPI
@@ -91,6 +108,9 @@ type CRD struct {
 	// ShortNames represent the CRD list of aliases. Short names allow shorter
 	// strings to match a CR on the CLI.
 	ShortNames []string
+	// serviceAPIGroup is the Kubernetes API group of the service, which CRDs
+	// are placed in unless configured with `api_group`
+	serviceAPIGroup string
 }
 
 // Config returns a pointer to the generator config
@@ -153,6 +173,120 @@ func (r *CRD) Note() string {
 	return *resourceConfig.Note
 }
 
+// IsUpsert returns true if the resource is created by an operation that both
+// creates and updates the resource, for instance a `CreateOrUpdate*` or, when
+// `put_operation_is_replace` is configured for the resource, a `Put*`
+// operation.
+func (r *CRD) IsUpsert() bool {
+	return r.Ops.Replace != nil && r.Ops.Create == r.Ops.Replace
+}
+
+// IsObserveOnly returns true if the resource is configured to be observe-only,
+// meaning the controller only reads the AWS resource and never creates,
+// updates or deletes it.
+func (r *CRD) IsObserveOnly() bool {
+	return r.cfg.ResourceIsObserveOnly(r.Names.Original)
+}
+
+// IsSingleton returns true if the resource is configured to be a singleton,
+// like account settings, that always exists and is created and updated by its
+// write operation. See ackgenconfig.SingletonConfig.
+func (r *CRD) IsSingleton() bool {
+	return r.cfg.GetSingletonConfig(r.Names.Original) != nil
+}
+
+// APIGroup returns the Kubernetes API group of the CRD, e.g.
+// "ec2.services.k8s.aws" or, for a CRD configured with `api_group`, the
+// overridden API group
+func (r *CRD) APIGroup() string {
+	if r.APIGroupPackage() != "" {
+		return r.cfg.GetResourceAPIGroup(r.Names.Original)
+	}
+	return r.serviceAPIGroup
+}
+
+// APIGroupPackage returns the name of the Go package, relative to the
+// `apis/{version}` package, containing the types of a CRD placed in a
+// non-default API group. An empty string is returned for CRDs in the
+// service's API group.
+func (r *CRD) APIGroupPackage() string {
+	return r.apiGroupPackageOf(r.Names.Original)
+}
+
+// apiGroupPackageOf returns the name of the Go package containing the types
+// of the supplied resource of the service, or an empty string if the
+// resource is in the service's API group
+func (r *CRD) apiGroupPackageOf(resourceName string) string {
+	apiGroup := r.cfg.GetResourceAPIGroup(resourceName)
+	if apiGroup == "" || apiGroup == r.serviceAPIGroup {
+		return ""
+	}
+	return apiGroupPackageName(apiGroup)
+}
+
+// HasNamesOverride returns true if the Kind or plural names of the CRD are
+// overridden in the generator config
+func (r *CRD) HasNamesOverride() bool {
+	return r.cfg.GetResourceNamesConfig(r.Names.Original) != nil
+}
+
+// Singular returns the lowercased singular name of the CRD
+func (r *CRD) Singular() string {
+	if namesConfig := r.cfg.GetResourceNamesConfig(r.Names.Original); namesConfig != nil {
+		if namesConfig.Singular != "" {
+			return namesConfig.Singular
+		}
+	}
+	return strings.ToLower(r.Kind)
+}
+
+// IsClusterScoped returns true if the CRD is cluster-scoped instead of
+// namespaced
+func (r *CRD) IsClusterScoped() bool {
+	return r.cfg.ResourceIsClusterScoped(r.Names.Original)
+}
+
+// ObserveOperation returns the operation whose Input shape identifies and
+// whose Output shape describes an observe-only resource: the ReadOne
+// operation if there is one, otherwise the GetAttributes or ReadMany
+// operation. It returns nil for resources that are not observe-only.
+func (r *CRD) ObserveOperation() *awssdkmodel.Operation {
+	if !r.IsObserveOnly() {
+		return nil
+	}
+	switch {
+	case r.Ops.ReadOne != nil:
+		return r.Ops.ReadOne
+	case r.Ops.GetAttributes != nil:
+		return r.Ops.GetAttributes
+	default:
+		return r.Ops.ReadMany
+	}
+}
+
+// UpdatesByReplace returns true if the resource is an upsert resource that is
+// also updated by its Replace operation, meaning sdkCreate and sdkUpdate share
+// the same request construction.
+func (r *CRD) UpdatesByReplace() bool {
+	return r.IsUpsert() && r.Ops.Update == r.Ops.Replace
+}
+
+// ReplaceReadsAfterWrite returns true if the resource's Replace operation
+// returns nothing that could be set on the resource, so the resource is read
+// back after the operation to populate its Status fields. The resource must
+// have an operation to read it back with.
+func (r *CRD) ReplaceReadsAfterWrite() bool {
+	if r.Ops.Replace == nil {
+		return false
+	}
+	if r.Ops.ReadOne == nil && r.Ops.GetAttributes == nil &&
+		r.Ops.ReadMany == nil && r.CustomFindMethodName() == "" {
+		return false
+	}
+	outputShape, err := r.GetOutputShape(r.Ops.Replace)
+	return err == nil && (outputShape == nil || len(outputShape.MemberRefs) == 0)
+}
+
 // HasShapeAsMember returns true if the supplied Shape name appears in *any*
 // payload shape of *any* Operation for the resource. It recurses down through
 // the resource's Operation Input and Output shapes and their member shapes
@@ -320,7 +454,8 @@ func (r *CRD) CompareIgnoredFields() []string {
 }
 
 // CustomSyncFields returns the CRD's top-level Spec fields that are configured
-// with `custom_sync`, in a deterministic order.
+// with `custom_sync` or `child_collection`, along with the tag field of a CRD
+// configured with `tags.sync_operations`, in a deterministic order.
 //
 // Such fields are not reconciled by the resource's Update operation. Instead,
 // the code generator emits boilerplate into sdkUpdate that invokes a
@@ -336,7 +471,7 @@ func (r *CRD) CustomSyncFields() []*Field {
 	// SpecFieldNames is sorted, which keeps generated output stable.
 	for _, fieldName := range r.SpecFieldNames() {
 		f := r.SpecFields[fieldName]
-		if f.FieldConfig != nil && f.FieldConfig.CustomSync != nil {
+		if f.HasCustomSync() {
 			res = append(res, f)
 		}
 	}
@@ -464,31 +599,141 @@ func (r *CRD) IsARNPrimaryKey() bool {
 	return resGenConfig.IsARNPrimaryKey
 }
 
+// ARNTemplate returns the template of the ARN of the resource, or an empty
+// string if the ARN is not synthesized from the resource's metadata and
+// fields.
+func (r *CRD) ARNTemplate() string {
+	resGenConfig := r.cfg.GetResourceConfig(r.Names.Original)
+	if resGenConfig == nil {
+		return ""
+	}
+	return resGenConfig.ARNTemplate
+}
+
+// ARNTemplateField returns the string field of the resource named by the
+// supplied `Spec.` or `Status.` placeholder of the resource's ARN template.
+// Field paths are matched exactly or, failing that, case-insensitively, e.g.
+// `APIMappingID` matches `ApiMappingId`. It returns an error if no field or
+// several fields match, or if the field is not a string field of the Spec or
+// Status named by the placeholder.
+func (r *CRD) ARNTemplateField(placeholder string) (*Field, error) {
+	prefix, fieldPath, _ := strings.Cut(placeholder, ".")
+	field, found := r.Fields[fieldPath]
+	if !found {
+		matches := []string{}
+		for path := range r.Fields {
+			if strings.EqualFold(path, fieldPath) {
+				matches = append(matches, path)
+			}
+		}
+		sort.Strings(matches)
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("placeholder %q: field %q not found", placeholder, fieldPath)
+		case 1:
+			field = r.Fields[matches[0]]
+		default:
+			return nil, fmt.Errorf(
+				"placeholder %q: field %q is ambiguous, matches %s",
+				placeholder, fieldPath, strings.Join(matches, ", "),
+			)
+		}
+	}
+	topFieldName, _, _ := strings.Cut(field.Path, ".")
+	topField := r.Fields[topFieldName]
+	inSpec := false
+	if topField != nil {
+		_, inSpec = r.SpecFields[topField.Names.Original]
+	}
+	if inSpec != (prefix == "Spec") {
+		return nil, fmt.Errorf(
+			"placeholder %q: field %q is not a %s field", placeholder, field.Path, prefix,
+		)
+	}
+	if field.GoType != "*string" {
+		return nil, fmt.Errorf(
+			"placeholder %q: field %q must be a string field", placeholder, field.Path,
+		)
+	}
+	return field, nil
+}
+
 // GetPrimaryKeyField returns the field designated as the primary key, nil if
 // none are specified or an error if multiple are designated.
 func (r *CRD) GetPrimaryKeyField() (*Field, error) {
+	primaryFields, err := r.GetPrimaryKeyFields()
+	if err != nil {
+		return nil, err
+	}
+	switch len(primaryFields) {
+	case 0:
+		return nil, nil
+	case 1:
+		return primaryFields[0], nil
+	default:
+		return nil, fmt.Errorf("multiple fields are marked with is_primary_key")
+	}
+}
+
+// GetPrimaryKeyFields returns the fields designated as the primary key, nil
+// if none are specified. When multiple fields are designated, they form a
+// composite primary key and are returned in the order of their
+// `primary_key_position`. An error is returned if the positions of the fields
+// of a composite primary key are not 1 to N or if one of them is not a string
+// field.
+func (r *CRD) GetPrimaryKeyFields() ([]*Field, error) {
 	fConfigs := r.cfg.GetFieldConfigs(r.Names.Original)
 
-	var primaryField *Field
+	primaryFields := []*Field{}
+	positions := map[*Field]int{}
 	for fieldName, fieldConfig := range fConfigs {
 		if !fieldConfig.IsPrimaryKey {
 			continue
 		}
-
-		// Multiple primary fields
-		if primaryField != nil {
-			return nil, fmt.Errorf("multiple fields are marked with is_primary_key")
-		}
-
 		fieldNames := names.New(fieldName)
 		fPath := fieldNames.Camel
-		var found bool
-		primaryField, found = r.Fields[fPath]
+		primaryField, found := r.Fields[fPath]
 		if !found {
 			return nil, fmt.Errorf("could not find field with path %s for primary key %s", fPath, fieldName)
 		}
+		primaryFields = append(primaryFields, primaryField)
+		positions[primaryField] = fieldConfig.PrimaryKeyPosition
+	}
+	if len(primaryFields) <= 1 {
+		if len(primaryFields) == 0 {
+			return nil, nil
+		}
+		return primaryFields, nil
+	}
+
+	sort.Slice(primaryFields, func(i, j int) bool {
+		return positions[primaryFields[i]] < positions[primaryFields[j]]
+	})
+	for i, primaryField := range primaryFields {
+		if positions[primaryField] != i+1 {
+			return nil, fmt.Errorf(
+				"resource %q: fields of the composite primary key must have a primary_key_position from 1 to %d",
+				r.Names.Original, len(primaryFields),
+			)
+		}
+		if primaryField.GoType != "*string" {
+			return nil, fmt.Errorf(
+				"resource %q: composite primary key field %q must be a string field",
+				r.Names.Original, primaryField.Path,
+			)
+		}
 	}
-	return primaryField, nil
+	return primaryFields, nil
+}
+
+// PrimaryKeySeparator returns the separator of the values of the fields of
+// the composite primary key in the identifier of the resource.
+func (r *CRD) PrimaryKeySeparator() string {
+	rConfig := r.cfg.GetResourceConfig(r.Names.Original)
+	if rConfig == nil || rConfig.PrimaryKeySeparator == "" {
+		return "/"
+	}
+	return rConfig.PrimaryKeySeparator
 }
 
 // GetMatchingInputShapeFieldName returns the name of the field in the Input shape.
@@ -681,7 +926,9 @@ func (r *CRD) SpecIdentifierField() *string {
 	rConfig := r.cfg.GetResourceConfig(r.Names.Original)
 	if rConfig != nil {
 		for fName, fConfig := range rConfig.Fields {
-			if fConfig.IsPrimaryKey {
+			// The first field of a composite primary key stands for the
+			// resource's name
+			if fConfig.IsPrimaryKey && fConfig.PrimaryKeyPosition <= 1 {
 				return &fName
 			}
 		}
@@ -730,6 +977,9 @@ func (r *CRD) addAdditionalPrinterColumns(additionalColumns []*ackgenconfig.Addi
 		printerColumn := &PrinterColumn{}
 		printerColumn.Name = additionalColumn.Name
 		printerColumn.JSONPath = additionalColumn.JSONPath
+		if additionalColumn.Path != "" {
+			printerColumn.JSONPath = r.printerColumnJSONPath(additionalColumn.Path)
+		}
 		printerColumn.Type = additionalColumn.Type
 		printerColumn.Priority = additionalColumn.Priority
 		printerColumn.Index = additionalColumn.Index
@@ -890,6 +1140,65 @@ func (r *CRD) ReconcileRequeuOnSuccessSeconds() int {
 	return r.cfg.GetReconcileRequeueOnSuccessSeconds(r.Names.Original)
 }
 
+// ReconcileRequeueWhen returns the rules mapping the values of the custom
+// resource's fields to the delay after which to requeue it
+func (r *CRD) ReconcileRequeueWhen() []ackgenconfig.RequeueRule {
+	return r.cfg.GetReconcileRequeueWhen(r.Names.Original)
+}
+
+// ReadAfterCreateGraceSeconds returns the number of seconds after creation
+// during which a custom resource that cannot be found is requeued instead of
+// being created again
+func (r *CRD) ReadAfterCreateGraceSeconds() int {
+	return r.cfg.GetReconcileReadAfterCreateGraceSeconds(r.Names.Original)
+}
+
+// ReadAfterCreateGraceStatusField returns the Status field storing the time of
+// creation of the custom resource, or nil if the CRD has no read-after-create
+// grace window. An error is returned if the configured field is not a string
+// Status field of the CRD.
+func (r *CRD) ReadAfterCreateGraceStatusField() (*Field, error) {
+	if r.ReadAfterCreateGraceSeconds() <= 0 {
+		return nil, nil
+	}
+	name := r.cfg.GetReconcileReadAfterCreateGraceStatusField(r.Names.Original)
+	f, err := r.stringStatusField(name)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"resource %q: reconcile.read_after_create_grace_status_field: %w",
+			r.Names.Original, err,
+		)
+	}
+	return f, nil
+}
+
+// stringStatusField returns the string Status field of the CRD with the
+// supplied original or Go name, compared case-insensitively, or an error if
+// there is no such field or if it is not a string field.
+func (r *CRD) stringStatusField(name string) (*Field, error) {
+	var field *Field
+	for _, f := range r.StatusFields {
+		if strings.EqualFold(f.Names.Original, name) ||
+			strings.EqualFold(f.Names.Camel, name) {
+			field = f
+			break
+		}
+	}
+	if field == nil {
+		return nil, fmt.Errorf("%q is not a Status field", name)
+	}
+	if field.GoType != "*string" {
+		return nil, fmt.Errorf("%q must be a string field", name)
+	}
+	return field, nil
+}
+
+// ReadAfterCreateGraceErrorCodes returns the additional error codes treated
+// like NotFound during the read-after-create grace window
+func (r *CRD) ReadAfterCreateGraceErrorCodes() []string {
+	return r.cfg.GetReconcileReadAfterCreateGraceErrorCodes(r.Names.Original)
+}
+
 // CustomUpdateMethodName returns the name of the custom resourceManager method
 // for updating the resource state, if any has been specified in the generator
 // config
@@ -897,6 +1206,75 @@ func (r *CRD) CustomUpdateMethodName() string {
 	return r.cfg.GetCustomUpdateMethodName(r.Names.Original)
 }
 
+// HasUpdateOperations returns true if the resource is updated by several SDK
+// operations configured in `update_operation.operations`.
+func (r *CRD) HasUpdateOperations() bool {
+	return len(r.cfg.GetUpdateOperations(r.Names.Original)) > 0
+}
+
+// UpdateOperations returns the SDK operations, in the configured order, that
+// together update the resource. An error is returned if an operation does not
+// exist in the SDK, if a field path is not a Spec field of the resource, or if
+// a mutable Spec field is not owned by any of the operations, since changes to
+// it would never be sent to AWS.
+func (r *CRD) UpdateOperations() ([]*UpdateOperation, error) {
+	opCfgs := r.cfg.GetUpdateOperations(r.Names.Original)
+	if len(opCfgs) == 0 {
+		return nil, nil
+	}
+	res := make([]*UpdateOperation, 0, len(opCfgs))
+	owned := map[string]bool{}
+	for _, opCfg := range opCfgs {
+		if opCfg == nil {
+			continue
+		}
+		op, found := r.sdkAPI.API.Operations[opCfg.Name]
+		if !found {
+			return nil, fmt.Errorf(
+				"resource %q: update operation %q not found in SDK",
+				r.Names.Original, opCfg.Name,
+			)
+		}
+		for _, fieldPath := range opCfg.Fields {
+			specFieldName := strings.SplitN(fieldPath, ".", 2)[0]
+			_, found := r.Fields[fieldPath]
+			if _, inSpec := r.SpecFields[specFieldName]; !found || !inSpec {
+				return nil, fmt.Errorf(
+					"resource %q: update operation %q: Spec field %q not found",
+					r.Names.Original, opCfg.Name, fieldPath,
+				)
+			}
+			owned[specFieldName] = true
+		}
+		res = append(res, &UpdateOperation{
+			Operation:  op,
+			Names:      names.New(op.ExportedName),
+			FieldPaths: opCfg.Fields,
+		})
+	}
+	unowned := []string{}
+	for _, fieldName := range r.SpecFieldNames() {
+		field := r.SpecFields[fieldName]
+		if owned[fieldName] || field.IsImmutable() || field.IsReference() ||
+			field.HasCustomSync() {
+			continue
+		}
+		if field.FieldConfig != nil && field.FieldConfig.Compare != nil &&
+			field.FieldConfig.Compare.IsIgnored {
+			continue
+		}
+		unowned = append(unowned, fieldName)
+	}
+	if len(unowned) > 0 {
+		return nil, fmt.Errorf(
+			"resource %q: Spec fields %s are not owned by any update operation; "+
+				"add them to the fields of an operation or mark them is_immutable",
+			r.Names.Original, strings.Join(unowned, ", "),
+		)
+	}
+	return res, nil
+}
+
 func (r *CRD) CustomFindMethodName() string {
 	return r.cfg.GetCustomFindMethodName(r.Names.Original)
 }
@@ -905,6 +1283,19 @@ func (r *CRD) CustomDeleteMethodName() string {
 	return r.cfg.GetCustomDeleteMethodName(r.Names.Original)
 }
 
+// PreDeleteUpdates returns the values, keyed by Spec field name, set through
+// the update operation of the resource before it is deleted
+func (r *CRD) PreDeleteUpdates() map[string]string {
+	return r.cfg.GetPreDeleteUpdates(r.Names.Original)
+}
+
+// DeleteInputFields returns the instructions, keyed by member name, for
+// setting members of the Delete operation's Input shape that are not fields
+// of the resource
+func (r *CRD) DeleteInputFields() map[string]*ackgenconfig.DeleteInputFieldConfig {
+	return r.cfg.GetDeleteInputFields(r.Names.Original)
+}
+
 // ListOpMatchFieldNames returns a slice of strings representing the field
 // names in the List operation's Output shape's element Shape that we should
 // check a corresponding value in the target Spec exists.
@@ -1020,6 +1411,28 @@ func (r *CRD) HasReferenceFields() bool {
 	return false
 }
 
+// HasKubernetesObjectReferenceFields returns true if any of the fields in CRD
+// references a Kubernetes object that is not an ACK resource
+func (r *CRD) HasKubernetesObjectReferenceFields() bool {
+	for _, field := range r.Fields {
+		if field.ReferencesKubernetesObject() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasACKResourceReferenceFields returns true if any of the fields in CRD
+// references an ACK resource
+func (r *CRD) HasACKResourceReferenceFields() bool {
+	for _, field := range r.Fields {
+		if field.HasReference() && !field.ReferencesKubernetesObject() {
+			return true
+		}
+	}
+	return false
+}
+
 // ReferencedServiceNames returns the set of service names for ACK controllers
 // whose resources are referenced inside the CRD. The service name is
 // the go package name for the AWS service inside aws-sdk-go.
@@ -1043,6 +1456,46 @@ func (r *CRD) ReferencedServiceNames() (serviceNames []string) {
 	return serviceNames
 }
 
+// APIGroupImport describes the import of the Go package containing the types
+// of the resources in one of the service's API groups
+type APIGroupImport struct {
+	// Alias is the import alias of the package
+	Alias string
+	// Package is the name of the package relative to the `apis/{version}`
+	// package, or an empty string for the service's API group
+	Package string
+}
+
+// ReferencedAPIGroupImports returns the imports of the Go packages containing
+// the types of the resources of the same service, but in another API group
+// than the CRD, that are referenced inside the CRD
+func (r *CRD) ReferencedAPIGroupImports() []APIGroupImport {
+	imports := []APIGroupImport{}
+	seen := map[string]bool{}
+	for _, fieldName := range r.SortedFieldNames() {
+		field := r.Fields[fieldName]
+		if !field.HasReference() || field.FieldConfig.References.ServiceName != "" {
+			continue
+		}
+		if field.ReferencesKubernetesObject() {
+			continue
+		}
+		pkg := field.ReferencedAPIGroupPackage()
+		if pkg == r.APIGroupPackage() || seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+		imports = append(imports, APIGroupImport{
+			Alias:   field.ReferencedAPITypesAlias(),
+			Package: pkg,
+		})
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		return imports[i].Alias < imports[j].Alias
+	})
+	return imports
+}
+
 // SortedFieldNames returns the fieldNames of the CRD in a sorted
 // order.
 func (r *CRD) SortedFieldNames() []string {
@@ -1066,6 +1519,21 @@ func NewCRD(
 	pluralize := pluralize.NewClient()
 	kind := crdNames.Camel
 	plural := pluralize.Plural(kind)
+	if namesConfig := cfg.GetResourceNamesConfig(crdNames.Original); namesConfig != nil {
+		if namesConfig.Kind != "" {
+			// The Kind is also the name of the CRD's Go type and package, so
+			// all names but the original resource name (which the generator
+			// config is keyed by) follow the overridden Kind.
+			original := crdNames.Original
+			crdNames = names.New(namesConfig.Kind)
+			crdNames.Original = original
+			kind = crdNames.Camel
+			plural = pluralize.Plural(kind)
+		}
+		if namesConfig.Plural != "" {
+			plural = namesConfig.Plural
+		}
+	}
 	return &CRD{
 		sdkAPI:                   sdkAPI,
 		cfg:                      cfg,
@@ -1078,6 +1546,6 @@ func NewCRD(
 		SpecFields:               map[string]*Field{},
 		StatusFields:             map[string]*Field{},
 		Fields:                   map[string]*Field{},
-		ShortNames:               cfg.GetResourceShortNames(kind),
+		ShortNames:               cfg.GetResourceShortNames(crdNames.Original),
 	}
 }
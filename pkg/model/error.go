@@ -13,10 +13,202 @@
 
 package model
 
+import (
+	"sort"
+	"strings"
+
+	awssdkmodel "github.com/aws-controllers-k8s/code-generator/pkg/api"
+)
+
+// transientErrorNameParts are parts of exception names denoting errors that
+// may go away on their own, which are never inferred to be terminal even
+// when the API model classifies them as HTTP 400 client errors.
+var transientErrorNameParts = []string{
+	"NotFound",
+	"InUse",
+	"Conflict",
+	"Concurrent",
+	"InProgress",
+	"LimitExceeded",
+	"Unavailable",
+	"Timeout",
+}
+
+// throttlingErrorNameParts are parts of exception names denoting throttling
+// errors, for API models lacking the smithy.api#retryable trait on them.
+var throttlingErrorNameParts = []string{
+	"Throttl",
+	"TooManyRequests",
+}
+
 // TerminalExceptionCodes returns terminal exception codes as
-// []string for custom resource
+// []string for custom resource. The codes specified in generator config are
+// returned if any, otherwise the codes inferred from the API model when the
+// service or the resource opts in to the inference. See
+// InferredTerminalExceptionCodes.
 func (r *CRD) TerminalExceptionCodes() []string {
-	return r.cfg.GetTerminalExceptionCodes(r.Names.Original)
+	if codes := r.cfg.GetTerminalExceptionCodes(r.Names.Original); codes != nil {
+		return codes
+	}
+	if !r.cfg.ExceptionCodesAreInferred(r.Names.Original) {
+		return nil
+	}
+	return r.InferredTerminalExceptionCodes()
+}
+
+// RetryableExceptionCodes returns retryable exception codes as []string for
+// custom resource. The codes specified in generator config are returned if
+// any, otherwise the codes inferred from the API model when the service or the
+// resource opts in to the inference. See InferredRetryableExceptionCodes.
+func (r *CRD) RetryableExceptionCodes() []string {
+	if codes := r.cfg.GetRetryableExceptionCodes(r.Names.Original); codes != nil {
+		return codes
+	}
+	if !r.cfg.ExceptionCodesAreInferred(r.Names.Original) {
+		return nil
+	}
+	return r.InferredRetryableExceptionCodes()
+}
+
+// ExceptionShape returns the exception shape of the API model whose name or
+// awsQueryError code is the supplied exception code, or nil if there is no
+// such exception shape.
+func (r *CRD) ExceptionShape(code string) *awssdkmodel.Shape {
+	if r.sdkAPI == nil || r.sdkAPI.API == nil {
+		return nil
+	}
+	shapes := r.sdkAPI.API.Shapes
+	if shape, found := shapes[code]; found && shape.Exception {
+		return shape
+	}
+	// Query-compatible services return a code that differs from the name of
+	// the exception shape
+	shapeNames := make([]string, 0, len(shapes))
+	for shapeName := range shapes {
+		shapeNames = append(shapeNames, shapeName)
+	}
+	sort.Strings(shapeNames)
+	for _, shapeName := range shapeNames {
+		shape := shapes[shapeName]
+		if shape.Exception && shape.ErrorInfo.Code == code {
+			return shape
+		}
+	}
+	return nil
+}
+
+// InferredTerminalExceptionCodes returns the sorted codes of the resource's
+// operations' exceptions that the API model classifies as validation-style
+// client errors: HTTP 400 client errors that are neither retryable nor
+// throttling errors. Exceptions mapped to an HTTP status code in generator
+// config, like the resource's 404 exception, and exceptions whose names
+// denote a transient error (e.g. "ResourceInUseException") are excluded.
+func (r *CRD) InferredTerminalExceptionCodes() []string {
+	mapped := map[string]bool{}
+	if r.cfg != nil {
+		if resGenConfig, found := r.cfg.Resources[r.Names.Original]; found &&
+			resGenConfig.Exceptions != nil {
+			for _, excConfig := range resGenConfig.Exceptions.Errors {
+				mapped[excConfig.Code] = true
+			}
+		}
+	}
+	codes := []string{}
+	for _, errShape := range r.operationErrorShapes() {
+		code := exceptionCode(errShape)
+		if mapped[code] || isRetryableErrorShape(errShape) {
+			continue
+		}
+		if errShape.ErrorInfo.Fault != "client" ||
+			errShape.ErrorInfo.HTTPStatusCode != 400 {
+			continue
+		}
+		if nameContainsAny(code, transientErrorNameParts) {
+			continue
+		}
+		codes = append(codes, code)
+	}
+	return uniqueSorted(codes)
+}
+
+// InferredRetryableExceptionCodes returns the sorted codes of the resource's
+// operations' exceptions that the API model marks as retryable or throttling
+// errors, or that are HTTP 429 errors.
+func (r *CRD) InferredRetryableExceptionCodes() []string {
+	codes := []string{}
+	for _, errShape := range r.operationErrorShapes() {
+		if isRetryableErrorShape(errShape) {
+			codes = append(codes, exceptionCode(errShape))
+		}
+	}
+	return uniqueSorted(codes)
+}
+
+// operationErrorShapes returns the error shapes of the resource's operations
+func (r *CRD) operationErrorShapes() []*awssdkmodel.Shape {
+	ops := []*awssdkmodel.Operation{
+		r.Ops.Create,
+		r.Ops.ReadOne,
+		r.Ops.ReadMany,
+		r.Ops.Update,
+		r.Ops.Delete,
+		r.Ops.GetAttributes,
+		r.Ops.SetAttributes,
+		r.Ops.Replace,
+	}
+	res := []*awssdkmodel.Shape{}
+	for _, op := range ops {
+		if op == nil {
+			continue
+		}
+		for _, errShapeRef := range op.ErrorRefs {
+			if errShapeRef.Shape != nil {
+				res = append(res, errShapeRef.Shape)
+			}
+		}
+	}
+	return res
+}
+
+// isRetryableErrorShape returns true if the supplied error shape is retryable
+// or a throttling error
+func isRetryableErrorShape(errShape *awssdkmodel.Shape) bool {
+	return errShape.ErrorInfo.Retryable ||
+		errShape.ErrorInfo.Throttling ||
+		errShape.ErrorInfo.HTTPStatusCode == 429 ||
+		nameContainsAny(exceptionCode(errShape), throttlingErrorNameParts)
+}
+
+// exceptionCode returns the code of the supplied error shape, as returned by
+// the AWS API
+func exceptionCode(errShape *awssdkmodel.Shape) string {
+	if errShape.ErrorInfo.Code != "" {
+		return errShape.ErrorInfo.Code
+	}
+	return errShape.ShapeName
+}
+
+// nameContainsAny returns true if the supplied name contains any of the
+// supplied parts
+func nameContainsAny(name string, parts []string) bool {
+	for _, part := range parts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueSorted returns the sorted, deduplicated supplied strings
+func uniqueSorted(in []string) []string {
+	sort.Strings(in)
+	res := []string{}
+	for i, s := range in {
+		if i == 0 || s != in[i-1] {
+			res = append(res, s)
+		}
+	}
+	return res
 }
 
 // ExceptionCode returns the name of the resource's Exception code for the
@@ -0,0 +1,88 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+// TestExceptionCodes_Inferred verifies that the terminal and retryable
+// exception codes are inferred from the Smithy error traits of the resource's
+// operations' error shapes.
+func TestExceptionCodes_Inferred(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "mwaaserverless")
+
+	crds, err := g.GetCRDs()
+	require.NoError(err)
+
+	crd := getCRDByName("Workflow", crds)
+	require.NotNil(crd)
+
+	// Only ValidationException is a non-retryable HTTP 400 client error.
+	// ConflictException (409), AccessDeniedException (403) and
+	// ServiceQuotaExceededException (402) are not inferred to be terminal.
+	assert.Equal([]string{"ValidationException"}, crd.TerminalExceptionCodes())
+	// ThrottlingException is a throttling error and InternalServerException
+	// has the smithy.api#retryable trait.
+	assert.Equal(
+		[]string{"InternalServerException", "ThrottlingException"},
+		crd.RetryableExceptionCodes(),
+	)
+}
+
+// TestExceptionCodes_ConfigOverrides verifies that the exception codes
+// specified in generator config replace the inferred codes, and that the
+// inference is disabled unless the service opts in.
+func TestExceptionCodes_ConfigOverrides(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "mwaaserverless",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-exception-overrides.yaml",
+		})
+
+	crds, err := g.GetCRDs()
+	require.NoError(err)
+
+	crd := getCRDByName("Workflow", crds)
+	require.NotNil(crd)
+
+	// Exception codes are not inferred unless the service or the resource
+	// opts in.
+	assert.Empty(crd.TerminalExceptionCodes())
+	assert.Equal([]string{"ThrottlingException"}, crd.RetryableExceptionCodes())
+	// The inferred codes are still available to the templates.
+	assert.Equal([]string{"ValidationException"}, crd.InferredTerminalExceptionCodes())
+
+	g = testutil.NewModelForService(t, "elasticache")
+
+	crds, err = g.GetCRDs()
+	require.NoError(err)
+
+	crd = getCRDByName("ReplicationGroup", crds)
+	require.NotNil(crd)
+	assert.Equal(
+		crd.Config().GetTerminalExceptionCodes("ReplicationGroup"),
+		crd.TerminalExceptionCodes(),
+	)
+}
@@ -184,16 +184,25 @@ func (f *Field) IsRequired() bool {
 	if f.FieldConfig != nil && f.FieldConfig.IsRequired != nil {
 		return *f.FieldConfig.IsRequired
 	}
+	// Observe-only resources have no Create operation and their Spec fields
+	// come from the Input shape of the operation reading the resource.
+	op := f.CRD.Ops.Create
+	if op == nil {
+		op = f.CRD.ObserveOperation()
+	}
+	if op == nil || op.InputRef.Shape == nil {
+		return false
+	}
 	// We need to look up the original member name in the input struct
 	// otherwise renamed fields will not be discovered as required.
 	originalMember := f.CRD.Config().GetOriginalMemberName(
 		f.CRD.Names.Original,
-		f.CRD.Ops.Create.Name,
+		op.Name,
 		f.Names.Original,
 	)
 	return util.InStrings(
 		originalMember,
-		f.CRD.Ops.Create.InputRef.Shape.Required,
+		op.InputRef.Shape.Required,
 	)
 }
 
@@ -209,6 +218,42 @@ func (f *Field) IsImmutable() bool {
 	return false
 }
 
+// ListType returns the value of the `+listType` kubebuilder marker for the
+// field, derived from the field's `compare.list_semantics` config, or the
+// empty string if no marker should be emitted.
+//
+// Kubernetes only allows the "set" list type for lists of scalars, so no
+// marker is emitted for lists of structs, lists or maps with "set" semantics.
+func (f *Field) ListType() string {
+	if f.FieldConfig == nil || f.FieldConfig.Compare == nil {
+		return ""
+	}
+	if f.ShapeRef == nil || f.ShapeRef.Shape == nil || f.ShapeRef.Shape.Type != "list" {
+		return ""
+	}
+	switch f.FieldConfig.Compare.ListSemantics {
+	case ackgenconfig.ListSemanticsSet:
+		switch f.ShapeRef.Shape.MemberRef.Shape.Type {
+		case "structure", "union", "list", "map":
+			return ""
+		}
+		return "set"
+	case ackgenconfig.ListSemanticsKeyed:
+		return "map"
+	}
+	return ""
+}
+
+// ListMapKey returns the value of the `+listMapKey` kubebuilder marker for the
+// field, which is the JSON name of the `compare.key_member`, or the empty
+// string if the field does not have keyed list semantics.
+func (f *Field) ListMapKey() string {
+	if f.ListType() != "map" {
+		return ""
+	}
+	return names.New(f.FieldConfig.Compare.KeyMember).CamelLower
+}
+
 // GetSetterConfig returns the SetFieldConfig object associated with this field
 // and a supplied operation type, or nil if none exists.
 func (f *Field) GetSetterConfig(opType OpType) *ackgenconfig.SetFieldConfig {
@@ -281,25 +326,51 @@ func (f *Field) HasReference() bool {
 	return f.FieldConfig != nil && f.FieldConfig.References != nil
 }
 
-// IsReference returns true if the Field has type '*ackv1alpha1.AWSResourceReferenceWrapper'
-// or '[]*ackv1alpha1.AWSResourceReferenceWrapper'.
+// IsReference returns true if the Field has type '*ackv1alpha1.AWSResourceReferenceWrapper',
+// '[]*ackv1alpha1.AWSResourceReferenceWrapper' or
+// 'map[string]*ackv1alpha1.AWSResourceReferenceWrapper'.
 // These fields are not part of aws-sdk-go model and they are generated by
 // ACK code-generator to accept references of other resource(s).
 func (f *Field) IsReference() bool {
 	trimmedGoType := strings.TrimPrefix(f.GoType, "[]")
+	trimmedGoType = strings.TrimPrefix(trimmedGoType, "map[string]")
 	return trimmedGoType == "*ackv1alpha1.AWSResourceReferenceWrapper"
 }
 
 // HasCustomSync returns true if the field is configured with `custom_sync`,
 // meaning it is reconciled by a hand-written sync function rather than by the
-// resource's Update operation.
+// resource's Update operation, or with `child_collection`, or is the tag field
+// of a resource configured with `tags.sync_operations`, meaning it is
+// reconciled by a generated sync function.
 func (f *Field) HasCustomSync() bool {
-	return f.FieldConfig != nil && f.FieldConfig.CustomSync != nil
+	if f.HasTagSync() {
+		return true
+	}
+	return f.FieldConfig != nil &&
+		(f.FieldConfig.CustomSync != nil || f.FieldConfig.ChildCollection != nil)
+}
+
+// HasTagSync returns true if the field is the tag field of a resource
+// configured with `tags.sync_operations`, meaning the tags are added to and
+// removed from the resource by dedicated SDK operations.
+func (f *Field) HasTagSync() bool {
+	if f.CRD == nil || !f.CRD.HasTagSyncOperations() {
+		return false
+	}
+	tagField, err := f.CRD.GetTagField()
+	return err == nil && tagField == f
+}
+
+// HasChildCollection returns true if the field is configured with
+// `child_collection`, meaning its elements are added to and removed from the
+// resource by dedicated SDK operations.
+func (f *Field) HasChildCollection() bool {
+	return f.FieldConfig != nil && f.FieldConfig.ChildCollection != nil
 }
 
-// CustomSyncMethodName returns the name of the hand-written sync method that the
-// generated code calls for this field. It returns the empty string when the
-// field is not configured with `custom_sync`.
+// CustomSyncMethodName returns the name of the sync method that the generated
+// code calls for this field. It returns the empty string when the field is not
+// reconciled by a sync function. See HasCustomSync.
 //
 // The name is always "sync" followed by the field's camel-cased name, so a Tags
 // field yields "syncTags". It is deliberately not configurable, so that the sync
@@ -343,9 +414,10 @@ func (f *Field) GetReferenceFieldName() (names.Names, error) {
 		)
 	}
 	refName := refNamePrefix
-	// If the shape of corresponding field is a list, singularize the refNamePrefix
-	// and add Refs at the end
-	if f.ShapeRef != nil && f.ShapeRef.Shape != nil && f.ShapeRef.Shape.Type == "list" {
+	// If the shape of corresponding field is a list or a map, singularize the
+	// refNamePrefix and add Refs at the end
+	if f.ShapeRef != nil && f.ShapeRef.Shape != nil &&
+		(f.ShapeRef.Shape.Type == "list" || f.ShapeRef.Shape.Type == "map") {
 		refName = fmt.Sprintf("%sRefs", pluralize.NewClient().Singular(refNamePrefix))
 	} else {
 		refName = fmt.Sprintf("%sRef", refNamePrefix)
@@ -375,6 +447,9 @@ func (f *Field) ReferencedResourceNamePlural() string {
 	pluralize := pluralize.NewClient()
 	if f.FieldConfig != nil && f.FieldConfig.References != nil {
 		referencedResourceName = f.FieldConfig.References.Resource
+		if f.FieldConfig.References.IsKubernetesObjectReference() {
+			referencedResourceName = f.FieldConfig.References.Kind
+		}
 	}
 	if referencedResourceName != "" {
 		return pluralize.Plural(referencedResourceName)
@@ -382,6 +457,67 @@ func (f *Field) ReferencedResourceNamePlural() string {
 	return referencedResourceName
 }
 
+// ReferencesClusterScopedResource returns true if the field references a
+// resource in the same service that is configured with `scope: Cluster`.
+// Cluster-scoped resources do not live in any namespace, so references to
+// them are resolved without a namespace lookup.
+func (f *Field) ReferencesClusterScopedResource() bool {
+	if f.FieldConfig == nil || f.FieldConfig.References == nil {
+		return false
+	}
+	if f.ReferencesKubernetesObject() {
+		refCfg := f.FieldConfig.References
+		return refCfg.Group == "" && refCfg.Kind == "Namespace"
+	}
+	if f.ReferencedServiceName() != f.CRD.sdkAPI.API.PackageName() {
+		return false
+	}
+	resName := f.CRD.cfg.GetResourceNameForKind(f.FieldConfig.References.Resource)
+	return f.CRD.cfg.ResourceIsClusterScoped(resName)
+}
+
+// ReferencedAPIGroupPackage returns the name of the Go package, relative to
+// the `apis/{version}` package, containing the types of the resource of the
+// same service referenced by the field. An empty string is returned if the
+// referenced resource is in the service's API group or belongs to another
+// service.
+func (f *Field) ReferencedAPIGroupPackage() string {
+	if f.FieldConfig == nil || f.FieldConfig.References == nil {
+		return ""
+	}
+	if f.FieldConfig.References.ServiceName != "" || f.ReferencesKubernetesObject() {
+		return ""
+	}
+	resName := f.CRD.cfg.GetResourceNameForKind(f.FieldConfig.References.Resource)
+	return f.CRD.apiGroupPackageOf(resName)
+}
+
+// ReferencesKubernetesObject returns true if the field references a
+// Kubernetes object, identified by its group, version and kind, instead of an
+// ACK resource. The value of such a reference is extracted from the object
+// with a JSONPath.
+func (f *Field) ReferencesKubernetesObject() bool {
+	return f.FieldConfig != nil && f.FieldConfig.References.IsKubernetesObjectReference()
+}
+
+// ReferencedAPITypesAlias returns the import alias of the Go package
+// containing the types of the resource referenced by the field, e.g.
+// "svcapitypes" for a resource in the same API group as the field's CRD or
+// "ec2apitypes" for a resource of the EC2 service
+func (f *Field) ReferencedAPITypesAlias() string {
+	if f.FieldConfig == nil || f.FieldConfig.References == nil {
+		return ""
+	}
+	if f.FieldConfig.References.ServiceName != "" {
+		return f.ReferencedServiceName() + "apitypes"
+	}
+	pkg := f.ReferencedAPIGroupPackage()
+	if pkg == f.CRD.APIGroupPackage() {
+		return "svcapitypes"
+	}
+	return apiGroupTypesAlias(pkg, f.CRD.sdkAPI.API.PackageName())
+}
+
 // ReferenceFieldPath returns the fieldPath for the corresponding
 // Reference field. It replaces the fieldName with ReferenceFieldName
 // at the end of fieldPath
@@ -402,9 +538,10 @@ func (f *Field) FieldPathWithUnderscore() string {
 }
 
 // NewReferenceField returns a pointer to a new Field object.
-// The go-type of field is either slice of '*AWSResourceReferenceWrapper' or
-// '*AWSResourceReferenceWrapper' depending on whether 'shapeRef' parameter
-// has 'list' type or not, respectively
+// The go-type of field is either slice of '*AWSResourceReferenceWrapper', map
+// of string to '*AWSResourceReferenceWrapper' or '*AWSResourceReferenceWrapper'
+// depending on whether 'shapeRef' parameter has 'list', 'map' or any other
+// type, respectively
 func NewReferenceField(
 	crd *CRD,
 	fieldNames names.Names,
@@ -413,10 +550,15 @@ func NewReferenceField(
 	gt := "*ackv1alpha1.AWSResourceReferenceWrapper"
 	gtp := "*github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1.AWSResourceReferenceWrapper"
 	gte := ""
-	if shapeRef.Shape.Type == "list" {
+	switch shapeRef.Shape.Type {
+	case "list":
 		gt = "[]" + gt
 		gtp = "[]" + gtp
 		gte = "*ackv1alpha1.AWSResourceReferenceWrapper"
+	case "map":
+		gt = "map[string]" + gt
+		gtp = "map[string]" + gtp
+		gte = "*ackv1alpha1.AWSResourceReferenceWrapper"
 	}
 	return &Field{
 		CRD:               crd,
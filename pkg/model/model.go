@@ -50,8 +50,8 @@ type Model struct {
 }
 
 // MetaVars returns a MetaVars struct populated with metadata about the AWS
-// service API
-func (m *Model) MetaVars() templateset.MetaVars {
+// service API and its CRDs
+func (m *Model) MetaVars() (templateset.MetaVars, error) {
 	controllerName := m.cfg.ControllerName
 	if controllerName == "" {
 		controllerName = m.servicePackageName
@@ -67,6 +67,10 @@ func (m *Model) MetaVars() templateset.MetaVars {
 	if m.cfg.SDKNames.Package != "" {
 		servicePackageName = m.cfg.SDKNames.Package
 	}
+	crds, err := m.GetCRDs()
+	if err != nil {
+		return templateset.MetaVars{}, err
+	}
 	return templateset.MetaVars{
 		ControllerName:          controllerName,
 		ServicePackageName:      servicePackageName,
@@ -76,15 +80,50 @@ func (m *Model) MetaVars() templateset.MetaVars {
 		APIVersion:              m.apiVersion,
 		ClientInterfaceTypeName: m.ClientInterfaceTypeName(),
 		ClientStructTypeName:    m.ClientStructTypeName(),
-		CRDNames:                m.crdNames(),
+		CRDNames:                crdNames(crds),
+		NamespacedCRDNames:      crdNamesWithScope(crds, false),
+		ClusterScopedCRDNames:   crdNamesWithScope(crds, true),
+		APIGroups:               m.apiGroups(crds),
+		APIGroupPackages:        m.APIGroupPackages(),
+		CRDManifestNames:        crdManifestNames(crds),
+	}, nil
+}
+
+// apiGroups returns the service's API group followed by the sorted API groups
+// that the supplied CRDs are placed in with `api_group`
+func (m *Model) apiGroups(crds []*CRD) []string {
+	apiGroups := []string{}
+	seen := map[string]bool{m.APIGroup(): true}
+	for _, crd := range crds {
+		if seen[crd.APIGroup()] {
+			continue
+		}
+		seen[crd.APIGroup()] = true
+		apiGroups = append(apiGroups, crd.APIGroup())
 	}
+	sort.Strings(apiGroups)
+	return append([]string{m.APIGroup()}, apiGroups...)
 }
 
-// crdNames returns all crd names lowercased and in plural
-func (m *Model) crdNames() []string {
+// crdManifestNames returns the base names of the manifests generated by
+// controller-gen for the supplied CRDs, e.g. "ecr.services.k8s.aws_repositories"
+func crdManifestNames(crds []*CRD) []string {
+	var manifestNames []string
+
+	for _, crd := range crds {
+		manifestNames = append(
+			manifestNames,
+			crd.APIGroup()+"_"+strings.ToLower(crd.Plural),
+		)
+	}
+
+	return manifestNames
+}
+
+// crdNames returns the names of the supplied crds lowercased and in plural
+func crdNames(crds []*CRD) []string {
 	var crdConfigs []string
 
-	crds, _ := m.GetCRDs()
 	for _, crd := range crds {
 		crdConfigs = append(crdConfigs, strings.ToLower(crd.Plural))
 	}
@@ -92,6 +131,20 @@ func (m *Model) crdNames() []string {
 	return crdConfigs
 }
 
+// crdNamesWithScope returns the names, lowercased and in plural, of the
+// cluster-scoped or of the namespaced crds among the supplied crds
+func crdNamesWithScope(crds []*CRD, clusterScoped bool) []string {
+	var crdConfigs []string
+
+	for _, crd := range crds {
+		if crd.IsClusterScoped() == clusterScoped {
+			crdConfigs = append(crdConfigs, strings.ToLower(crd.Plural))
+		}
+	}
+
+	return crdConfigs
+}
+
 // GetCRDs returns a slice of `CRD` structs that describe the
 // top-level resources discovered by the code generator for an AWS service API
 func (m *Model) GetCRDs() ([]*CRD, error) {
@@ -112,6 +165,7 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 	deleteOps := (*opMap)[OpTypeDelete]
 	getAttributesOps := (*opMap)[OpTypeGetAttributes]
 	setAttributesOps := (*opMap)[OpTypeSetAttributes]
+	replaceOps := (*opMap)[OpTypeReplace]
 
 	// Validate generator config against SDK before building CRDs
 	sdkOps := make(map[string]struct{}, len(m.SDKAPI.API.Operations))
@@ -130,9 +184,31 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 	for crdName := range createOps {
 		crdNameKeys = append(crdNameKeys, crdName)
 	}
+	// A resource without a Create operation but with an operation that both
+	// creates and updates it is an upsert resource.
+	for crdName := range replaceOps {
+		if _, found := createOps[crdName]; !found {
+			crdNameKeys = append(crdNameKeys, crdName)
+		}
+	}
+	// A singleton resource has no Create operation but configured read and
+	// write operations, and an observe-only resource only needs an operation
+	// that reads it.
+	otherCRDNames := append(
+		m.cfg.GetSingletonResourceNames(),
+		m.cfg.GetObserveOnlyResourceNames()...,
+	)
+	for _, crdName := range otherCRDNames {
+		if _, found := createOps[crdName]; found {
+			continue
+		}
+		if _, found := replaceOps[crdName]; found {
+			continue
+		}
+		crdNameKeys = append(crdNameKeys, crdName)
+	}
 	sort.Strings(crdNameKeys)
 	for _, crdName := range crdNameKeys {
-		createOp := createOps[crdName]
 		if m.cfg.ResourceIsIgnored(crdName) {
 			continue
 		}
@@ -145,9 +221,53 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 			Delete:        deleteOps[crdName],
 			GetAttributes: getAttributesOps[crdName],
 			SetAttributes: setAttributesOps[crdName],
+			Replace:       replaceOps[crdName],
+		}
+		observeOnly := m.cfg.ResourceIsObserveOnly(crdName)
+		singletonCfg := m.cfg.GetSingletonConfig(crdName)
+		createOp, found := createOps[crdName]
+		if observeOnly {
+			// The controller never writes to an observe-only resource, so
+			// none of the operations writing to it are used.
+			ops.Create = nil
+			ops.Update = nil
+			ops.Delete = nil
+			ops.SetAttributes = nil
+			ops.Replace = nil
+		} else if singletonCfg != nil {
+			// A singleton resource always exists. It is created and updated
+			// by its write operation and optionally reset on deletion.
+			sdkOps := m.SDKAPI.API.Operations
+			ops.ReadOne = sdkOps[singletonCfg.ReadOperation]
+			ops.Replace = sdkOps[singletonCfg.WriteOperation]
+			ops.Create = ops.Replace
+			ops.Update = ops.Replace
+			ops.Delete = nil
+			if singletonCfg.GetDeletePolicy() == ackgenconfig.SingletonDeletePolicyReset {
+				ops.Delete = sdkOps[singletonCfg.ResetOperation]
+			}
+			createOp = ops.Replace
+		} else if !found {
+			createOp = ops.Replace
+			ops.Create = ops.Replace
+			if ops.Update == nil {
+				ops.Update = ops.Replace
+			}
 		}
 		m.RemoveIgnoredOperations(&ops)
 		crd := NewCRD(m.SDKAPI, m.cfg, m.docCfg, crdNames, ops)
+		crd.serviceAPIGroup = m.APIGroup()
+		if observeOnly {
+			// The Spec and Status fields of an observe-only resource are
+			// gathered from the operation reading it instead.
+			createOp = crd.ObserveOperation()
+			if createOp == nil {
+				return nil, fmt.Errorf(
+					"resource %q: observe_only requires a ReadOne, GetAttributes or ReadMany operation",
+					crdName,
+				)
+			}
+		}
 
 		// OK, begin to gather the CRDFields that will go into the Spec struct.
 		// These fields are those members of the Create operation's Input
@@ -185,6 +305,13 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 				continue
 			}
 
+			// Only the members identifying an observe-only resource go into
+			// its Spec. The other members of the read operation's Input shape
+			// (filters, pagination, etc) are not resource properties.
+			if observeOnly && !util.InStrings(memberName, inputShape.Required) {
+				continue
+			}
+
 			// If this is the wrapper field and we have input_wrapper_field_path
 			// configured, add the wrapper's member fields instead of the wrapper
 			if inputWrapperFieldPath != nil && memberName == *inputWrapperFieldPath {
@@ -336,14 +463,19 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 		if err != nil {
 			return nil, err
 		}
-		if outputShape.UsedAsOutput && len(outputShape.MemberRefs) == 1 {
-			// We might be in a "wrapper" shape. Unwrap it to find the real object
-			// representation for the CRD's createOp. If there is a single member
-			// shape and that member shape is a structure, unwrap it.
+		// We might be in a "wrapper" shape. Unwrap it to find the real object
+		// representation for the CRD's createOp.
+		outputShape = unwrapOutputShape(outputShape)
+		if observeOnly && createOp == crd.Ops.ReadMany {
+			// The ReadMany operation's Output shape contains a list of the
+			// resources. Unwrap it to find the object representation.
 			for _, mn := range outputShape.MemberNames() {
 				memberRef := outputShape.MemberRefs[mn]
-				if memberRef.Shape.Type == "structure" {
-					outputShape = memberRef.Shape
+				if memberRef.Shape.Type == "list" &&
+					memberRef.Shape.MemberRef.Shape != nil &&
+					memberRef.Shape.MemberRef.Shape.Type == "structure" {
+					outputShape = memberRef.Shape.MemberRef.Shape
+					break
 				}
 			}
 		}
@@ -380,6 +512,39 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 			}
 		}
 
+		if singletonCfg != nil && crd.Ops.ReadOne != nil {
+			// The write operation of a singleton resource usually returns
+			// nothing about it, so we also want the fields that are in the
+			// read operation's Output Shape but not in the Spec.
+			readOp := crd.Ops.ReadOne
+			readShape, err := crd.GetOutputShape(readOp)
+			if err != nil {
+				return nil, err
+			}
+			readShape = unwrapOutputShape(readShape)
+			for _, memberName := range readShape.MemberNames() {
+				memberShapeRef := readShape.MemberRefs[memberName]
+				if memberShapeRef.Shape == nil {
+					return nil, ErrNilShapePointer
+				}
+				fieldName := m.cfg.GetResourceFieldName(
+					crd.Names.Original,
+					readOp.Name,
+					memberName,
+				)
+				if inSpec, inStatus := crd.HasMember(fieldName, readOp.Name); inSpec || inStatus {
+					continue
+				}
+				if crd.IsPrimaryARNField(memberName) {
+					continue
+				}
+				memberNames := names.New(fieldName)
+				if err := crd.AddStatusField(memberNames, memberShapeRef); err != nil {
+					return nil, err
+				}
+			}
+		}
+
 		// Now add the additional Status fields that are required from other
 		// API operations.
 		statusFieldConfigs := m.cfg.GetFieldConfigs(crdName)
@@ -528,8 +693,138 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 	return crds, nil
 }
 
-// validateCustomSyncConfigs rejects `custom_sync` field configs that the code
+// unwrapOutputShape returns the structure member of an Output shape that only
+// "wraps" the object representation of a resource, or the Output shape itself
+// if it is not such a wrapper.
+func unwrapOutputShape(shape *awssdkmodel.Shape) *awssdkmodel.Shape {
+	if !shape.UsedAsOutput || len(shape.MemberRefs) != 1 {
+		return shape
+	}
+	for _, mn := range shape.MemberNames() {
+		memberRef := shape.MemberRefs[mn]
+		if memberRef.Shape.Type == "structure" {
+			return memberRef.Shape
+		}
+	}
+	return shape
+}
+
+// validateKeyedLists rejects `compare.list_semantics: keyed` field configs
+// whose `compare.key_member` is not a string member of the list's element
+// struct, which the keyed comparison and the `+listMapKey` marker need.
+func validateKeyedLists(crd *CRD) []string {
+	errs := []string{}
+	for _, fieldPath := range crd.SortedFieldNames() {
+		field := crd.Fields[fieldPath]
+		if field.FieldConfig == nil || field.FieldConfig.Compare == nil ||
+			field.FieldConfig.Compare.ListSemantics != ackgenconfig.ListSemanticsKeyed {
+			continue
+		}
+		prefix := fmt.Sprintf(
+			"resources.%s.fields.%s.compare", crd.Names.Original, fieldPath,
+		)
+		if field.ShapeRef == nil || field.ShapeRef.Shape == nil ||
+			field.ShapeRef.Shape.Type != "list" ||
+			field.ShapeRef.Shape.MemberRef.Shape.Type != "structure" {
+			errs = append(errs, fmt.Sprintf(
+				"%s.list_semantics: keyed list semantics require a list of structs", prefix,
+			))
+			continue
+		}
+		elemShape := field.ShapeRef.Shape.MemberRef.Shape
+		keyMember := field.FieldConfig.Compare.KeyMember
+		var keyShape *awssdkmodel.Shape
+		for _, memberName := range elemShape.MemberNames() {
+			if strings.EqualFold(memberName, keyMember) {
+				keyShape = elemShape.MemberRefs[memberName].Shape
+				break
+			}
+		}
+		switch {
+		case keyShape == nil:
+			errs = append(errs, fmt.Sprintf(
+				"%s.key_member: %q is not a member of %s. available: %s",
+				prefix, keyMember, elemShape.ShapeName,
+				strings.Join(elemShape.MemberNames(), ", "),
+			))
+		case keyShape.Type != "string":
+			errs = append(errs, fmt.Sprintf(
+				"%s.key_member: %q must be a string member of %s",
+				prefix, keyMember, elemShape.ShapeName,
+			))
+		}
+	}
+	return errs
+}
+
+// validateARNTemplate checks that the `Spec.` and `Status.` placeholders of
+// the resource's arn_template name string fields of the resource. The syntax
+// of the template is checked by ValidateConfig.
+func validateARNTemplate(crd *CRD) []string {
+	arnTemplate := crd.ARNTemplate()
+	if arnTemplate == "" {
+		return nil
+	}
+	parts, err := ackgenconfig.ParseARNTemplate(arnTemplate)
+	if err != nil {
+		return nil
+	}
+	errs := []string{}
+	for i := 1; i < len(parts); i += 2 {
+		if util.InStrings(parts[i], ackgenconfig.ARNTemplateMetadataPlaceholders) {
+			continue
+		}
+		if _, err := crd.ARNTemplateField(parts[i]); err != nil {
+			errs = append(errs, fmt.Sprintf(
+				"resources.%s.arn_template: %s", crd.Names.Original, err,
+			))
+		}
+	}
+	return errs
+}
+
+// validateTagSync rejects a `tags.sync_operations` config that the code
 // generator cannot produce working code for.
+func validateTagSync(crd *CRD) []string {
+	if !crd.HasTagSyncOperations() {
+		return nil
+	}
+	// Resolving the tag sync checks the tagging operations against the SDK and
+	// the tag field's shape.
+	ts, err := crd.TagSync()
+	if err != nil {
+		return []string{err.Error()}
+	}
+	prefix := fmt.Sprintf("resources.%s.tags.sync_operations", crd.Names.Original)
+	fc := ts.Field.FieldConfig
+	if fc != nil && (fc.CustomSync != nil || fc.ChildCollection != nil) {
+		return []string{fmt.Sprintf(
+			"%s: tag field %q cannot also be configured with custom_sync or "+
+				"child_collection", prefix, ts.Field.Path,
+		)}
+	}
+	if fc != nil && fc.Compare != nil && fc.Compare.IsIgnored {
+		return []string{fmt.Sprintf(
+			"%s: tag field %q cannot be configured with compare.is_ignored, "+
+				"because the tags would never appear in the delta",
+			prefix, ts.Field.Path,
+		)}
+	}
+	// A custom update method and the SetAttributes update path never invoke
+	// the generated sync methods.
+	if crd.CustomUpdateMethodName() != "" ||
+		(crd.Ops.Update == nil && !crd.HasUpdateOperations() && crd.Ops.SetAttributes != nil) {
+		return []string{fmt.Sprintf(
+			"%s: resource is updated by a custom update method or a "+
+				"SetAttributes operation, so syncTags would never be called",
+			prefix,
+		)}
+	}
+	return nil
+}
+
+// validateCustomSyncConfigs rejects `custom_sync` and `child_collection` field
+// configs that the code generator cannot produce working code for.
 //
 // Each of these combinations otherwise yields a controller that compiles but
 // misbehaves at runtime, so they are caught at generation time instead.
@@ -537,15 +832,27 @@ func (m *Model) validateCustomSyncConfigs(crds []*CRD) error {
 	var errs []string
 	for _, crd := range crds {
 		for fieldName, fc := range m.cfg.GetFieldConfigs(crd.Names.Original) {
-			if fc.CustomSync == nil {
+			if fc.CustomSync == nil && fc.ChildCollection == nil {
 				continue
 			}
+			configKey := "custom_sync"
+			if fc.ChildCollection != nil {
+				configKey = "child_collection"
+			}
 			prefix := fmt.Sprintf(
-				"resources.%s.fields.%s.custom_sync", crd.Names.Original, fieldName,
+				"resources.%s.fields.%s.%s", crd.Names.Original, fieldName, configKey,
 			)
+			// Both configs generate a call to the same sync method name, so only
+			// one of them may own the field.
+			if fc.CustomSync != nil && fc.ChildCollection != nil {
+				errs = append(errs, fmt.Sprintf(
+					"%s: cannot be combined with custom_sync", prefix,
+				))
+				continue
+			}
 			// The generated code lives in sdkUpdate, so without an Update
 			// operation there is nowhere to emit the sync invocation.
-			if crd.Ops.Update == nil {
+			if crd.Ops.Update == nil && !crd.HasUpdateOperations() {
 				errs = append(errs, fmt.Sprintf(
 					"%s: resource has no Update operation, so the sync function "+
 						"would never be called", prefix,
@@ -596,6 +903,14 @@ func (m *Model) validateCustomSyncConfigs(crds []*CRD) error {
 				}
 			}
 		}
+		// Resolving the child collections checks the add, remove and read
+		// operations against the SDK and the field's shape.
+		if _, err := crd.ChildCollections(); err != nil {
+			errs = append(errs, err.Error())
+		}
+		errs = append(errs, validateTagSync(crd)...)
+		errs = append(errs, validateKeyedLists(crd)...)
+		errs = append(errs, validateARNTemplate(crd)...)
 	}
 	if len(errs) > 0 {
 		sort.Strings(errs)
@@ -666,6 +981,9 @@ func (m *Model) RemoveIgnoredOperations(ops *Ops) {
 	if m.cfg.OperationIsIgnored(ops.SetAttributes) {
 		ops.SetAttributes = nil
 	}
+	if m.cfg.OperationIsIgnored(ops.Replace) {
+		ops.Replace = nil
+	}
 }
 
 // IsShapeUsedInCRDs returns true if the supplied shape name is a member of amy
@@ -823,6 +1141,14 @@ func (m *Model) processNestedFieldTypeDefs(
 	for _, crd := range crds {
 		for _, fieldPath := range crd.SortedFieldNames() {
 			field := crd.Fields[fieldPath]
+			if field.ListType() == "map" {
+				// The apiserver rejects `+listType=map` lists whose key
+				// member is neither required nor defaulted, whether the list
+				// is a top-level or a nested field.
+				if err := setTypeDefListMapKeyRequired(field, tdefs); err != nil {
+					return fmt.Errorf("resource %q, field %q: %w", crd.Names.Original, fieldPath, err)
+				}
+			}
 			if !strings.Contains(fieldPath, ".") {
 				// top-level fields have already had their structure
 				// transformed during the CRD.AddSpecField and
@@ -862,6 +1188,11 @@ func (m *Model) processNestedFieldTypeDefs(
 					return fmt.Errorf("resource %q, field %q: %w", crd.Names.Original, fieldPath, err)
 				}
 			}
+			if field.ListType() != "" {
+				if err := setTypeDefAttributeListType(crd, fieldPath, field, tdefs); err != nil {
+					return fmt.Errorf("resource %q, field %q: %w", crd.Names.Original, fieldPath, err)
+				}
+			}
 		}
 	}
 	return nil
@@ -919,16 +1250,11 @@ func getAttributeFromPath(crd *CRD, fieldPath string, tdefs []*TypeDef) (parentT
 	// the beginning of field path and leave rest of nested member names as is.
 	// Ex: ResourcesVpcConfig.SecurityGroupIDs will become VPCConfigRequest.SecurityGroupIDs
 	// for Cluster resource in eks-controller.
-	specFieldShapeRef := topLevelField.ShapeRef
+	//
+	// Lists and maps (including lists of lists or maps of lists) are
+	// replaced by their element shape.
+	specFieldShapeRef := containerElemShapeRef(topLevelField.ShapeRef)
 	specFieldShapeName := specFieldShapeRef.ShapeName
-	switch shapeType := specFieldShapeRef.Shape.Type; shapeType {
-	case "list":
-		specFieldShapeName = topLevelField.ShapeRef.Shape.MemberRef.ShapeName
-		specFieldShapeRef = &topLevelField.ShapeRef.Shape.MemberRef
-	case "map":
-		specFieldShapeName = topLevelField.ShapeRef.Shape.ValueRef.ShapeName
-		specFieldShapeRef = &topLevelField.ShapeRef.Shape.ValueRef
-	}
 	fieldShapePath := strings.Replace(fieldPath, topLevelFieldName, specFieldShapeName, 1)
 	fsp := ackfp.FromString(fieldShapePath)
 
@@ -954,8 +1280,10 @@ func getAttributeFromPath(crd *CRD, fieldPath string, tdefs []*TypeDef) (parentT
 		switch parentFieldShapeRef.Shape.Type {
 		case "list":
 			// e.g FunctionAssociationsList in CloudFront DistributionConfig.DefaultCacheBehavior.FunctionAssociations
-			fallbackName = parentFieldShapeRef.Shape.MemberRef.ShapeName
+			fallbackName = containerElemShapeRef(parentFieldShapeRef).ShapeName
 			fallbackName = strings.TrimSuffix(fallbackName, "List")
+		case "map":
+			fallbackName = containerElemShapeRef(parentFieldShapeRef).ShapeName
 		default:
 			// NOTE(a-hilaly): Very likely that we will need to add more cases here
 			// as we encounter more special APIs in the future.
@@ -1010,6 +1338,41 @@ func setTypeDefAttributeImmutable(crd *CRD, fieldPath string, tdefs []*TypeDef)
 	return nil
 }
 
+// setTypeDefAttributeListType sets the ListType and ListMapKey for the
+// corresponding attribute represented by fieldPath of nested field.
+func setTypeDefAttributeListType(crd *CRD, fieldPath string, f *Field, tdefs []*TypeDef) error {
+	_, fieldAttr, err := getAttributeFromPath(crd, fieldPath, tdefs)
+	if err != nil {
+		return err
+	}
+	if fieldAttr != nil {
+		fieldAttr.ListType = f.ListType()
+		fieldAttr.ListMapKey = f.ListMapKey()
+	}
+	return nil
+}
+
+// setTypeDefListMapKeyRequired marks the `compare.key_member` attribute of the
+// TypeDef of the supplied keyed list field's elements as required.
+func setTypeDefListMapKeyRequired(f *Field, tdefs []*TypeDef) error {
+	elemShape := f.ShapeRef.Shape.MemberRef.Shape
+	for _, tdef := range tdefs {
+		if tdef.Shape == nil || tdef.Shape.ShapeName != elemShape.ShapeName {
+			continue
+		}
+		keyAttr := tdef.GetAttribute(f.FieldConfig.Compare.KeyMember)
+		if keyAttr == nil {
+			return fmt.Errorf(
+				"compare.key_member %q is not a member of %s",
+				f.FieldConfig.Compare.KeyMember, elemShape.ShapeName,
+			)
+		}
+		keyAttr.IsRequired = true
+		return nil
+	}
+	return fmt.Errorf("cannot find type definition of list element %s", elemShape.ShapeName)
+}
+
 // updateTypeDefAttributeWithReference adds a new AWSResourceReference attribute
 // for the corresponding attribute represented by fieldPath of nested field
 func updateTypeDefAttributeWithReference(crd *CRD, fieldPath string, tdefs []*TypeDef) error {
@@ -1043,8 +1406,11 @@ func addReferenceAttribute(td *TypeDef, attr *Attr) error {
 		Documentation: "// Reference field for " + attr.Names.Camel,
 	}
 	refAttrGoType := "*ackv1alpha1.AWSResourceReferenceWrapper"
-	if attr.Shape.Type == "list" {
+	switch attr.Shape.Type {
+	case "list":
 		refAttrGoType = fmt.Sprintf("[]%s", refAttrGoType)
+	case "map":
+		refAttrGoType = fmt.Sprintf("map[string]%s", refAttrGoType)
 	}
 	refAttr := NewAttr(refAttrName, refAttrGoType, refAttrShape)
 	// Add reference attribute to the parent field typedef
@@ -1231,15 +1597,14 @@ func (m *Model) processStructField(
 }
 
 // processListField recurses through the members of a nested field that
-// is a list type that has a struct element type and adds any Field objects to
-// the supplied CRD.
+// is a list type that has a struct element type (possibly within further
+// nested lists or maps) and adds any Field objects to the supplied CRD.
 func (m *Model) processListField(
 	crd *CRD,
 	fieldPath string,
 	field *Field,
 ) error {
-	fieldShape := field.ShapeRef.Shape
-	elementFieldShape := fieldShape.MemberRef.Shape
+	elementFieldShape := containerElemShapeRef(field.ShapeRef).Shape
 	if elementFieldShape.Type != "structure" {
 		return nil
 	}
@@ -1253,15 +1618,14 @@ func (m *Model) processListField(
 }
 
 // processMapField recurses through the members of a nested field that
-// is a map type that has a struct value type and adds any Field objects to
-// the supplied CRD.
+// is a map type that has a struct value type (possibly within further nested
+// lists or maps) and adds any Field objects to the supplied CRD.
 func (m *Model) processMapField(
 	crd *CRD,
 	fieldPath string,
 	field *Field,
 ) error {
-	fieldShape := field.ShapeRef.Shape
-	valueFieldShape := fieldShape.ValueRef.Shape
+	valueFieldShape := containerElemShapeRef(field.ShapeRef).Shape
 	if valueFieldShape.Type != "structure" {
 		return nil
 	}
@@ -1274,6 +1638,23 @@ func (m *Model) processMapField(
 	return nil
 }
 
+// containerElemShapeRef returns the ShapeRef of the elements of the supplied
+// list or map ShapeRef, diving through any nested lists and maps. For example,
+// the element ShapeRef of a list of maps of structs is the struct's ShapeRef.
+// ShapeRefs that are not lists or maps are returned as-is.
+func containerElemShapeRef(shapeRef *awssdkmodel.ShapeRef) *awssdkmodel.ShapeRef {
+	for {
+		switch shapeRef.Shape.Type {
+		case "list":
+			shapeRef = &shapeRef.Shape.MemberRef
+		case "map":
+			shapeRef = &shapeRef.Shape.ValueRef
+		default:
+			return shapeRef
+		}
+	}
+}
+
 // GetEnumDefs returns a slice of pointers to `EnumDef` structs which
 // represent string fields whose value is constrained to one or more specific
 // string values.
@@ -1375,6 +1756,42 @@ func (m *Model) APIGroup() string {
 	return fmt.Sprintf("%s.%s", name, suffix)
 }
 
+// apiGroupPackageName returns the name of the Go package containing the types
+// of the CRDs in the supplied API group, which is the first DNS label of the
+// API group, e.g. "vpc" for "vpc.ec2.services.k8s.aws"
+func apiGroupPackageName(apiGroup string) string {
+	label, _, _ := strings.Cut(apiGroup, ".")
+	return strings.ReplaceAll(label, "-", "")
+}
+
+// apiGroupTypesAlias returns the import alias of the Go package containing the
+// types of the CRDs placed in the API group with the supplied package name,
+// or of the service's API group if the package name is empty
+func apiGroupTypesAlias(apiGroupPackage, servicePackageName string) string {
+	if apiGroupPackage == "" {
+		return servicePackageName + "apitypes"
+	}
+	return apiGroupPackage + "apitypes"
+}
+
+// APIGroupPackages returns the sorted names of the Go packages containing the
+// types of the CRDs placed in API groups other than the service's
+func (m *Model) APIGroupPackages() []string {
+	packages := []string{}
+	seen := map[string]bool{}
+	crds, _ := m.GetCRDs()
+	for _, crd := range crds {
+		pkg := crd.APIGroupPackage()
+		if pkg == "" || seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+	return packages
+}
+
 // ClientInterfaceTypeName returns the name of the aws-sdk-go primary API
 // interface type name.
 func (m *Model) ClientInterfaceTypeName() string {
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"testing"
 
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
 	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 
 	"github.com/stretchr/testify/assert"
@@ -279,3 +280,111 @@ func TestAPIGatewayV2_WithNestedReference(t *testing.T) {
 	assert.Equal(t, "IssuerRef", issuerRefAttr.Names.Camel)
 	assert.Equal(t, "*ackv1alpha1.AWSResourceReferenceWrapper", issuerRefAttr.GoType)
 }
+
+func TestAPIGatewayV2_WithMapReference(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-map-reference.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	stageCrd := getCRDByName("Stage", crds)
+	require.NotNil(stageCrd)
+
+	assert.NotNil(stageCrd.SpecFields["StageVariables"])
+	require.NotNil(stageCrd.SpecFields["StageVariableRefs"])
+	assert.Equal("map[string]*ackv1alpha1.AWSResourceReferenceWrapper", stageCrd.SpecFields["StageVariableRefs"].GoType)
+	assert.True(stageCrd.SpecFields["StageVariableRefs"].IsReference())
+}
+
+func TestAPIGatewayV2_WithAPIGroups(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-api-groups.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	apiCrd := getCRDByName("Api", crds)
+	require.NotNil(apiCrd)
+	assert.Equal("core.apigatewayv2.services.k8s.aws", apiCrd.APIGroup())
+	assert.Equal("core", apiCrd.APIGroupPackage())
+	assert.False(apiCrd.HasNamesOverride())
+
+	// The Kind, and the Go names following it, are overridden while the
+	// original resource name is kept to look up the generator config.
+	linkCrd := getCRDByName("VpcLink", crds)
+	require.NotNil(linkCrd)
+	assert.Equal("PrivateLink", linkCrd.Kind)
+	assert.Equal("PrivateLink", linkCrd.Names.Camel)
+	assert.Equal("private_link", linkCrd.Names.Snake)
+	assert.Equal("privatelinks", linkCrd.Plural)
+	assert.Equal("privatelink", linkCrd.Singular())
+	assert.True(linkCrd.HasNamesOverride())
+	assert.Equal("core", linkCrd.APIGroupPackage())
+
+	// Integration stays in the service's API group and references resources
+	// in the "core" API group.
+	integrationCrd := getCRDByName("Integration", crds)
+	require.NotNil(integrationCrd)
+	assert.Equal("apigatewayv2.services.k8s.aws", integrationCrd.APIGroup())
+	assert.Equal("", integrationCrd.APIGroupPackage())
+	for _, field := range integrationCrd.Fields {
+		if field.HasReference() {
+			assert.Equal("core", field.ReferencedAPIGroupPackage())
+			assert.Equal("coreapitypes", field.ReferencedAPITypesAlias())
+		}
+	}
+	assert.Equal(
+		[]model.APIGroupImport{{Alias: "coreapitypes", Package: "core"}},
+		integrationCrd.ReferencedAPIGroupImports(),
+	)
+
+	// References to other services are unaffected by API groups.
+	for _, field := range linkCrd.Fields {
+		if field.HasReference() {
+			assert.Equal("", field.ReferencedAPIGroupPackage())
+			assert.Equal("ec2apitypes", field.ReferencedAPITypesAlias())
+		}
+	}
+	assert.Empty(linkCrd.ReferencedAPIGroupImports())
+
+	metaVars, err := g.MetaVars()
+	require.NoError(err)
+	assert.Equal(
+		[]string{"apigatewayv2.services.k8s.aws", "core.apigatewayv2.services.k8s.aws"},
+		metaVars.APIGroups,
+	)
+	assert.Equal([]string{"core"}, metaVars.APIGroupPackages)
+	assert.Contains(metaVars.CRDManifestNames, "core.apigatewayv2.services.k8s.aws_privatelinks")
+	assert.Contains(metaVars.CRDManifestNames, "apigatewayv2.services.k8s.aws_integrations")
+}
+
+func TestAPIGatewayV2_ApiMapping_ARNTemplateFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-arn-template.yaml",
+	})
+	_, err := g.GetCRDs()
+	require.NoError(err)
+
+	g = testutil.NewModelForServiceWithOptions(t, "apigatewayv2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-arn-template.yaml",
+	})
+	g.GetConfig().Resources["ApiMapping"] = ackgenconfig.ResourceConfig{
+		ARNTemplate: "arn:{partition}:apigateway:{region}::/domainnames/{Spec.Domain}/apimappings/{Status.DomainName}",
+	}
+	_, err = g.GetCRDs()
+	require.Error(err)
+	assert.Contains(err.Error(), `resources.ApiMapping.arn_template: placeholder "Spec.Domain": field "Domain" not found`)
+	assert.Contains(err.Error(), `resources.ApiMapping.arn_template: placeholder "Status.DomainName": field "DomainName" is not a Status field`)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
 )
 
@@ -130,3 +131,66 @@ func TestCodeDeploy_Deployment(t *testing.T) {
 	}
 	assert.Equal(expPrinterColNames, gotPrinterColNames)
 }
+
+func TestCodeDeploy_DeploymentGroup_WithNestedReference(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "codedeploy", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-nested-reference.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("DeploymentGroup", crds)
+	require.NotNil(crd)
+
+	// Fields of structs within lists of lists are found by field path
+	require.NotNil(crd.Fields["EC2TagSet.EC2TagSetList.Value"])
+	assert.True(crd.Fields["EC2TagSet.EC2TagSetList.Value"].HasReference())
+
+	tds, err := g.GetTypeDefs()
+	require.Nil(err)
+
+	var tagFilterTD *model.TypeDef
+	for _, td := range tds {
+		if td != nil && td.Names.Original == "EC2TagFilter" {
+			tagFilterTD = td
+			break
+		}
+	}
+	require.NotNil(tagFilterTD)
+	valueRefAttr := tagFilterTD.GetAttribute("ValueRef")
+	require.NotNil(valueRefAttr)
+	assert.Equal("*ackv1alpha1.AWSResourceReferenceWrapper", valueRefAttr.GoType)
+}
+
+func TestCodeDeploy_Deployment_PrinterColumnPaths(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "codedeploy", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-printer-column-paths.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Deployment", crds)
+	require.NotNil(crd)
+
+	// Printer columns configured with a field path have their JSONPath
+	// derived from the JSON names of the fields, keeping the selectors.
+	gotPrinterCols, err := crd.AdditionalPrinterColumns()
+	require.NoError(err)
+	gotJSONPaths := map[string]string{}
+	for _, pc := range gotPrinterCols {
+		gotJSONPaths[pc.Name] = pc.JSONPath
+	}
+	assert.Equal(map[string]string{
+		"Description":        ".spec.description",
+		"FirstRollbackEvent": ".spec.autoRollbackConfiguration.events[0]",
+		"TagKeys":            ".spec.targetInstances.tagFilters[*].key",
+	}, gotJSONPaths)
+}
@@ -144,3 +144,130 @@ func TestECRRepository(t *testing.T) {
 	}
 	assert.Equal(expStatusFieldCamel, attrCamelNames(statusFields))
 }
+
+func TestECRLifecyclePolicy_Upsert(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-upsert.yaml",
+		})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("LifecyclePolicy", crds)
+	require.NotNil(crd)
+
+	// The ECR LifecyclePolicy API has no Create or Update operation. The
+	// PutLifecyclePolicy operation both creates and updates the resource:
+	//
+	// * PutLifecyclePolicy
+	// * GetLifecyclePolicy
+	// * DeleteLifecyclePolicy
+	require.NotNil(crd.Ops.Replace)
+	assert.Equal("PutLifecyclePolicy", crd.Ops.Replace.ExportedName)
+	assert.Equal(crd.Ops.Replace, crd.Ops.Create)
+	assert.Equal(crd.Ops.Replace, crd.Ops.Update)
+	assert.NotNil(crd.Ops.ReadOne)
+	assert.NotNil(crd.Ops.Delete)
+
+	assert.True(crd.IsUpsert())
+	assert.True(crd.UpdatesByReplace())
+	// PutLifecyclePolicy returns the resource, so it is not read back.
+	assert.False(crd.ReplaceReadsAfterWrite())
+
+	assert.Contains(crd.SpecFieldNames(), "LifecyclePolicyText")
+	assert.Contains(crd.SpecFieldNames(), "RepositoryName")
+
+	// Resources with a Create operation are unaffected.
+	repo := getCRDByName("Repository", crds)
+	require.NotNil(repo)
+	assert.Nil(repo.Ops.Replace)
+	assert.False(repo.IsUpsert())
+}
+
+func TestECRImage_ObserveOnlyReadMany(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-observe-only.yaml",
+		})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	// ECR has no operation creating an image, so the Image resource only
+	// exists because it is observe-only.
+	crd := getCRDByName("Image", crds)
+	require.NotNil(crd)
+	assert.True(crd.IsObserveOnly())
+	assert.Nil(crd.Ops.ReadOne)
+	require.NotNil(crd.Ops.ReadMany)
+	assert.Equal(crd.Ops.ReadMany, crd.ObserveOperation())
+
+	// The fields of the listed image go into the Status
+	assert.Equal([]string{"RepositoryName"}, crd.SpecFieldNames())
+	assert.Contains(crd.StatusFieldNames(), "ImageDigest")
+	assert.Contains(crd.StatusFieldNames(), "ImageTag")
+}
+
+func TestECRRegistryPolicy_Singleton(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-singleton.yaml",
+		})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	// ECR has no CreateRegistryPolicy operation, the registry policy is
+	// created and updated by PutRegistryPolicy and reset by
+	// DeleteRegistryPolicy.
+	crd := getCRDByName("RegistryPolicy", crds)
+	require.NotNil(crd)
+	assert.True(crd.IsSingleton())
+	assert.False(crd.IsClusterScoped())
+	assert.True(crd.IsUpsert())
+	assert.True(crd.UpdatesByReplace())
+	require.NotNil(crd.Ops.ReadOne)
+	assert.Equal("GetRegistryPolicy", crd.Ops.ReadOne.ExportedName)
+	require.NotNil(crd.Ops.Replace)
+	assert.Equal("PutRegistryPolicy", crd.Ops.Replace.ExportedName)
+	require.NotNil(crd.Ops.Delete)
+	assert.Equal("DeleteRegistryPolicy", crd.Ops.Delete.ExportedName)
+	assert.Equal([]string{"PolicyText"}, crd.SpecFieldNames())
+	assert.Len(crd.StatusFieldNames(), 1)
+
+	// Without a reset operation, the singleton is retained on deletion. The
+	// read operation's Output shape adds Status fields the write operation's
+	// Output shape does not have.
+	crd = getCRDByName("RegistryScanningConfiguration", crds)
+	require.NotNil(crd)
+	assert.True(crd.IsClusterScoped())
+	assert.Nil(crd.Ops.Delete)
+	assert.Contains(crd.StatusFieldNames(), "ScanningConfiguration")
+}
+
+func TestECRRegistryScanningConfiguration_ClusterScoped(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-singleton.yaml",
+	})
+
+	metaVars, err := g.MetaVars()
+	require.NoError(err)
+	require.Len(metaVars.ClusterScopedCRDNames, 1)
+	assert.Equal("registryscanningconfigurations", metaVars.ClusterScopedCRDNames[0])
+	assert.NotContains(metaVars.NamespacedCRDNames, "registryscanningconfigurations")
+	assert.Contains(metaVars.NamespacedCRDNames, "registrypolicies")
+	assert.Len(metaVars.CRDNames, len(metaVars.NamespacedCRDNames)+len(metaVars.ClusterScopedCRDNames))
+}
@@ -55,3 +55,74 @@ func TestEKS_WithNestedReference(t *testing.T) {
 	assert.Equal("SecurityGroupRefs", securityGroupRefsAttr.Names.Camel)
 	assert.Equal("[]*ackv1alpha1.AWSResourceReferenceWrapper", securityGroupRefsAttr.GoType)
 }
+
+func TestEKSAddon_ReplaceWithUpdate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "eks",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-replace-operation.yaml",
+		})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Addon", crds)
+	require.NotNil(crd)
+
+	// CreateAddon is configured as a Replace operation, but the resource keeps
+	// its dedicated UpdateAddon operation for updates.
+	assert.True(crd.IsUpsert())
+	require.NotNil(crd.Ops.Update)
+	assert.Equal("UpdateAddon", crd.Ops.Update.ExportedName)
+	assert.False(crd.UpdatesByReplace())
+}
+
+func TestEKSCluster_ObserveOnly(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "eks",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-observe-only.yaml",
+		})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Cluster", crds)
+	require.NotNil(crd)
+	assert.True(crd.IsObserveOnly())
+	assert.False(crd.IsAdoptable())
+
+	// None of the operations writing to the resource are used.
+	assert.Nil(crd.Ops.Create)
+	assert.Nil(crd.Ops.Update)
+	assert.Nil(crd.Ops.Delete)
+	require.NotNil(crd.ObserveOperation())
+	assert.Equal("DescribeCluster", crd.ObserveOperation().ExportedName)
+
+	// Only the field identifying the cluster is in the Spec, everything the
+	// DescribeCluster operation returns is in the Status.
+	assert.Equal([]string{"Name"}, crd.SpecFieldNames())
+	assert.True(crd.SpecFields["Name"].IsRequired())
+	statusFieldNames := crd.StatusFieldNames()
+	assert.Contains(statusFieldNames, "Endpoint")
+	assert.Contains(statusFieldNames, "Version")
+	assert.Contains(statusFieldNames, "Tags")
+	// The cluster's ARN goes into Status.ACKResourceMetadata
+	for _, field := range crd.StatusFields {
+		assert.NotEqual("arn", strings.ToLower(field.Names.Original))
+	}
+
+	// The controller never writes tags to an observe-only resource
+	tagField, err := crd.GetTagField()
+	require.Nil(err)
+	assert.Nil(tagField)
+
+	crd = getCRDByName("Addon", crds)
+	require.NotNil(crd)
+	assert.Equal([]string{"AddonName", "ClusterName"}, crd.SpecFieldNames())
+	assert.Contains(crd.StatusFieldNames(), "AddonVersion")
+}
@@ -100,3 +100,55 @@ func TestS3_Bucket(t *testing.T) {
 		assert.NotNil(testutil.GetTypeDefByName(t, g, typeDef))
 	}
 }
+
+func TestS3_Bucket_KeyedListSemantics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "s3", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-list-semantics-keyed.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Bucket", crds)
+	require.NotNil(crd)
+
+	field := crd.Fields["Logging.LoggingEnabled.TargetGrants"]
+	require.NotNil(field)
+	assert.Equal("map", field.ListType())
+	assert.Equal("permission", field.ListMapKey())
+
+	// The markers are emitted on the attribute of the TypeDef containing the
+	// nested field
+	loggingEnabledTD := testutil.GetTypeDefByName(t, g, "LoggingEnabled")
+	require.NotNil(loggingEnabledTD)
+	targetGrantsAttr := loggingEnabledTD.GetAttribute("TargetGrants")
+	require.NotNil(targetGrantsAttr)
+	assert.Equal("map", targetGrantsAttr.ListType)
+	assert.Equal("permission", targetGrantsAttr.ListMapKey)
+
+	// The apiserver requires the key member of the list elements to be set
+	targetGrantTD := testutil.GetTypeDefByName(t, g, "TargetGrant")
+	require.NotNil(targetGrantTD)
+	assert.True(targetGrantTD.GetAttribute("Permission").IsRequired)
+	assert.False(targetGrantTD.GetAttribute("Grantee").IsRequired)
+}
+
+func TestS3_Bucket_KeyedListSemantics_UnknownKeyMember(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "s3", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-list-semantics-keyed.yaml",
+	})
+	g.GetConfig().Resources["Bucket"].Fields["Logging.LoggingEnabled.TargetGrants"].Compare.KeyMember = "Name"
+
+	_, err := g.GetCRDs()
+	require.Error(err)
+	assert.Contains(
+		err.Error(),
+		`resources.Bucket.fields.Logging.LoggingEnabled.TargetGrants.compare.key_member: "Name" is not a member of TargetGrant. available: Grantee, Permission`,
+	)
+}
@@ -67,6 +67,8 @@ func GetOpTypeAndResourceNameFromOpID(
 	pluralize := pluralize.NewClient()
 	if strings.HasPrefix(opID, "CreateOrUpdate") {
 		return OpTypeReplace, strings.TrimPrefix(opID, "CreateOrUpdate")
+	} else if strings.HasPrefix(opID, "Put") && cfg.PutOperationIsReplace(strings.TrimPrefix(opID, "Put")) {
+		return OpTypeReplace, strings.TrimPrefix(opID, "Put")
 	} else if strings.HasPrefix(opID, "BatchCreate") {
 		resName := strings.TrimPrefix(opID, "BatchCreate")
 		if pluralize.IsPlural(resName) {
@@ -159,3 +159,26 @@ func TestGetOpTypeAndResourceNameFromOpID_PluralSingular(t *testing.T) {
 		assert.Equal(test.expResName, resName, test.opID)
 	}
 }
+
+func TestGetOpTypeAndResourceNameFromOpID_PutOperationIsReplace(t *testing.T) {
+	assert := assert.New(t)
+
+	// Without put_operation_is_replace, Put operations are not classified
+	g := testutil.NewModelForService(t, "ecr")
+	opType, resName := model.GetOpTypeAndResourceNameFromOpID("PutLifecyclePolicy", g.GetConfig())
+	assert.Equal(model.OpTypeUnknown, opType)
+	assert.Equal("PutLifecyclePolicy", resName)
+
+	g = testutil.NewModelForServiceWithOptions(t, "ecr",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-upsert.yaml",
+		})
+	opType, resName = model.GetOpTypeAndResourceNameFromOpID("PutLifecyclePolicy", g.GetConfig())
+	assert.Equal(model.OpTypeReplace, opType)
+	assert.Equal("LifecyclePolicy", resName)
+
+	// The option only applies to the resources it is configured for
+	opType, resName = model.GetOpTypeAndResourceNameFromOpID("PutRegistryPolicy", g.GetConfig())
+	assert.Equal(model.OpTypeUnknown, opType)
+	assert.Equal("PutRegistryPolicy", resName)
+}
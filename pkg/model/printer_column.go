@@ -17,6 +17,10 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/aws-controllers-k8s/pkg/names"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/fieldpath"
 )
 
 // PrinterColumn represents a single field in the CRD's Spec or Status objects
@@ -180,3 +184,30 @@ func (r *CRD) addStatusPrintableColumn(
 		fmt.Sprintf("%s.%s", ".status", field.Names.CamelLower),
 	)
 }
+
+// printerColumnJSONPath returns the JSONPath of the printer column for the
+// supplied field path, using the JSON names of the fields it traverses, e.g.
+// `.status.endpoints[*].address` for "Status.Endpoints[*].Address"
+func (r *CRD) printerColumnJSONPath(path string) string {
+	fp := fieldpath.FromString(path)
+	out := ""
+	for idx := 0; idx < fp.Size(); idx++ {
+		part := fp.At(idx)
+		if key, ok := fieldpath.Key(part); ok {
+			out += "." + strings.ReplaceAll(key, ".", "\\.")
+			continue
+		}
+		if fieldpath.IsSelector(part) {
+			out += part
+			continue
+		}
+		if idx == 1 {
+			if field, ok := r.Fields[part]; ok {
+				out += "." + field.Names.CamelLower
+				continue
+			}
+		}
+		out += "." + names.New(part).CamelLower
+	}
+	return out
+}
@@ -0,0 +1,280 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ReferenceGraphNode is a kind of resource referencing, or referenced by,
+// another kind of resource
+type ReferenceGraphNode struct {
+	// Group is the API group of the resource, empty for core Kubernetes
+	// objects like ConfigMaps
+	Group string `json:"group"`
+	// Kind is the Kind of the resource
+	Kind string `json:"kind"`
+}
+
+// ID returns the fully-qualified name of the node's Kind, e.g.
+// "VPC.ec2.services.k8s.aws", or just the Kind for core Kubernetes objects
+func (n ReferenceGraphNode) ID() string {
+	if n.Group == "" {
+		return n.Kind
+	}
+	return n.Kind + "." + n.Group
+}
+
+// ReferenceGraphEdge is a reference field of a resource to another resource
+type ReferenceGraphEdge struct {
+	// From is the resource containing the reference field
+	From ReferenceGraphNode `json:"from"`
+	// To is the referenced resource
+	To ReferenceGraphNode `json:"to"`
+	// FieldPath is the path of the field that is set from the referenced
+	// resource, e.g. "Routes.GatewayID"
+	FieldPath string `json:"fieldPath"`
+	// SkipResourceStateValidations is true if the reference does not wait
+	// for the referenced resource to be synced
+	SkipResourceStateValidations bool `json:"skipResourceStateValidations"`
+}
+
+// ReferenceGraph is the directed graph of the references between the resources
+// of a service and the resources (of any service) or Kubernetes objects they
+// reference
+type ReferenceGraph struct {
+	// Nodes are the resources of the graph, sorted by ID
+	Nodes []ReferenceGraphNode `json:"nodes"`
+	// Edges are the reference fields of the graph, sorted by the ID of the
+	// referencing node and field path
+	Edges []ReferenceGraphEdge `json:"edges"`
+}
+
+// GetReferenceGraph returns the ReferenceGraph built from the ReferencesConfig
+// of every field of every CRD in the model
+func (m *Model) GetReferenceGraph() (*ReferenceGraph, error) {
+	crds, err := m.GetCRDs()
+	if err != nil {
+		return nil, err
+	}
+	// The API group of a referenced resource of this service depends on
+	// the group the CRD of the resource is placed in.
+	apiGroupsByKind := map[string]string{}
+	for _, crd := range crds {
+		apiGroupsByKind[crd.Kind] = crd.APIGroup()
+	}
+
+	g := &ReferenceGraph{
+		Nodes: []ReferenceGraphNode{},
+		Edges: []ReferenceGraphEdge{},
+	}
+	nodes := map[string]ReferenceGraphNode{}
+	for _, crd := range crds {
+		from := ReferenceGraphNode{Group: crd.APIGroup(), Kind: crd.Kind}
+		nodes[from.ID()] = from
+		for _, fieldPath := range crd.SortedFieldNames() {
+			field := crd.Fields[fieldPath]
+			if !field.HasReference() {
+				continue
+			}
+			refCfg := field.FieldConfig.References
+			to := ReferenceGraphNode{Group: refCfg.Group, Kind: refCfg.Kind}
+			if !refCfg.IsKubernetesObjectReference() {
+				to.Kind = refCfg.Resource
+				if refCfg.ServiceName == "" || refCfg.ServiceName == m.servicePackageName {
+					to.Group = m.APIGroup()
+					if apiGroup, found := apiGroupsByKind[to.Kind]; found {
+						to.Group = apiGroup
+					}
+				} else {
+					to.Group = fmt.Sprintf("%s.services.k8s.aws", refCfg.ServiceName)
+				}
+			}
+			nodes[to.ID()] = to
+			g.Edges = append(g.Edges, ReferenceGraphEdge{
+				From:                         from,
+				To:                           to,
+				FieldPath:                    fieldPath,
+				SkipResourceStateValidations: refCfg.SkipResourceStateValidations,
+			})
+		}
+	}
+	for _, node := range nodes {
+		g.Nodes = append(g.Nodes, node)
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].ID() < g.Nodes[j].ID()
+	})
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From.ID() != g.Edges[j].From.ID() {
+			return g.Edges[i].From.ID() < g.Edges[j].From.ID()
+		}
+		return g.Edges[i].FieldPath < g.Edges[j].FieldPath
+	})
+	return g, nil
+}
+
+// Cycles returns the cycles of the graph containing at least one edge that
+// does not skip resource state validations. Resources in such a cycle never
+// sync, because each of them waits for the next one to be synced first.
+//
+// Only the shortest cycle through each such edge is returned, starting with
+// its lowest edge (by ID of the referencing node and field path).
+//
+// References of a kind of resource to the same kind, like the parent of an
+// API Gateway Resource, are not cycles: a resource references another
+// resource of its kind, not itself.
+func (g *ReferenceGraph) Cycles() [][]ReferenceGraphEdge {
+	cycles := [][]ReferenceGraphEdge{}
+	seen := map[string]bool{}
+	for idx, edge := range g.Edges {
+		if edge.SkipResourceStateValidations || edge.From.ID() == edge.To.ID() {
+			continue
+		}
+		path := g.shortestPath(edge.To, edge.From)
+		if path == nil {
+			continue
+		}
+		cycle := append([]int{idx}, path...)
+		// Rotate the cycle to start with its lowest edge so the same cycle
+		// found through different edges is only returned once.
+		lowest := 0
+		for i := range cycle {
+			if cycle[i] < cycle[lowest] {
+				lowest = i
+			}
+		}
+		cycle = append(append([]int{}, cycle[lowest:]...), cycle[:lowest]...)
+		key := fmt.Sprint(cycle)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		edges := make([]ReferenceGraphEdge, len(cycle))
+		for i, edgeIdx := range cycle {
+			edges[i] = g.Edges[edgeIdx]
+		}
+		cycles = append(cycles, edges)
+	}
+	return cycles
+}
+
+// shortestPath returns the indexes of the edges of the shortest path from the
+// supplied node to the supplied, distinct, node, or nil if there is no such
+// path. References of a kind to the same kind are never part of the path.
+func (g *ReferenceGraph) shortestPath(from, to ReferenceGraphNode) []int {
+	// viaEdge stores the index of the edge through which a node was first
+	// reached
+	viaEdge := map[string]int{}
+	queue := []string{from.ID()}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for idx, edge := range g.Edges {
+			next := edge.To.ID()
+			if edge.From.ID() != cur || next == cur || next == from.ID() {
+				continue
+			}
+			if _, visited := viaEdge[next]; visited {
+				continue
+			}
+			viaEdge[next] = idx
+			if next == to.ID() {
+				path := []int{}
+				for node := next; node != from.ID(); node = g.Edges[viaEdge[node]].From.ID() {
+					path = append([]int{viaEdge[node]}, path...)
+				}
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// ValidateCycles returns an error reporting every cycle of the graph unless
+// all the references in each cycle skip resource state validations
+func (g *ReferenceGraph) ValidateCycles() error {
+	cycles := g.Cycles()
+	if len(cycles) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(cycles))
+	for i, cycle := range cycles {
+		msg := cycle[0].From.ID()
+		for _, edge := range cycle {
+			msg += fmt.Sprintf(" --(%s)--> %s", edge.FieldPath, edge.To.ID())
+		}
+		msgs[i] = msg
+	}
+	return fmt.Errorf(
+		"found %d reference cycle(s) in which resources never sync; set "+
+			"references.skip_resource_state_validations on every reference "+
+			"field of the cycle to allow it:\n  %s",
+		len(cycles), strings.Join(msgs, "\n  "),
+	)
+}
+
+// DOT returns the graph in the Graphviz DOT language. References skipping
+// resource state validations are dashed.
+func (g *ReferenceGraph) DOT() string {
+	out := "digraph references {\n"
+	for _, node := range g.Nodes {
+		out += fmt.Sprintf("\t%q [label=%q];\n", node.ID(), node.Kind)
+	}
+	for _, edge := range g.Edges {
+		style := ""
+		if edge.SkipResourceStateValidations {
+			style = ", style=dashed"
+		}
+		out += fmt.Sprintf(
+			"\t%q -> %q [label=%q%s];\n",
+			edge.From.ID(), edge.To.ID(), edge.FieldPath, style,
+		)
+	}
+	out += "}\n"
+	return out
+}
+
+// Mermaid returns the graph as a Mermaid flowchart. References skipping
+// resource state validations are dotted.
+func (g *ReferenceGraph) Mermaid() string {
+	// Mermaid node IDs cannot contain dots, so nodes are identified by
+	// their index.
+	nodeIDs := make(map[string]string, len(g.Nodes))
+	out := "flowchart LR\n"
+	for idx, node := range g.Nodes {
+		nodeIDs[node.ID()] = fmt.Sprintf("n%d", idx)
+		out += fmt.Sprintf("\tn%d[\"%s\"]\n", idx, node.ID())
+	}
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if edge.SkipResourceStateValidations {
+			arrow = "-.->"
+		}
+		out += fmt.Sprintf(
+			"\t%s %s|%s| %s\n",
+			nodeIDs[edge.From.ID()], arrow, edge.FieldPath, nodeIDs[edge.To.ID()],
+		)
+	}
+	return out
+}
+
+// JSON returns the indented JSON representation of the graph
+func (g *ReferenceGraph) JSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
@@ -0,0 +1,181 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestReferenceGraph_APIGatewayV2(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-reference.yaml",
+	})
+
+	graph, err := g.GetReferenceGraph()
+	require.Nil(err)
+
+	integration := model.ReferenceGraphNode{Group: "apigatewayv2.services.k8s.aws", Kind: "Integration"}
+	api := model.ReferenceGraphNode{Group: "apigatewayv2.services.k8s.aws", Kind: "API"}
+	vpcLink := model.ReferenceGraphNode{Group: "apigatewayv2.services.k8s.aws", Kind: "VPCLink"}
+	securityGroup := model.ReferenceGraphNode{Group: "ec2.services.k8s.aws", Kind: "SecurityGroup"}
+	subnet := model.ReferenceGraphNode{Group: "ec2-modified.services.k8s.aws", Kind: "Subnet"}
+
+	assert.Contains(graph.Nodes, api)
+	assert.Contains(graph.Nodes, integration)
+	assert.Contains(graph.Nodes, securityGroup)
+	assert.Contains(graph.Nodes, subnet)
+	assert.Equal(
+		[]model.ReferenceGraphEdge{
+			{From: integration, To: api, FieldPath: "APIID"},
+			{From: vpcLink, To: securityGroup, FieldPath: "SecurityGroupIDs"},
+			{From: vpcLink, To: subnet, FieldPath: "SubnetIDs"},
+		},
+		graph.Edges,
+	)
+	assert.Empty(graph.Cycles())
+	assert.Nil(graph.ValidateCycles())
+}
+
+func TestReferenceGraph_Cycle(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-reference-cycle.yaml",
+	})
+
+	graph, err := g.GetReferenceGraph()
+	require.Nil(err)
+
+	cycles := graph.Cycles()
+	require.Len(cycles, 1)
+	require.Len(cycles[0], 2)
+	assert.Equal("API", cycles[0][0].From.Kind)
+	assert.Equal("Target", cycles[0][0].FieldPath)
+	assert.Equal("Integration", cycles[0][1].From.Kind)
+	assert.Equal("APIID", cycles[0][1].FieldPath)
+
+	err = graph.ValidateCycles()
+	require.NotNil(err)
+	assert.Contains(
+		err.Error(),
+		"API.apigatewayv2.services.k8s.aws --(Target)--> "+
+			"Integration.apigatewayv2.services.k8s.aws --(APIID)--> "+
+			"API.apigatewayv2.services.k8s.aws",
+	)
+
+	// The cycle is still reported when only some of its references skip
+	// resource state validations...
+	for idx := range graph.Edges {
+		if graph.Edges[idx].FieldPath == "Target" {
+			graph.Edges[idx].SkipResourceStateValidations = true
+		}
+	}
+	assert.Len(graph.Cycles(), 1)
+
+	// ... but not when all of them do
+	for idx := range graph.Edges {
+		graph.Edges[idx].SkipResourceStateValidations = true
+	}
+	assert.Empty(graph.Cycles())
+	assert.Nil(graph.ValidateCycles())
+}
+
+func TestReferenceGraph_SelfReference(t *testing.T) {
+	assert := assert.New(t)
+
+	resource := model.ReferenceGraphNode{Group: "apigateway.services.k8s.aws", Kind: "Resource"}
+	restAPI := model.ReferenceGraphNode{Group: "apigateway.services.k8s.aws", Kind: "RestAPI"}
+	graph := &model.ReferenceGraph{
+		Nodes: []model.ReferenceGraphNode{resource, restAPI},
+		Edges: []model.ReferenceGraphEdge{
+			{From: resource, To: resource, FieldPath: "ParentID"},
+			{From: resource, To: restAPI, FieldPath: "RestAPIID"},
+			{From: restAPI, To: restAPI, FieldPath: "CloneFrom"},
+		},
+	}
+
+	// A resource referencing another resource of the same kind is not a
+	// cycle
+	assert.Empty(graph.Cycles())
+	assert.Nil(graph.ValidateCycles())
+
+	// ... and such references are not part of the cycles between kinds
+	graph.Edges = append(graph.Edges, model.ReferenceGraphEdge{
+		From: restAPI, To: resource, FieldPath: "RootResourceID",
+	})
+	cycles := graph.Cycles()
+	assert.Len(cycles, 1)
+	assert.Equal(
+		[]model.ReferenceGraphEdge{graph.Edges[1], graph.Edges[3]},
+		cycles[0],
+	)
+}
+
+func TestReferenceGraph_Output(t *testing.T) {
+	assert := assert.New(t)
+
+	api := model.ReferenceGraphNode{Group: "apigatewayv2.services.k8s.aws", Kind: "API"}
+	integration := model.ReferenceGraphNode{Group: "apigatewayv2.services.k8s.aws", Kind: "Integration"}
+	configMap := model.ReferenceGraphNode{Kind: "ConfigMap"}
+	graph := &model.ReferenceGraph{
+		Nodes: []model.ReferenceGraphNode{api, configMap, integration},
+		Edges: []model.ReferenceGraphEdge{
+			{From: api, To: integration, FieldPath: "Target", SkipResourceStateValidations: true},
+			{From: integration, To: api, FieldPath: "APIID"},
+			{From: integration, To: configMap, FieldPath: "Description"},
+		},
+	}
+
+	assert.Equal(`digraph references {
+	"API.apigatewayv2.services.k8s.aws" [label="API"];
+	"ConfigMap" [label="ConfigMap"];
+	"Integration.apigatewayv2.services.k8s.aws" [label="Integration"];
+	"API.apigatewayv2.services.k8s.aws" -> "Integration.apigatewayv2.services.k8s.aws" [label="Target", style=dashed];
+	"Integration.apigatewayv2.services.k8s.aws" -> "API.apigatewayv2.services.k8s.aws" [label="APIID"];
+	"Integration.apigatewayv2.services.k8s.aws" -> "ConfigMap" [label="Description"];
+}
+`, graph.DOT())
+
+	assert.Equal(`flowchart LR
+	n0["API.apigatewayv2.services.k8s.aws"]
+	n1["ConfigMap"]
+	n2["Integration.apigatewayv2.services.k8s.aws"]
+	n0 -.->|Target| n2
+	n2 -->|APIID| n0
+	n2 -->|Description| n1
+`, graph.Mermaid())
+
+	out, err := graph.JSON()
+	assert.Nil(err)
+	assert.Contains(string(out), `"from": {
+        "group": "apigatewayv2.services.k8s.aws",
+        "kind": "Integration"
+      },
+      "to": {
+        "group": "",
+        "kind": "ConfigMap"
+      },
+      "fieldPath": "Description",
+      "skipResourceStateValidations": false`)
+}
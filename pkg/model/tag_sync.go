@@ -0,0 +1,284 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws-controllers-k8s/code-generator/pkg/api"
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/config"
+)
+
+// TagSync describes the tag field of a resource whose tags are reconciled by
+// dedicated tagging operations of the AWS service API. See
+// ackgenconfig.TagSyncOperationsConfig.
+type TagSync struct {
+	// Field is the Spec field holding the tags
+	Field *Field
+	// TagOp is the SDK operation adding or overwriting tags of the resource
+	TagOp *awssdkmodel.Operation
+	// UntagOp is the SDK operation removing tags from the resource
+	UntagOp *awssdkmodel.Operation
+	// ListOp is the SDK operation returning the tags of the resource, if any
+	ListOp *awssdkmodel.Operation
+	// ARNMembers maps the ExportedName of each of the operations above to
+	// the exact name of the member of its Input shape receiving the ARN
+	ARNMembers map[string]string
+	// Tags is the member of the TagOp's Input shape receiving the tags
+	Tags *TagSyncMember
+	// TagKeysMember is the exact name of the member of the UntagOp's Input
+	// shape receiving the keys of the tags to remove
+	TagKeysMember string
+	// ListTags is the member of the ListOp's Output shape containing the
+	// tags, if ListOp is set
+	ListTags *TagSyncMember
+	// BatchSize is the maximum number of tags, or tag keys, passed to a single
+	// call of the TagOp or UntagOp. Zero means all of them are passed in a
+	// single call.
+	BatchSize int
+}
+
+// TagSyncMember is a member of an SDK shape holding tags, either as a map of
+// strings or as a list of structures with a key and a value member.
+type TagSyncMember struct {
+	// Name is the exact name of the member
+	Name string
+	// Shape is the shape of the member
+	Shape *awssdkmodel.Shape
+	// KeyMember is the exact name of the tag structure's key member. It is
+	// empty when Shape is a map.
+	KeyMember string
+	// ValueMember is the exact name of the tag structure's value member. It
+	// is empty when Shape is a map.
+	ValueMember string
+}
+
+// IsMap returns true if the tags are held in a map rather than in a list of
+// structures.
+func (m *TagSyncMember) IsMap() bool {
+	return m.Shape.Type == "map"
+}
+
+// Operations returns the tag and untag operations of the tag sync.
+func (ts *TagSync) Operations() []*awssdkmodel.Operation {
+	return []*awssdkmodel.Operation{ts.TagOp, ts.UntagOp}
+}
+
+// tagSyncOperationsConfig returns the `tags.sync_operations` config of the
+// CRD, or nil if the CRD's tags are not reconciled by tagging operations.
+func (r *CRD) tagSyncOperationsConfig() *ackgenconfig.TagSyncOperationsConfig {
+	if r.cfg.TagsAreIgnored(r.Names.Original) {
+		return nil
+	}
+	resConfig := r.cfg.GetResourceConfig(r.Names.Original)
+	if resConfig == nil || resConfig.TagConfig == nil {
+		return nil
+	}
+	return resConfig.TagConfig.SyncOperations
+}
+
+// HasTagSyncOperations returns true if the CRD's tag field is reconciled by
+// the tagging operations configured with `tags.sync_operations`.
+func (r *CRD) HasTagSyncOperations() bool {
+	return r.tagSyncOperationsConfig() != nil
+}
+
+// TagSync returns the tag sync of the CRD, with its SDK operations and their
+// members resolved, or nil if the CRD has no `tags.sync_operations` config. An
+// error is returned if an operation or member does not exist in the SDK or if
+// a member's shape cannot hold tags.
+func (r *CRD) TagSync() (*TagSync, error) {
+	syncCfg := r.tagSyncOperationsConfig()
+	if syncCfg == nil {
+		return nil, nil
+	}
+	ts, err := r.newTagSync(syncCfg)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"resource %q: tags.sync_operations: %w", r.Names.Original, err,
+		)
+	}
+	return ts, nil
+}
+
+// newTagSync returns the TagSync for the supplied config, resolving the SDK
+// operations and members it names.
+func (r *CRD) newTagSync(
+	syncCfg *ackgenconfig.TagSyncOperationsConfig,
+) (*TagSync, error) {
+	tagField, err := r.GetTagField()
+	if err != nil {
+		return nil, err
+	}
+	if r.SpecFields[tagField.Names.Original] != tagField {
+		return nil, fmt.Errorf(
+			"tag field %q must be a top-level Spec field", tagField.Path,
+		)
+	}
+	arnMember := defaultString(syncCfg.ARNMember, "ResourceArn")
+	ts := &TagSync{
+		Field:      tagField,
+		ARNMembers: map[string]string{},
+		BatchSize:  syncCfg.BatchSize,
+	}
+
+	var tagsRef, tagKeysRef *awssdkmodel.ShapeRef
+	ts.TagOp, err = r.tagSyncOp(syncCfg.TagOperation)
+	if err != nil {
+		return nil, err
+	}
+	if err = ts.addARNMember(ts.TagOp, arnMember); err != nil {
+		return nil, err
+	}
+	tagsMember := defaultString(syncCfg.TagsMember, "Tags")
+	tagsMember, tagsRef, err = shapeMember(ts.TagOp.InputRef.Shape, tagsMember)
+	if err != nil {
+		return nil, fmt.Errorf("operation %q: %w", ts.TagOp.ExportedName, err)
+	}
+	if ts.Tags, err = r.newTagSyncMember(tagsMember, tagsRef); err != nil {
+		return nil, fmt.Errorf("operation %q: %w", ts.TagOp.ExportedName, err)
+	}
+
+	ts.UntagOp, err = r.tagSyncOp(syncCfg.UntagOperation)
+	if err != nil {
+		return nil, err
+	}
+	if err = ts.addARNMember(ts.UntagOp, arnMember); err != nil {
+		return nil, err
+	}
+	tagKeysMember := defaultString(syncCfg.TagKeysMember, "TagKeys")
+	ts.TagKeysMember, tagKeysRef, err = shapeMember(ts.UntagOp.InputRef.Shape, tagKeysMember)
+	if err != nil {
+		return nil, fmt.Errorf("operation %q: %w", ts.UntagOp.ExportedName, err)
+	}
+	if tagKeysRef.Shape.Type != "list" || tagKeysRef.Shape.MemberRef.Shape.Type != "string" {
+		return nil, fmt.Errorf(
+			"operation %q: member %q must be a list of strings",
+			ts.UntagOp.ExportedName, ts.TagKeysMember,
+		)
+	}
+
+	if syncCfg.ListOperation != "" {
+		ts.ListOp, err = r.tagSyncOp(syncCfg.ListOperation)
+		if err != nil {
+			return nil, err
+		}
+		if err = ts.addARNMember(ts.ListOp, arnMember); err != nil {
+			return nil, err
+		}
+		if ts.ListOp.OutputRef.Shape == nil {
+			return nil, fmt.Errorf(
+				"operation %q has no Output shape", ts.ListOp.ExportedName,
+			)
+		}
+		listTagsMember := defaultString(syncCfg.ListTagsMember, "Tags")
+		listTagsMember, listTagsRef, err := shapeMember(ts.ListOp.OutputRef.Shape, listTagsMember)
+		if err != nil {
+			return nil, fmt.Errorf("operation %q: %w", ts.ListOp.ExportedName, err)
+		}
+		if ts.ListTags, err = r.newTagSyncMember(listTagsMember, listTagsRef); err != nil {
+			return nil, fmt.Errorf("operation %q: %w", ts.ListOp.ExportedName, err)
+		}
+	}
+	return ts, nil
+}
+
+// tagSyncOp returns the named SDK operation, which must have an Input shape.
+func (r *CRD) tagSyncOp(opName string) (*awssdkmodel.Operation, error) {
+	op, found := r.sdkAPI.API.Operations[opName]
+	if !found {
+		return nil, fmt.Errorf("operation %q not found in SDK", opName)
+	}
+	if op.InputRef.Shape == nil {
+		return nil, fmt.Errorf("operation %q has no Input shape", opName)
+	}
+	return op, nil
+}
+
+// addARNMember records the exact name of the supplied operation's Input shape
+// member receiving the resource's ARN, which must be a string.
+func (ts *TagSync) addARNMember(op *awssdkmodel.Operation, arnMember string) error {
+	memberName, memberRef, err := shapeMember(op.InputRef.Shape, arnMember)
+	if err != nil {
+		return fmt.Errorf("operation %q: %w", op.ExportedName, err)
+	}
+	if memberRef.Shape.Type != "string" {
+		return fmt.Errorf(
+			"operation %q: member %q must be a string", op.ExportedName, memberName,
+		)
+	}
+	ts.ARNMembers[op.ExportedName] = memberName
+	return nil
+}
+
+// newTagSyncMember returns the TagSyncMember for the supplied member, which
+// must be a map of strings or a list of structures with the key and value
+// members of the CRD's tag field.
+func (r *CRD) newTagSyncMember(
+	memberName string,
+	memberRef *awssdkmodel.ShapeRef,
+) (*TagSyncMember, error) {
+	m := &TagSyncMember{Name: memberName, Shape: memberRef.Shape}
+	switch memberRef.Shape.Type {
+	case "map":
+		if memberRef.Shape.KeyRef.Shape.Type != "string" ||
+			memberRef.Shape.ValueRef.Shape.Type != "string" {
+			return nil, fmt.Errorf("member %q must be a map of strings", memberName)
+		}
+		return m, nil
+	case "list":
+		elemShape := memberRef.Shape.MemberRef.Shape
+		if elemShape.Type != "structure" {
+			break
+		}
+		keyMember := defaultString(r.GetTagKeyMemberName(), "Key")
+		valueMember := defaultString(r.GetTagValueMemberName(), "Value")
+		var err error
+		if m.KeyMember, _, err = shapeMember(elemShape, keyMember); err != nil {
+			return nil, fmt.Errorf("member %q: %w", memberName, err)
+		}
+		if m.ValueMember, _, err = shapeMember(elemShape, valueMember); err != nil {
+			return nil, fmt.Errorf("member %q: %w", memberName, err)
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf(
+		"member %q must be a map of strings or a list of structures", memberName,
+	)
+}
+
+// shapeMember returns the exact name and the ShapeRef of the member of the
+// supplied shape matching, case-insensitively, the supplied name.
+func shapeMember(
+	shape *awssdkmodel.Shape,
+	name string,
+) (string, *awssdkmodel.ShapeRef, error) {
+	for _, memberName := range shape.MemberNames() {
+		if strings.EqualFold(memberName, name) {
+			return memberName, shape.MemberRefs[memberName], nil
+		}
+	}
+	return "", nil, fmt.Errorf(
+		"member %q not found in shape %q", name, shape.ShapeName,
+	)
+}
+
+// defaultString returns s, or def if s is empty.
+func defaultString(s string, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
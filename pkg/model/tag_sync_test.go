@@ -0,0 +1,118 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+// TestTagSync_ECR_Repository verifies that the model resolves the tagging
+// operations of a resource whose tags are a list of structures, and that its
+// tag field is synced like a custom_sync field.
+func TestTagSync_ECR_Repository(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-tag-sync.yaml",
+		})
+
+	crds, err := g.GetCRDs()
+	require.NoError(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	require.True(crd.HasTagSyncOperations())
+
+	ts, err := crd.TagSync()
+	require.NoError(err)
+	require.NotNil(ts)
+
+	assert.Equal("Tags", ts.Field.Names.Camel)
+	assert.Equal("TagResource", ts.TagOp.ExportedName)
+	assert.Equal("UntagResource", ts.UntagOp.ExportedName)
+	require.NotNil(ts.ListOp)
+	assert.Equal("ListTagsForResource", ts.ListOp.ExportedName)
+	assert.Equal("ResourceArn", ts.ARNMembers["TagResource"])
+	assert.Equal("ResourceArn", ts.ARNMembers["UntagResource"])
+	assert.Equal("ResourceArn", ts.ARNMembers["ListTagsForResource"])
+	assert.Equal("TagKeys", ts.TagKeysMember)
+	assert.Equal(10, ts.BatchSize)
+
+	require.NotNil(ts.Tags)
+	assert.False(ts.Tags.IsMap())
+	assert.Equal("Tags", ts.Tags.Name)
+	assert.Equal("Key", ts.Tags.KeyMember)
+	assert.Equal("Value", ts.Tags.ValueMember)
+	require.NotNil(ts.ListTags)
+	assert.False(ts.ListTags.IsMap())
+
+	// The tag field is synced through the custom_sync dispatch.
+	tags := crd.SpecFields["Tags"]
+	require.NotNil(tags)
+	assert.True(tags.HasTagSync())
+	assert.True(tags.HasCustomSync())
+	assert.Equal("syncTags", tags.CustomSyncMethodName())
+	assert.Len(crd.CustomSyncFields(), 1)
+}
+
+// TestTagSync_EKS_Cluster verifies that the model resolves the tagging
+// operations of a resource whose tags are a map.
+func TestTagSync_EKS_Cluster(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "eks",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-tag-sync.yaml",
+		})
+
+	crds, err := g.GetCRDs()
+	require.NoError(err)
+
+	crd := getCRDByName("Cluster", crds)
+	require.NotNil(crd)
+
+	ts, err := crd.TagSync()
+	require.NoError(err)
+	require.NotNil(ts)
+	assert.True(ts.Tags.IsMap())
+	assert.Empty(ts.Tags.KeyMember)
+	require.NotNil(ts.ListTags)
+	assert.True(ts.ListTags.IsMap())
+	assert.Equal("syncTags", crd.SpecFields["Tags"].CustomSyncMethodName())
+}
+
+// TestTagSyncInvalid_ARNMember rejects a tag sync whose arn_member is not a
+// member of the tagging operations' Input shapes.
+func TestTagSyncInvalid_ARNMember(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr",
+		&testutil.TestingModelOptions{
+			GeneratorConfigFile: "generator-with-tag-sync-bad-member.yaml",
+		})
+
+	_, err := g.GetCRDs()
+	require.Error(err)
+	assert.Contains(err.Error(), "tags.sync_operations")
+	assert.Contains(err.Error(), `member "RepositoryArn" not found`)
+}
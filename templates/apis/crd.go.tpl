@@ -30,6 +30,14 @@ type {{ .CRD.Kind }}Spec struct {
     // +kubebuilder:validation:Required
 {{ end -}}
 
+{{- if $field.ListType -}}
+    // +listType={{ $field.ListType }}
+{{ end -}}
+
+{{- if $field.ListMapKey -}}
+    // +listMapKey={{ $field.ListMapKey }}
+{{ end -}}
+
     {{ $field.Names.Camel }} {{ $field.GoType }} {{ $field.GetGoTag }}
 {{- end }}
 }
@@ -58,6 +66,12 @@ type {{ .CRD.Kind }}Status struct {
 }
 
 // {{ .CRD.Kind }} is the Schema for the {{ .CRD.Plural }} API
+{{- if .CRD.IsObserveOnly }}
+//
+// {{ .CRD.Kind }} is observe-only: the controller reads the AWS resource
+// identified by the Spec into the Status but never creates, updates or deletes
+// the AWS resource.
+{{- end }}
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 {{- range $column := .CRD.AdditionalPrinterColumns }}
@@ -72,6 +86,12 @@ type {{ .CRD.Kind }}Status struct {
 {{- if .CRD.ShortNames }}
 // +kubebuilder:resource:shortName={{ Join .CRD.ShortNames ";" }}
 {{- end }}
+{{- if .CRD.IsClusterScoped }}
+// +kubebuilder:resource:scope=Cluster
+{{- end }}
+{{- if .CRD.HasNamesOverride }}
+// +kubebuilder:resource:path={{ ToLower .CRD.Plural }},singular={{ .CRD.Singular }}
+{{- end }}
 type {{ .CRD.Kind }} struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
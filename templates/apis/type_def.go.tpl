@@ -8,9 +8,18 @@ type {{ .Names.Camel }} struct {
 	{{- if $attr.Shape.Documentation }}
 	{{ $attr.Shape.Documentation }}
 	{{- end }}
+	{{- if $attr.IsRequired }}
+	// +kubebuilder:validation:Required
+	{{- end }}
 	{{- if $attr.IsImmutable }}
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable once set"
 	{{- end }}
+	{{- if $attr.ListType }}
+	// +listType={{ $attr.ListType }}
+	{{- end }}
+	{{- if $attr.ListMapKey }}
+	// +listMapKey={{ $attr.ListMapKey }}
+	{{- end }}
 	{{ $attr.Names.Camel }} {{ $attr.GoType }} {{ $attr.GetGoTag }}
 {{- end }}
 }
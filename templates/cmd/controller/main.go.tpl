@@ -39,6 +39,9 @@ resources across service controller. */ -}}
 
 	svcresource "github.com/aws-controllers-k8s/{{ .ControllerName }}-controller/pkg/resource"
 	svctypes "github.com/aws-controllers-k8s/{{ .ControllerName }}-controller/apis/{{ .APIVersion }}"
+{{- range $apiGroupPackage := .APIGroupPackages }}
+	svc{{ $apiGroupPackage }}types "github.com/aws-controllers-k8s/{{ $controllerName }}-controller/apis/{{ $apiVersion }}/{{ $apiGroupPackage }}"
+{{- end }}
 
 	{{/* TODO(a-hilaly): import apis/* packages to register webhooks */}}
 	{{range $crdName := .SnakeCasedCRDNames }}_ "github.com/aws-controllers-k8s/{{ $controllerName }}-controller/pkg/resource/{{ $crdName }}"
@@ -70,8 +73,10 @@ func depVersion(path string) string {
 
 func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
-	{{/* TODO(a-hilaly): register all the apis/* schemes */}}
 	_ = svctypes.AddToScheme(scheme)
+{{- range $apiGroupPackage := .APIGroupPackages }}
+	_ = svc{{ $apiGroupPackage }}types.AddToScheme(scheme)
+{{- end }}
 	_ = ackv1alpha1.AddToScheme(scheme)
 {{- range $referencedServiceName := .ReferencedServiceNames }}
 {{- if not (eq $referencedServiceName $servicePackageName) }}
@@ -24,7 +24,7 @@ spec:
     owned: 
     {{- range .CRDs}}
     - kind: {{ .Kind}}
-      name: {{ ToLower .Plural }}.{{$.APIGroup}}
+      name: {{ ToLower .Plural }}.{{ .APIGroup }}
       version: {{$.APIVersion}}
       displayName: {{.Kind}}
       description: {{.Kind}} represents the state of an AWS {{$.ControllerName}} {{.Kind}} resource.
@@ -1,3 +1,4 @@
+{{- if .NamespacedCRDNames }}
 ---
 apiVersion: rbac.authorization.k8s.io/v1
 kind: Role
@@ -7,12 +8,36 @@ metadata:
   namespace: default
 rules:
 - apiGroups:
-  - {{ .APIGroup }}
+{{- range $apiGroup := .APIGroups }}
+  - {{ $apiGroup }}
+{{- end }}
   resources:
-{{- range $crdName := .CRDNames }}
+{{- range $crdName := .NamespacedCRDNames }}
   - {{ $crdName }}
 {{- end }}
   verbs:
   - get
   - list
   - watch
+{{- end }}
+{{- if .ClusterScopedCRDNames }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  creationTimestamp: null
+  name: ack-{{ .ControllerName }}-cluster-reader
+rules:
+- apiGroups:
+{{- range $apiGroup := .APIGroups }}
+  - {{ $apiGroup }}
+{{- end }}
+  resources:
+{{- range $crdName := .ClusterScopedCRDNames }}
+  - {{ $crdName }}
+{{- end }}
+  verbs:
+  - get
+  - list
+  - watch
+{{- end }}
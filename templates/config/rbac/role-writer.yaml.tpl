@@ -1,3 +1,4 @@
+{{- if .NamespacedCRDNames }}
 ---
 apiVersion: rbac.authorization.k8s.io/v1
 kind: Role
@@ -7,9 +8,11 @@ metadata:
   namespace: default
 rules:
 - apiGroups:
-  - {{ .APIGroup }}
+{{- range $apiGroup := .APIGroups }}
+  - {{ $apiGroup }}
+{{- end }}
   resources:
-{{- range $crdName := .CRDNames }}
+{{- range $crdName := .NamespacedCRDNames }}
   - {{ $crdName }}
 {{- end }}
   verbs:
@@ -21,12 +24,52 @@ rules:
   - update
   - watch
 - apiGroups:
-  - {{ .APIGroup }}
+{{- range $apiGroup := .APIGroups }}
+  - {{ $apiGroup }}
+{{- end }}
   resources:
-{{- range $crdName := .CRDNames }}
+{{- range $crdName := .NamespacedCRDNames }}
   - {{ $crdName }}
 {{- end }}
   verbs:
   - get
   - patch
   - update
+{{- end }}
+{{- if .ClusterScopedCRDNames }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  creationTimestamp: null
+  name: ack-{{ .ControllerName }}-cluster-writer
+rules:
+- apiGroups:
+{{- range $apiGroup := .APIGroups }}
+  - {{ $apiGroup }}
+{{- end }}
+  resources:
+{{- range $crdName := .ClusterScopedCRDNames }}
+  - {{ $crdName }}
+{{- end }}
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+{{- range $apiGroup := .APIGroups }}
+  - {{ $apiGroup }}
+{{- end }}
+  resources:
+{{- range $crdName := .ClusterScopedCRDNames }}
+  - {{ $crdName }}
+{{- end }}
+  verbs:
+  - get
+  - patch
+  - update
+{{- end }}
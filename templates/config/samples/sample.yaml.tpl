@@ -1,6 +1,10 @@
-{{- range .Samples -}}
+{{- range $sample := .Samples -}}
+{{- $apiGroup := $.APIGroup -}}
+{{- range $.CRDs -}}
+{{- if eq .Kind $sample.Kind -}}{{- $apiGroup = .APIGroup -}}{{- end -}}
+{{- end -}}
 ---
-apiVersion: {{$.APIGroup}}/{{$.APIVersion}}
+apiVersion: {{ $apiGroup }}/{{$.APIVersion}}
 kind: {{.Kind}}
 metadata:
   name: example
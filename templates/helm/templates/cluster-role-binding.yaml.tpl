@@ -49,4 +49,26 @@ subjects:
   name: {{ "{{ $serviceAccountName }}" }}
   namespace: {{ "{{ $releaseNamespace }}" }}
 {{ "{{ end }}" }}
+{{- if .ClusterScopedCRDNames }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: {{ "{{ $fullname }}" }}-cluster-scoped
+  labels:
+    app.kubernetes.io/name: {{ "{{ $fullname }}" }}
+    app.kubernetes.io/instance: {{ "{{ .Release.Name }}" }}
+    app.kubernetes.io/managed-by: Helm
+    app.kubernetes.io/version: {{ "{{ $appVersion }}" }}
+    k8s-app: {{ "{{ $fullname }}" }}
+    helm.sh/chart: {{ "{{ $chartVersion }}" }}
+roleRef:
+  kind: ClusterRole
+  apiGroup: rbac.authorization.k8s.io
+  name: {{ "{{ $fullname }}" }}-cluster-scoped
+subjects:
+- kind: ServiceAccount
+  name: {{ "{{ $serviceAccountName }}" }}
+  namespace: {{ "{{ $releaseNamespace }}" }}
+{{- end }}
 {{ "{{ end }}" }}
\ No newline at end of file
@@ -41,4 +41,39 @@ metadata:
   {{ "{{- end }}" }}
 {{ "{{ $rbacRules }}" }}
 {{ "{{ end }}" }}
+{{- if .ClusterScopedCRDNames }}
+---
+# Cluster-scoped resources are not in any watched namespace, so the controller
+# needs a ClusterRole to reconcile them.
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ "{{ $fullname }}" }}-cluster-scoped
+  labels:
+    app.kubernetes.io/name: {{ "{{ $fullname }}" }}
+    app.kubernetes.io/instance: {{ "{{ .Release.Name }}" }}
+    app.kubernetes.io/managed-by: Helm
+    app.kubernetes.io/version: {{ "{{ $appVersion }}" }}
+    k8s-app: {{ "{{ $fullname }}" }}
+    helm.sh/chart: {{ "{{ $chartVersion }}" }}
+  {{ "{{- range $key, $value := $labels }}" }}
+    {{ "{{ $key }}: {{ $value | quote }}" }}
+  {{ "{{- end }}" }}
+rules:
+- apiGroups:
+{{- range $apiGroup := .APIGroups }}
+  - {{ $apiGroup }}
+{{- end }}
+  resources:
+{{- range $crdName := .ClusterScopedCRDNames }}
+  - {{ $crdName }}
+  - {{ $crdName }}/status
+{{- end }}
+  verbs:
+  - get
+  - list
+  - patch
+  - update
+  - watch
+{{- end }}
 {{ "{{ end }}" }}
\ No newline at end of file
@@ -1,3 +1,4 @@
+{{- if .NamespacedCRDNames }}
 ---
 apiVersion: rbac.authorization.k8s.io/v1
 kind: Role
@@ -14,12 +15,43 @@ metadata:
     helm.sh/chart: {{ IncludeTemplate "chart.name-version" }}
 rules:
 - apiGroups:
-  - {{ .APIGroup }}
+{{- range $apiGroup := .APIGroups }}
+  - {{ $apiGroup }}
+{{- end }}
+  resources:
+{{- range $crdName := .NamespacedCRDNames }}
+  - {{ $crdName }}
+{{- end }}
+  verbs:
+  - get
+  - list
+  - watch
+{{- end }}
+{{- if .ClusterScopedCRDNames }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  creationTimestamp: null
+  name: {{ IncludeTemplate "app.fullname" }}-cluster-reader
+  labels:
+    app.kubernetes.io/name: {{ IncludeTemplate "app.name" }}
+    app.kubernetes.io/instance: {{ "{{ .Release.Name }}" }}
+    app.kubernetes.io/managed-by: Helm
+    app.kubernetes.io/version: {{ "{{ .Chart.AppVersion | quote }}" }}
+    k8s-app: {{ IncludeTemplate "app.name" }}
+    helm.sh/chart: {{ IncludeTemplate "chart.name-version" }}
+rules:
+- apiGroups:
+{{- range $apiGroup := .APIGroups }}
+  - {{ $apiGroup }}
+{{- end }}
   resources:
-{{- range $crdName := .CRDNames }}
+{{- range $crdName := .ClusterScopedCRDNames }}
   - {{ $crdName }}
 {{- end }}
   verbs:
   - get
   - list
   - watch
+{{- end }}
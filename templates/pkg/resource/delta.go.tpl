@@ -4,6 +4,12 @@ package {{ .CRD.Names.Snake }}
 
 import (
 	"bytes"
+{{- if HasCompareNormalizers .CRD }}
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+{{- end }}
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
@@ -28,6 +34,11 @@ func newResourceDelta(
 		delta.Add("", a, b)
 		return delta
 	}
+{{- if HasCompareARNNormalizer .CRD }}
+	// ARNs are only normalized within the account and region of the resources
+	normalizeARNCanonical := newARNCanonicalNormalizer(a, b)
+	_ = normalizeARNCanonical
+{{- end }}
 
 {{- if $hookCode := Hook .CRD "delta_pre_compare" }}
 {{ $hookCode }}
@@ -54,6 +65,11 @@ func newResourceDeltaForPreDelete(
 		delta.Add("", a, b)
 		return delta, nil
 	}
+{{- if HasCompareARNNormalizer .CRD }}
+	// ARNs are only normalized within the account and region of the resources
+	normalizeARNCanonical := newARNCanonicalNormalizer(a, b)
+	_ = normalizeARNCanonical
+{{- end }}
 
 {{ GoCodeCompareForPreDelete .CRD "delta" "a.ko" "b.ko" 1}}
 
@@ -64,3 +80,136 @@ func newResourceDeltaForPreDelete(
 	return delta, merged
 }
 {{ end }}
+{{ if HasCompareNormalizers .CRD }}
+// normalizeStringPSlice returns a copy of the supplied slice with the
+// normalize function applied to each of its non-nil elements.
+func normalizeStringPSlice(
+	values []*string,
+	normalize func(string) string,
+) []*string {
+	if values == nil {
+		return nil
+	}
+	normalized := make([]*string, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			normalized = append(normalized, nil)
+			continue
+		}
+		normalizedValue := normalize(*value)
+		normalized = append(normalized, &normalizedValue)
+	}
+	return normalized
+}
+
+// normalizeStringPMap returns a copy of the supplied map with the normalize
+// function applied to each of its non-nil values.
+func normalizeStringPMap(
+	values map[string]*string,
+	normalize func(string) string,
+) map[string]*string {
+	if values == nil {
+		return nil
+	}
+	normalized := make(map[string]*string, len(values))
+	for key, value := range values {
+		if value == nil {
+			normalized[key] = nil
+			continue
+		}
+		normalizedValue := normalize(*value)
+		normalized[key] = &normalizedValue
+	}
+	return normalized
+}
+
+// normalizeJSONCanonical returns the supplied JSON document with its keys
+// sorted and insignificant whitespace removed. Values that are not valid JSON
+// are returned unchanged.
+func normalizeJSONCanonical(value string) string {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(value), &doc); err != nil {
+		return value
+	}
+	canonical, err := json.Marshal(doc)
+	if err != nil {
+		return value
+	}
+	return string(canonical)
+}
+
+{{- if HasCompareARNNormalizer .CRD }}
+
+// newARNCanonicalNormalizer returns a function that reduces the ARN of a
+// resource in the same account and region as the supplied resources to its
+// final resource identifier, so that the ARN and the short name AWS expanded
+// it from compare equal. ARNs of resources in other accounts or regions, or
+// of resources compared before their account and region are known, are
+// returned unchanged so that a change of account or region is not hidden.
+// Values that are not ARNs are returned unchanged.
+func newARNCanonicalNormalizer(
+	a *resource,
+	b *resource,
+) func(string) string {
+	account, region := "", ""
+	for _, r := range []*resource{b, a} {
+		if r == nil || r.ko.Status.ACKResourceMetadata == nil {
+			continue
+		}
+		metadata := r.ko.Status.ACKResourceMetadata
+		if account == "" && metadata.OwnerAccountID != nil {
+			account = string(*metadata.OwnerAccountID)
+		}
+		if region == "" && metadata.Region != nil {
+			region = string(*metadata.Region)
+		}
+	}
+	return func(value string) string {
+		if !strings.HasPrefix(value, "arn:") {
+			return value
+		}
+		// arn:partition:service:region:account-id:resource
+		parts := strings.SplitN(value, ":", 6)
+		if len(parts) != 6 {
+			return value
+		}
+		// The region and account of global resources, e.g. S3 buckets, are
+		// empty.
+		if (parts[3] != "" && parts[3] != region) ||
+			(parts[4] != "" && parts[4] != account) {
+			return value
+		}
+		resource := parts[5]
+		if i := strings.LastIndexAny(resource, "/:"); i >= 0 {
+			resource = resource[i+1:]
+		}
+		return resource
+	}
+}
+{{- end }}
+
+// normalizeNumericString returns the shortest representation of the supplied
+// numeric string. Values that are not numbers are returned unchanged.
+func normalizeNumericString(value string) string {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return value
+	}
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// normalizeDuration returns the canonical representation of the supplied
+// duration string. Bare integers are interpreted as a number of seconds.
+// Values that are not durations are returned unchanged.
+func normalizeDuration(value string) string {
+	trimmed := strings.TrimSpace(value)
+	if seconds, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+		return (time.Duration(seconds) * time.Second).String()
+	}
+	duration, err := time.ParseDuration(trimmed)
+	if err != nil {
+		return value
+	}
+	return duration.String()
+}
+{{ end }}
@@ -24,7 +24,7 @@ import (
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/{{ .ServicePackageName }}"
 	"github.com/aws/aws-sdk-go-v2/aws"
 
-	svcapitypes "github.com/aws-controllers-k8s/{{ .ControllerName }}-controller/apis/{{ .APIVersion }}"
+	svcapitypes "github.com/aws-controllers-k8s/{{ .ControllerName }}-controller/apis/{{ .APIVersion }}{{ with .CRD.APIGroupPackage }}/{{ . }}{{ end }}"
 )
 
 var (
@@ -34,7 +34,11 @@ var (
 	_ = svcapitypes.{{ .CRD.Kind }}{}
 )
 
+{{ if .CRD.IsObserveOnly -}}
+// +kubebuilder:rbac:groups={{ .APIGroup }},resources={{ ToLower .CRD.Plural }},verbs=get;list;watch;update;patch
+{{ else -}}
 // +kubebuilder:rbac:groups={{ .APIGroup }},resources={{ ToLower .CRD.Plural }},verbs=get;list;watch;create;update;patch;delete
+{{ end -}}
 // +kubebuilder:rbac:groups={{ .APIGroup }},resources={{ ToLower .CRD.Plural }}/status,verbs=get;update;patch
 
 {{ GoCodeFindLateInitializedFieldNames .CRD "lateInitializeFieldNames" 1 }}
@@ -143,6 +147,22 @@ func (rm *resourceManager) Update(
 		// Should never happen... if it does, it's buggy code.
 		panic("resource manager's Update() method received resource with nil CR object")
 	}
+{{- if .CRD.HasAsyncOperations }}
+	// Wait for the asynchronous operation in progress when the resource was
+	// read, if any, to complete before updating the resource.
+	if rm.asyncOperationsInProgress(latest) {
+		return rm.onError(latest, ackrequeue.NeededAfter(
+			fmt.Errorf("asynchronous operation in progress, cannot be updated"),
+			ackrequeue.DefaultRequeueAfterDuration,
+		))
+	}
+	// An asynchronous operation that failed when the resource was read is
+	// only attempted again on the next reconciliation, so the Terminal
+	// condition set by sdkFind is reported.
+	if terminal := ackcondition.Terminal(latest); terminal != nil && terminal.Status == corev1.ConditionTrue {
+		return latest, ackerr.Terminal
+	}
+{{- end }}
 	updated, err := rm.sdkUpdate(ctx, desired, latest, delta)
 	if err != nil {
 	    if updated != nil {
@@ -165,6 +185,11 @@ func (rm *resourceManager) Delete(
 		// Should never happen... if it does, it's buggy code.
 		panic("resource manager's Update() method received resource with nil CR object")
 	}
+{{- if .CRD.IsObserveOnly }}
+	// {{ .CRD.Kind }} is observe-only. Deleting the custom resource leaves the
+	// AWS resource untouched.
+	return rm.onSuccess(r)
+{{- else }}
 	observed, err := rm.sdkDelete(ctx, r)
 	if err != nil {
 		if observed != nil {
@@ -174,6 +199,7 @@ func (rm *resourceManager) Delete(
 	}
 
 	return rm.onSuccess(observed)
+{{- end }}
 }
 
 // ARNFromName returns an AWS Resource Name from a given string name. This
@@ -190,12 +216,32 @@ func (rm *resourceManager) ARNFromName(name string) string {
 	)
 }
 
+{{- if .CRD.ReconcileRequeueWhen }}
+// LateInitialize late initializes the supplied resource, then requeues it if it
+// is in a transitional state as per the reconcile.requeue_when rules. Late
+// initialization is the last step of the ACK runtime's Sync, after the resource
+// has been created or updated, so resources in a transitional state are still
+// updated, and the runtime saves the returned resource before requeueing it.
+func (rm *resourceManager) LateInitialize(
+	ctx context.Context,
+	latest acktypes.AWSResource,
+) (acktypes.AWSResource, error) {
+	lateInitialized, err := rm.lateInitialize(ctx, latest)
+	if err != nil {
+		return lateInitialized, err
+	}
+	return rm.requeueWhen(lateInitialized)
+}
+
+// lateInitialize returns an acktypes.AWSResource after setting the late initialized
+{{- else }}
 // LateInitialize returns an acktypes.AWSResource after setting the late initialized
+{{- end }}
 // fields from the readOne call. This method will initialize the optional fields
 // which were not provided by the k8s user but were defaulted by the AWS service.
 // If there are no such fields to be initialized, the returned object is similar to
 // object passed in the parameter.
-func (rm *resourceManager) LateInitialize(
+func (rm *resourceManager) {{ if .CRD.ReconcileRequeueWhen }}lateInitialize{{ else }}LateInitialize{{ end }}(
 	ctx context.Context,
 	latest acktypes.AWSResource,
 ) (acktypes.AWSResource, error) {
@@ -263,10 +309,119 @@ func (rm *resourceManager) IsSynced(ctx context.Context, res acktypes.AWSResourc
 		// Should never happen... if it does, it's buggy code.
 		panic("resource manager's IsSynced() method received resource with nil CR object")
 	}
+{{- if .CRD.HasAsyncOperations }}
+	// The resource is not synced until its asynchronous operations, polled
+	// by sdkFind, complete.
+	if rm.asyncOperationsInProgress(r) {
+		return false, nil
+	}
+{{- end }}
 {{ GoCodeIsSynced .CRD "r.ko" 1}}
 	return true, nil
 }
 
+{{ if .CRD.ReconcileRequeueWhen -}}
+// requeueBackoffSinceAnnotation is the annotation in which the time at which
+// the resource started matching the reconcile.requeue_when rules is stored.
+// Unlike the resource's conditions, which the ACK runtime clears at the start
+// of every reconciliation, annotations survive from one reconciliation to the
+// next.
+const requeueBackoffSinceAnnotation = ackv1alpha1.AnnotationPrefix + "requeue-backoff-since"
+
+// requeueWhen returns a copy of the supplied resource, marked as not synced,
+// and a requeue error with the delay of the first reconcile.requeue_when rule
+// the resource matches, if any. It records in the copy the time at which the
+// resource started matching the rules, and removes it once the resource no
+// longer matches them.
+func (rm *resourceManager) requeueWhen(
+	res acktypes.AWSResource,
+) (acktypes.AWSResource, error) {
+	r := rm.concreteResource(res.DeepCopy())
+	requeueAfter, ok := rm.requeueAfter(r)
+	if !ok {
+		delete(r.ko.Annotations, requeueBackoffSinceAnnotation)
+		return r, nil
+	}
+	if _, found := r.ko.Annotations[requeueBackoffSinceAnnotation]; !found {
+		if r.ko.Annotations == nil {
+			r.ko.Annotations = map[string]string{}
+		}
+		r.ko.Annotations[requeueBackoffSinceAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	}
+	ackcondition.SetSynced(r, corev1.ConditionFalse, nil, nil)
+	return r, ackrequeue.NeededAfter(nil, requeueAfter)
+}
+
+// requeueAfter returns the delay after which to requeue the supplied resource,
+// and true if the resource matches one of the reconcile.requeue_when rules.
+func (rm *resourceManager) requeueAfter(r *resource) (time.Duration, bool) {
+{{ GoCodeResourceRequeueAfter .CRD "r" 1}}
+	return 0, false
+}
+
+// requeueBackoff returns the delay after which to requeue the supplied
+// resource. The delay grows from minDelay with the time elapsed since the
+// resource started matching the reconcile.requeue_when rules, so that the
+// interval between successive requeues doubles, up to maxDelay.
+func requeueBackoff(r *resource, minDelay time.Duration, maxDelay time.Duration) time.Duration {
+	delay := minDelay
+	if since, err := time.Parse(time.RFC3339, r.ko.Annotations[requeueBackoffSinceAnnotation]); err == nil {
+		if elapsed := time.Since(since); elapsed > delay {
+			delay = elapsed
+		}
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+{{ end -}}
+{{ if .CRD.ReadAfterCreateGraceSeconds -}}
+{{- $createdAtField := .CRD.ReadAfterCreateGraceStatusField.Names.Camel }}
+// readAfterCreateGraceRequeueAfter is the delay after which a resource that
+// was created but cannot be found yet is reconciled again during the
+// read-after-create grace window.
+const readAfterCreateGraceRequeueAfter = 5 * time.Second
+
+// setCreatedAt records the current time in the Status of the supplied custom
+// resource. The ACK runtime saves the Status even if the resource cannot be
+// read back after the Create, so the time of creation survives the
+// reconciliation.
+func setCreatedAt(ko *svcapitypes.{{ .CRD.Names.Camel }}) {
+	createdAt := time.Now().UTC().Format(time.RFC3339)
+	ko.Status.{{ $createdAtField }} = &createdAt
+}
+
+// withinReadAfterCreateGrace returns true if the supplied resource was created
+// less than {{ .CRD.ReadAfterCreateGraceSeconds }} seconds ago, as recorded by setCreatedAt. Resources being
+// deleted are never graced.
+func withinReadAfterCreateGrace(r *resource) bool {
+	if r.ko.DeletionTimestamp != nil || r.ko.Status.{{ $createdAtField }} == nil {
+		return false
+	}
+	createdAt, err := time.Parse(time.RFC3339, *r.ko.Status.{{ $createdAtField }})
+	return err == nil && time.Since(createdAt) <= {{ .CRD.ReadAfterCreateGraceSeconds }}*time.Second
+}
+
+// readAfterCreateGrace returns an error requeueing the reconciliation of the
+// supplied resource, which cannot be found, if it was created within the
+// read-after-create grace window. Otherwise it returns nil.
+//
+// It is called by sdkCreate, so a resource that is not visible yet is not
+// created a second time. Right after the Create, NotFound is left to the ACK
+// runtime, which retries the read operation itself.
+func readAfterCreateGrace(r *resource) error {
+	if !withinReadAfterCreateGrace(r) {
+		return nil
+	}
+	return ackrequeue.NeededAfter(
+		fmt.Errorf("resource not found after creation, waiting for it to become visible"),
+		readAfterCreateGraceRequeueAfter,
+	)
+}
+
+{{ end -}}
 // EnsureTags ensures that tags are present inside the AWSResource.
 // If the AWSResource does not have any existing resource tags, the 'tags'
 // field is initialized and the controller tags are added.
@@ -453,6 +608,10 @@ func (rm *resourceManager) onError(
 		return nil, err
 	}
 	r1, updated := rm.updateConditions(r, false, err)
+	if rm.retryableAWSError(err) {
+		// The request may succeed when retried, so requeue with backoff
+		err = ackrequeue.Needed(err)
+	}
 	if !updated {
 		return r, err
 	}
@@ -3,12 +3,22 @@
 package {{ .CRD.Names.Snake }}
 
 import (
+{{ if .CRD.HasKubernetesObjectReferenceFields -}}
+	"bytes"
+{{ end -}}
 	"context"
 {{ if .CRD.HasReferenceFields -}}
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+{{ if .CRD.HasKubernetesObjectReferenceFields -}}
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+{{ end -}}
 	"k8s.io/apimachinery/pkg/types"
+{{ if .CRD.HasKubernetesObjectReferenceFields -}}
+	"k8s.io/client-go/util/jsonpath"
+{{ end -}}
 {{ end -}}
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -26,15 +36,30 @@ import (
     {{ $referencedServiceName }}apitypes "github.com/aws-controllers-k8s/{{ $referencedServiceName }}-controller/apis/{{ $apiVersion }}"
 {{ end }}
 {{- end }}
+{{- range $apiGroupImport := .CRD.ReferencedAPIGroupImports }}
+	{{ $apiGroupImport.Alias }} "github.com/aws-controllers-k8s/{{ $.ControllerName }}-controller/apis/{{ $apiVersion }}{{ with $apiGroupImport.Package }}/{{ . }}{{ end }}"
+{{- end }}
 {{- end }}
 
-	svcapitypes "github.com/aws-controllers-k8s/{{ .ControllerName }}-controller/apis/{{ .APIVersion }}"
+	svcapitypes "github.com/aws-controllers-k8s/{{ .ControllerName }}-controller/apis/{{ .APIVersion }}{{ with .CRD.APIGroupPackage }}/{{ . }}{{ end }}"
 )
 
+{{ if .CRD.HasReferenceFields -}}
+// Hack to avoid import errors during build...
+var (
+	_ = ackrt.ResolveCrossNamespaceReference
+	_ = ackv1alpha1.ConditionTypeResourceSynced
+	_ = corev1.ConditionTrue
+)
+
+{{ end -}}
 {{ if .CRD.HasReferenceFields -}}
 {{ range $fieldName := .CRD.SortedFieldNames -}}
 {{ $field := (index $.CRD.Fields $fieldName) -}}
-{{ if and $field.HasReference (not (eq $field.ReferencedServiceName $servicePackageName)) -}}
+{{ if $field.ReferencesKubernetesObject -}}
+// +kubebuilder:rbac:groups={{ with $field.FieldConfig.References.Group }}{{ . }}{{ else }}""{{ end }},resources={{ ToLower $field.ReferencedResourceNamePlural }},verbs=get;list
+
+{{ else if and $field.HasReference (not (eq $field.ReferencedServiceName $servicePackageName)) -}}
 // +kubebuilder:rbac:groups={{ $field.ReferencedServiceName -}}.services.k8s.aws,resources={{ ToLower $field.ReferencedResourceNamePlural }},verbs=get;list
 // +kubebuilder:rbac:groups={{ $field.ReferencedServiceName -}}.services.k8s.aws,resources={{ ToLower $field.ReferencedResourceNamePlural }}/status,verbs=get;list
 
@@ -128,9 +153,14 @@ func (rm *resourceManager) resolveReferenceFor{{ $field.FieldPathWithUnderscore
 	return hasReferences, nil
 }
 
-{{- if not (and $getReferencedResourceStateResources (eq (index $getReferencedResourceStateResources $field.FieldConfig.References.Resource) "true" )) }}
+{{- if $field.ReferencesKubernetesObject }}
+{{- else if not (and $getReferencedResourceStateResources (eq (index $getReferencedResourceStateResources $field.FieldConfig.References.Resource) "true" )) }}
 {{- $getReferencedResourceStateResources = AddToMap $getReferencedResourceStateResources $field.FieldConfig.References.Resource "true" }}
 {{ template "read_referenced_resource_and_validate" $field }}
 {{ end -}}
 {{ end -}}
 {{ end -}}
+{{- if .CRD.HasKubernetesObjectReferenceFields }}
+
+{{ template "read_referenced_object_value" }}
+{{ end -}}
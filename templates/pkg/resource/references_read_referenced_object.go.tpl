@@ -0,0 +1,43 @@
+{{/*
+"read_referenced_object_value" template defines the function reading the value
+of references to Kubernetes objects that are not ACK resources. It should be
+invoked once for a CRD with such reference fields.
+Ex: {{ template "read_referenced_object_value" }}
+ */}}
+{{- define "read_referenced_object_value" -}}
+// getReferencedObjectValue reads the referenced Kubernetes object with the
+// supplied GroupVersionKind and returns the value found at the supplied
+// JSONPath in it. Returns `ackerr.ResourceReferenceMissingTargetFieldFor` if
+// the JSONPath does not match any value in the object. An empty value found at
+// the JSONPath is returned as is.
+func getReferencedObjectValue(
+	ctx context.Context,
+	apiReader client.Reader,
+	gvk schema.GroupVersionKind,
+	name string, // the Kubernetes name of the referenced object
+	namespace string, // the Kubernetes namespace of the referenced object
+	jsonPath string,
+) (string, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name: name,
+	}
+	if err := apiReader.Get(ctx, namespacedName, obj); err != nil {
+		return "", err
+	}
+	// Missing keys fail the execution, so that they are told apart from keys
+	// with an empty value
+	jp := jsonpath.New(gvk.Kind).AllowMissingKeys(false)
+	if err := jp.Parse(jsonPath); err != nil {
+		return "", err
+	}
+	var value bytes.Buffer
+	if err := jp.Execute(&value, obj.Object); err != nil {
+		return "", ackerr.ResourceReferenceMissingTargetFieldFor(
+			gvk.Kind, namespace, name, jsonPath)
+	}
+	return value.String(), nil
+}
+{{- end -}}
@@ -5,10 +5,7 @@ Ex: {{ template "read_referenced_resource_and_validate" $field }}
 Where field is of type 'Field' from aws-controllers-k8s/code-generator/pkg/model
  */}}
 {{- define "read_referenced_resource_and_validate" -}}
-{{- $objType := ( printf "%sapitypes.%s" .ReferencedServiceName .FieldConfig.References.Resource ) -}}
-{{- if eq .FieldConfig.References.ServiceName "" -}}
-{{- $objType = ( printf "svcapitypes.%s" .FieldConfig.References.Resource) -}}
-{{ end -}}
+{{- $objType := ( printf "%s.%s" .ReferencedAPITypesAlias .FieldConfig.References.Resource ) -}}
 // getReferencedResourceState_{{ .FieldConfig.References.Resource }} looks up whether a referenced resource
 // exists and is in a ACK.ResourceSynced=True state. If the referenced resource does exist and is
 // in a Synced state, returns nil, otherwise returns `ackerr.ResourceReferenceTerminalFor` or
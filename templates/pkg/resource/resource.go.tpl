@@ -10,7 +10,7 @@ import (
 	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"github.com/aws/aws-sdk-go-v2/aws"
 
-	svcapitypes "github.com/aws-controllers-k8s/{{ .ControllerName }}-controller/apis/{{ .APIVersion}}"
+	svcapitypes "github.com/aws-controllers-k8s/{{ .ControllerName }}-controller/apis/{{ .APIVersion}}{{ with .CRD.APIGroupPackage }}/{{ . }}{{ end }}"
 )
 
 // Hack to avoid import errors during build...
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"math"
 
@@ -23,13 +24,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	svcapitypes "github.com/aws-controllers-k8s/{{.ControllerName }}-controller/apis/{{ .APIVersion }}"
+	svcapitypes "github.com/aws-controllers-k8s/{{.ControllerName }}-controller/apis/{{ .APIVersion }}{{ with .CRD.APIGroupPackage }}/{{ . }}{{ end }}"
 )
 
 // Hack to avoid import errors during build...
 var (
 	_ = &metav1.Time{}
 	_ = strings.ToLower("")
+	_ = strconv.Itoa
 	_ = &svcsdk.Client{}
 	_ = &svcapitypes.{{ .CRD.Names.Camel }}{}
 	_ = ackv1alpha1.AWSAccountID("")
@@ -39,6 +41,7 @@ var (
 	_ = fmt.Sprintf("")
 	_ = &ackrequeue.NoRequeue{}
 	_ = &aws.Config{}
+	_ = &smithy.GenericAPIError{}
 )
 
 // sdkFind returns SDK-specific information about a supplied resource
@@ -57,6 +60,11 @@ var (
 // sdkCreate creates the supplied resource in the backend AWS service API and
 // returns a copy of the resource with resource fields (in both Spec and
 // Status) filled in with values from the CREATE API operation's Output shape.
+{{ if .CRD.IsObserveOnly }}
+	{{- template "sdk_create_observe_only" . }}
+{{- else if .CRD.IsUpsert }}
+	{{- template "sdk_create_replace" . }}
+{{- else -}}
 func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	desired *resource,
@@ -66,6 +74,11 @@ func (rm *resourceManager) sdkCreate(
 	defer func() {
 		exit(err)
 	}()
+{{- if .CRD.ReadAfterCreateGraceSeconds }}
+	if err = readAfterCreateGrace(desired); err != nil {
+		return nil, err
+	}
+{{- end }}
 
 {{- if $hookCode := Hook .CRD "sdk_create_pre_build_request" }}
 {{ $hookCode }}
@@ -101,6 +114,13 @@ func (rm *resourceManager) sdkCreate(
 {{- end }}
 {{ GoCodeSetCreateOutput .CRD "resp" "ko" 1 }}
 	rm.setStatusDefaults(ko)
+{{- if .CRD.ARNTemplate }}
+{{ GoCodeSetResourceARNFromTemplate .CRD "ko" 1 }}
+{{- end }}
+{{- if .CRD.ReadAfterCreateGraceSeconds }}
+	setCreatedAt(ko)
+{{- end }}
+{{- GoCodeSetAsyncOperationID .CRD "Create" .CRD.Ops.Create "resp" "ko" 1 }}
 {{- if $setOutputCustomMethodName := .CRD.SetOutputCustomMethodName .CRD.Ops.Create }}
 	// custom set output from response
 	ko, err = rm.{{ $setOutputCustomMethodName }}(ctx, desired, resp, ko)
@@ -125,15 +145,24 @@ func (rm *resourceManager) newCreateRequestPayload(
 {{ GoCodeSetCreateInput .CRD "r.ko" "res" 1 }}
 	return res, nil
 }
+{{- end }}
 
 // sdkUpdate patches the supplied resource in the backend AWS service API and
 // returns a new resource with updated fields.
-{{ if .CRD.CustomUpdateMethodName }}
+{{ if .CRD.IsObserveOnly }}
+	{{- template "sdk_update_observe_only" . }}
+{{- else if .CRD.CustomUpdateMethodName }}
 	{{- template "sdk_update_custom" . }}
+{{- else if .CRD.HasUpdateOperations }}
+	{{- template "sdk_update_operations" . }}
+{{- else if .CRD.UpdatesByReplace }}
+	{{- template "sdk_update_replace" . }}
 {{- else if .CRD.Ops.Update }}
 	{{- template "sdk_update" . }}
 {{- else if .CRD.Ops.SetAttributes }}
 	{{- template "sdk_update_set_attributes" . }}
+{{- else if .CRD.HasTagSyncOperations }}
+	{{- template "sdk_update_tag_sync" . }}
 {{- else }}
 	{{- template "sdk_update_not_implemented" . }}
 {{- end }}
@@ -149,13 +178,40 @@ func (rm *resourceManager) sdkDelete(
 		exit(err)
 	}()
 
-{{- if .CRD.CustomDeleteMethodName }}
+{{- if .CRD.IsObserveOnly }}
+	return nil, ackerr.NewTerminalError(errors.New(
+		"{{ .CRD.Kind }} is observe-only; the controller does not delete the AWS resource",
+	))
+{{- else if .CRD.CustomDeleteMethodName }}
 	{{- template "sdk_delete_custom" . }}
 {{- else if .CRD.Ops.Delete }}
 {{- if $hookCode := Hook .CRD "sdk_delete_pre_build_request" }}
 {{ $hookCode }}
 {{- end }}
 {{- GoCodeResourceIsDeletable .CRD "r" 1 }}
+{{- if .CRD.HasAsyncOperations }}
+	// Wait for the asynchronous operation in progress when the resource was
+	// read, if any, to complete before deleting the resource.
+	if rm.asyncOperationsInProgress(r) {
+		return r, ackrequeue.NeededAfter(
+			errors.New("asynchronous operation in progress, cannot be deleted"),
+			ackrequeue.DefaultRequeueAfterDuration,
+		)
+	}
+{{- end }}
+{{- if .CRD.PreDeleteUpdates }}
+	// Apply the pre-delete updates, e.g. lifting deletion protection, if the
+	// resource's annotations allow it. The resource is requeued after the
+	// update, and only deleted once it is read with the updates applied.
+	if updated, ok, err := rm.preDeleteUpdate(ctx, r); err != nil {
+		return nil, err
+	} else if ok {
+		return updated, ackrequeue.NeededAfter(
+			errors.New("resource updated before deletion, waiting for the update to be applied"),
+			ackrequeue.DefaultRequeueAfterDuration,
+		)
+	}
+{{- end }}
 {{- if $customMethod := .CRD.GetCustomImplementation .CRD.Ops.Delete }}
 	if err = rm.{{ $customMethod }}(ctx, r); err != nil {
 		return nil, err
@@ -174,7 +230,22 @@ func (rm *resourceManager) sdkDelete(
 {{- if $hookCode := Hook .CRD "sdk_delete_post_request" }}
 {{ $hookCode }}
 {{- end }}
+{{- if $setAsyncOperationID := GoCodeSetAsyncOperationID .CRD "Delete" .CRD.Ops.Delete "resp" "ko" 1 }}
+	if err != nil {
+		return nil, err
+	}
+	// The deletion completes asynchronously. Requeue until the resource can
+	// no longer be found.
+	ko := r.ko.DeepCopy()
+{{- $setAsyncOperationID }}
+	return &resource{ko}, ackrequeue.NeededAfter(nil, ackrequeue.DefaultRequeueAfterDuration)
+{{- else }}
 	return nil, err
+{{- end }}
+{{- else if .CRD.IsSingleton }}
+	// The {{ .CRD.Kind }} singleton always exists in AWS and its delete policy
+	// leaves it as it is when the custom resource is deleted.
+	return nil, nil
 {{- else }}
 	// TODO(jaypipes): Figure this out...
 	return nil, nil
@@ -313,7 +384,8 @@ func (rm *resourceManager) updateConditions (
 
 // terminalAWSError returns awserr, true; if the supplied error is an aws Error type
 // and if the exception indicates that it is a Terminal exception
-// 'Terminal' exception are specified in generator configuration
+// 'Terminal' exception are inferred from the API model or specified in
+// generator configuration
 func (rm *resourceManager) terminalAWSError(err error) bool {
 {{- if .CRD.TerminalExceptionCodes }}
 	if err == nil {
@@ -332,11 +404,132 @@ func (rm *resourceManager) terminalAWSError(err error) bool {
 		return false
 	}
 {{- else }}
-	// No terminal_errors specified for this resource in generator config
+	// No terminal errors inferred or specified for this resource
 	return false
 {{- end }}
 }
 
+// retryableAWSError returns true if the supplied error is an aws Error type
+// and if the exception indicates that the request may succeed when retried,
+// like a throttling exception
+// 'Retryable' exception are inferred from the API model or specified in
+// generator configuration
+func (rm *resourceManager) retryableAWSError(err error) bool {
+{{- if .CRD.RetryableExceptionCodes }}
+	if err == nil {
+		return false
+	}
+
+	var retryableErr smithy.APIError
+	if !errors.As(err, &retryableErr) {
+		return false
+	}
+	switch retryableErr.ErrorCode() {
+	case {{ range $x, $retryableCode := .CRD.RetryableExceptionCodes -}}{{ if ne ($x) (0) }},
+		{{ end }} "{{ $retryableCode }}"{{ end }}:
+		return true
+	default:
+		return false
+	}
+{{- else }}
+	// No retryable errors inferred or specified for this resource
+	return false
+{{- end }}
+}
+
+{{- if .CRD.ReadAfterCreateGraceErrorCodes }}
+
+// readAfterCreateGraceAWSError returns true if the supplied error is an aws
+// Error type and if the exception is treated like NotFound during the
+// read-after-create grace window
+// 'Read after create grace' exceptions are specified in generator
+// configuration
+func (rm *resourceManager) readAfterCreateGraceAWSError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var graceErr smithy.APIError
+	if !errors.As(err, &graceErr) {
+		return false
+	}
+	switch graceErr.ErrorCode() {
+	case {{ range $x, $graceCode := .CRD.ReadAfterCreateGraceErrorCodes -}}{{ if ne ($x) (0) }},
+		{{ end }} "{{ $graceCode }}"{{ end }}:
+		return true
+	default:
+		return false
+	}
+}
+{{- end }}
+
+{{- if .CRD.HasAsyncOperations }}
+
+// asyncOperationsCompleted returns true if no asynchronous operation of the
+// supplied resource is in progress, polling the status of the operations
+// whose ID is stored in the resource's Status. The IDs of completed
+// operations are cleared from the supplied resource, so it is only called
+// from sdkFind on the resource it returns.
+func (rm *resourceManager) asyncOperationsCompleted(
+	ctx context.Context,
+	r *resource,
+) (bool, error) {
+{{ GoCodeAsyncOperationsCompleted .CRD "r" 1 }}
+	return true, nil
+}
+
+// asyncOperationsInProgress returns true if an asynchronous operation of the
+// supplied resource was in progress when the resource was last read by
+// sdkFind. It does not call the AWS API.
+func (rm *resourceManager) asyncOperationsInProgress(
+	r *resource,
+) bool {
+{{ GoCodeAsyncOperationsInProgress .CRD "r" 1 }}
+	return false
+}
+{{- end }}
+
+{{- if .CRD.PreDeleteUpdates }}
+
+// allowPreDeleteUpdatesAnnotation is the annotation with which users allow the
+// controller to update the resource before deleting it.
+const allowPreDeleteUpdatesAnnotation = ackv1alpha1.AnnotationPrefix + "allow-pre-delete-updates"
+
+// preDeleteUpdate sets the fields of the supplied resource that must be
+// updated before it can be deleted, like its deletion protection, and updates
+// the resource if any of them differ from their pre-delete values. It returns
+// the updated resource and true if the resource was updated. The resource is
+// never updated unless its allow-pre-delete-updates annotation is "true".
+func (rm *resourceManager) preDeleteUpdate(
+	ctx context.Context,
+	r *resource,
+) (*resource, bool, error) {
+	if r.ko.GetAnnotations()[allowPreDeleteUpdatesAnnotation] != "true" {
+		return r, false, nil
+	}
+	ko := r.ko.DeepCopy()
+	updated := false
+{{ GoCodePreDeleteUpdates .CRD "ko" "updated" 1 }}
+	if !updated {
+		return r, false, nil
+	}
+	desired := &resource{ko}
+	updatedRes, err := rm.sdkUpdate(ctx, desired, r, newResourceDelta(desired, r))
+	if err != nil {
+		return r, false, err
+	}
+	return updatedRes, true, nil
+}
+{{- end }}
+
+{{- if .CRD.ChildCollectionFields }}
+{{ template "sdk_child_collections" . }}
+{{- end }}
+
+{{- if .CRD.HasTagSyncOperations }}
+{{ template "sdk_tag_sync" . }}
+{{- end }}
+
 {{- if $hookCode := Hook .CRD "sdk_file_end" }}
 {{ $hookCode }}
 {{- end }}
@@ -0,0 +1,62 @@
+{{- define "sdk_child_collections" -}}
+{{- range $cc := .CRD.ChildCollections }}
+{{- $fieldName := $cc.Field.Names.Camel }}
+
+// sync{{ $fieldName }} adds the children of the Spec.{{ $fieldName }} field that
+// are missing from the latest resource and removes the children of the latest
+// resource that are no longer desired
+func (rm *resourceManager) sync{{ $fieldName }}(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sync{{ $fieldName }}")
+	defer func() {
+		exit(err)
+	}()
+{{ GoCodeChildCollectionSync $.CRD $cc "desired" "latest" 1 -}}
+}
+{{- range $op := $cc.Operations }}
+
+// {{ $cc.PayloadMethodName $op }} returns an SDK-specific
+// struct for the HTTP request payload of the {{ $op.ExportedName }} API
+// call for the children in the Spec.{{ $fieldName }} field of the resource
+func (rm *resourceManager) {{ $cc.PayloadMethodName $op }}(
+	r *resource,
+) (*svcsdk.{{ $op.InputRef.Shape.ShapeName }}, error) {
+	res := &svcsdk.{{ $op.InputRef.Shape.ShapeName }}{}
+{{ GoCodeSetChildOperationInput $.CRD $cc $op "r.ko" "res" 1 }}
+	return res, nil
+}
+{{- end }}
+{{- if $cc.ReadOp }}
+{{- $readOpName := $cc.ReadOp.ExportedName }}
+
+// read{{ $fieldName }} sets the Spec.{{ $fieldName }} field of the supplied
+// resource from every page of the {{ $readOpName }} API call
+func (rm *resourceManager) read{{ $fieldName }}(
+	ctx context.Context,
+	ko *svcapitypes.{{ $.CRD.Names.Camel }},
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.read{{ $fieldName }}")
+	defer func() {
+		exit(err)
+	}()
+{{ GoCodeChildCollectionRead $.CRD $cc "ko" 1 -}}
+}
+
+// {{ $cc.PayloadMethodName $cc.ReadOp }} returns an SDK-specific
+// struct for the HTTP request payload of the {{ $readOpName }} API call
+// for the resource
+func (rm *resourceManager) {{ $cc.PayloadMethodName $cc.ReadOp }}(
+	r *resource,
+) (*svcsdk.{{ $cc.ReadOp.InputRef.Shape.ShapeName }}, error) {
+	res := &svcsdk.{{ $cc.ReadOp.InputRef.Shape.ShapeName }}{}
+{{ GoCodeSetChildCollectionReadInput $.CRD $cc "r.ko" "res" 1 }}
+	return res, nil
+}
+{{- end }}
+{{- end }}
+{{- end -}}
@@ -33,8 +33,8 @@ func (rm *resourceManager) sdkFind(
 {{- end }}
 	rm.metrics.RecordAPICall("GET_ATTRIBUTES", "{{ .CRD.Ops.GetAttributes.ExportedName }}", err)
 	if err != nil {
-		var awsErr smithy.APIError
-		if errors.As(err, &awsErr) && awsErr.ErrorCode() == "{{ ResourceExceptionCode .CRD 404 }}" {{ GoCodeSetExceptionMessageCheck .CRD 404 }} {
+		var awsErr {{ GoCodeExceptionGoType .CRD .CRD.Ops.GetAttributes (ResourceExceptionCode .CRD 404) }}
+		if errors.As(err, &awsErr) {{ GoCodeExceptionCodeCheck .CRD .CRD.Ops.GetAttributes (ResourceExceptionCode .CRD 404) "awsErr" }}{{ GoCodeSetExceptionMessageCheck .CRD 404 }}{
 			return nil, ackerr.NotFound
 		}
 		return nil, err
@@ -48,6 +48,12 @@ func (rm *resourceManager) sdkFind(
 {{ $hookCode }}
 {{- end }}
 	rm.setStatusDefaults(ko)
+{{- if .CRD.ARNTemplate }}
+{{ GoCodeSetResourceARNFromTemplate .CRD "ko" 1 }}
+{{- end }}
+{{- GoCodeChildCollectionReads .CRD "ko" 1 }}
+{{- GoCodeTagSyncReads .CRD "ko" 1 }}
+{{- GoCodeAsyncOperationsReads .CRD "ko" 1 }}
 {{- if $hookCode := Hook .CRD "sdk_get_attributes_post_set_output" }}
 {{ $hookCode }}
 {{- end }}
@@ -8,6 +8,15 @@ func (rm *resourceManager) sdkFind(
 	defer func() {
 		exit(err)
 	}()
+{{- if .CRD.ReadAfterCreateGraceErrorCodes }}
+	// Within the read-after-create grace window, the configured error codes
+	// report the resource as not found yet.
+	defer func() {
+		if err != nil && rm.readAfterCreateGraceAWSError(err) && withinReadAfterCreateGrace(r) {
+			latest, err = nil, ackerr.NotFound
+		}
+	}()
+{{- end }}
 
 {{- if $hookCode := Hook .CRD "sdk_read_many_pre_build_request" }}
 {{ $hookCode }}
@@ -33,8 +42,8 @@ func (rm *resourceManager) sdkFind(
 {{- end }}
 	rm.metrics.RecordAPICall("READ_MANY", "{{ .CRD.Ops.ReadMany.ExportedName }}", err)
 	if err != nil {
-		var awsErr smithy.APIError
-		if errors.As(err, &awsErr) && awsErr.ErrorCode() == "{{ ResourceExceptionCode .CRD 404 }}" {{ GoCodeSetExceptionMessageCheck .CRD 404 }} {
+		var awsErr {{ GoCodeExceptionGoType .CRD .CRD.Ops.ReadMany (ResourceExceptionCode .CRD 404) }}
+		if errors.As(err, &awsErr) {{ GoCodeExceptionCodeCheck .CRD .CRD.Ops.ReadMany (ResourceExceptionCode .CRD 404) "awsErr" }}{{ GoCodeSetExceptionMessageCheck .CRD 404 }}{
 			return nil, ackerr.NotFound
 		}
 		return nil, err
@@ -48,6 +57,9 @@ func (rm *resourceManager) sdkFind(
 {{- end }}
 {{ GoCodeSetReadManyOutput .CRD "resp" "ko" 1 }}
 	rm.setStatusDefaults(ko)
+{{- if .CRD.ARNTemplate }}
+{{ GoCodeSetResourceARNFromTemplate .CRD "ko" 1 }}
+{{- end }}
 {{- if $setOutputCustomMethodName := .CRD.SetOutputCustomMethodName .CRD.Ops.ReadMany }}
 	// custom set output from response
 	ko, err = rm.{{ $setOutputCustomMethodName }}(ctx, r, resp, ko)
@@ -55,6 +67,9 @@ func (rm *resourceManager) sdkFind(
 		return nil, err
 	}
 {{- end }}
+{{- GoCodeChildCollectionReads .CRD "ko" 1 }}
+{{- GoCodeTagSyncReads .CRD "ko" 1 }}
+{{- GoCodeAsyncOperationsReads .CRD "ko" 1 }}
 {{- if $hookCode := Hook .CRD "sdk_read_many_post_set_output" }}
 {{ $hookCode }}
 {{- end }}
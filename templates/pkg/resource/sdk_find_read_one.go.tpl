@@ -8,6 +8,15 @@ func (rm *resourceManager) sdkFind(
 	defer func() {
 		exit(err)
 	}()
+{{- if .CRD.ReadAfterCreateGraceErrorCodes }}
+	// Within the read-after-create grace window, the configured error codes
+	// report the resource as not found yet.
+	defer func() {
+		if err != nil && rm.readAfterCreateGraceAWSError(err) && withinReadAfterCreateGrace(r) {
+			latest, err = nil, ackerr.NotFound
+		}
+	}()
+{{- end }}
 
 {{- if $hookCode := Hook .CRD "sdk_read_one_pre_build_request" }}
 {{ $hookCode }}
@@ -34,8 +43,8 @@ func (rm *resourceManager) sdkFind(
 {{- end }}
 	rm.metrics.RecordAPICall("READ_ONE", "{{ .CRD.Ops.ReadOne.ExportedName }}", err)
 	if err != nil {
-		var awsErr smithy.APIError
-		if errors.As(err, &awsErr) && awsErr.ErrorCode() == "{{ ResourceExceptionCode .CRD 404 }}" {{ GoCodeSetExceptionMessageCheck .CRD 404 }} {
+		var awsErr {{ GoCodeExceptionGoType .CRD .CRD.Ops.ReadOne (ResourceExceptionCode .CRD 404) }}
+		if errors.As(err, &awsErr) {{ GoCodeExceptionCodeCheck .CRD .CRD.Ops.ReadOne (ResourceExceptionCode .CRD 404) "awsErr" }}{{ GoCodeSetExceptionMessageCheck .CRD 404 }}{
 			return nil, ackerr.NotFound
 		}
 		return nil, err
@@ -49,6 +58,9 @@ func (rm *resourceManager) sdkFind(
 {{- end }}
 {{ GoCodeSetReadOneOutput .CRD "resp" "ko" 1 }}
 	rm.setStatusDefaults(ko)
+{{- if .CRD.ARNTemplate }}
+{{ GoCodeSetResourceARNFromTemplate .CRD "ko" 1 }}
+{{- end }}
 {{- if $setOutputCustomMethodName := .CRD.SetOutputCustomMethodName .CRD.Ops.ReadOne }}
 	// custom set output from response
 	ko, err = rm.{{ $setOutputCustomMethodName }}(ctx, r, resp, ko)
@@ -56,6 +68,9 @@ func (rm *resourceManager) sdkFind(
 		return nil, err
 	}
 {{- end }}
+{{- GoCodeChildCollectionReads .CRD "ko" 1 }}
+{{- GoCodeTagSyncReads .CRD "ko" 1 }}
+{{- GoCodeAsyncOperationsReads .CRD "ko" 1 }}
 {{- if $hookCode := Hook .CRD "sdk_read_one_post_set_output" }}
 {{ $hookCode }}
 {{- end }}
@@ -0,0 +1,23 @@
+{{- define "sdk_create_observe_only" -}}
+func (rm *resourceManager) sdkCreate(
+	ctx context.Context,
+	desired *resource,
+) (*resource, error) {
+	// {{ .CRD.Kind }} is observe-only: the AWS resource must already exist
+	return nil, ackerr.NewTerminalError(errors.New(
+		"{{ .CRD.Kind }} is observe-only and the AWS resource was not found; the controller does not create it",
+	))
+}
+{{- end -}}
+{{- define "sdk_update_observe_only" -}}
+func (rm *resourceManager) sdkUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (*resource, error) {
+	return nil, ackerr.NewTerminalError(errors.New(
+		"{{ .CRD.Kind }} is observe-only; the controller does not update the AWS resource",
+	))
+}
+{{- end -}}
@@ -0,0 +1,130 @@
+{{- define "sdk_create_replace" -}}
+func (rm *resourceManager) sdkCreate(
+	ctx context.Context,
+	desired *resource,
+) (created *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkCreate")
+	defer func() {
+		exit(err)
+	}()
+{{- if .CRD.ReadAfterCreateGraceSeconds }}
+	if err = readAfterCreateGrace(desired); err != nil {
+		return nil, err
+	}
+{{- end }}
+{{ template "sdk_replace_call" (AddToMap (AddToMap (AddToMap (Nil) "CRD" .CRD) "Op" "Create") "Var" "created") }}
+{{- if .CRD.ReadAfterCreateGraceSeconds }}
+	setCreatedAt(ko)
+{{- end }}
+{{- GoCodeCustomSyncCreate .CRD "ko" 1 }}
+	return &resource{ko}, nil
+}
+
+// newReplaceRequestPayload returns an SDK-specific struct for the HTTP request
+// payload of the {{ .CRD.Ops.Replace.ExportedName }} API call for the resource,
+// which both creates and updates the resource
+func (rm *resourceManager) newReplaceRequestPayload(
+	ctx context.Context,
+	r *resource,
+) (*svcsdk.{{ .CRD.Ops.Replace.InputRef.Shape.ShapeName }}, error) {
+	res := &svcsdk.{{ .CRD.Ops.Replace.InputRef.Shape.ShapeName }}{}
+{{ GoCodeSetReplaceInput .CRD "r.ko" "res" 1 }}
+	return res, nil
+}
+{{- end -}}
+
+{{- define "sdk_update_replace" -}}
+func (rm *resourceManager) sdkUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkUpdate")
+	defer func() {
+		exit(err)
+	}()
+{{- GoCodeResourceIsUpdateable .CRD "latest" 1 }}
+{{- GoCodeCustomSyncUpdate .CRD "desired" "latest" "delta" 1 }}
+	// The {{ .CRD.Ops.Replace.ExportedName }} API replaces the whole resource,
+	// so the full desired state is sent regardless of the delta.
+{{ template "sdk_replace_call" (AddToMap (AddToMap (AddToMap (Nil) "CRD" .CRD) "Op" "Update") "Var" "updated") }}
+	return &resource{ko}, nil
+}
+{{- end -}}
+
+{{- /*
+sdk_replace_call calls the Replace operation of an upsert resource from
+sdkCreate or sdkUpdate, calling the hooks of the calling method, and leaves
+the written resource in `ko`. It expects a map with the CRD under "CRD", the
+type of operation of the calling method ("Create" or "Update") under "Op" and
+the name of the calling method's named return value under "Var".
+*/ -}}
+{{- define "sdk_replace_call" -}}
+{{- $hookPrefix := printf "sdk_%s" (ToLower .Op) -}}
+{{- if $hookCode := Hook .CRD (printf "%s_pre_build_request" $hookPrefix) }}
+{{ $hookCode }}
+{{- end }}
+{{- if $customMethod := .CRD.GetCustomImplementation .CRD.Ops.Replace }}
+	{{ .Var }}, err = rm.{{ $customMethod }}(ctx, desired)
+	if {{ .Var }} != nil || err != nil {
+		return {{ .Var }}, err
+	}
+{{- end }}
+	input, err := rm.newReplaceRequestPayload(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+{{- if $hookCode := Hook .CRD (printf "%s_post_build_request" $hookPrefix) }}
+{{ $hookCode }}
+{{- end }}
+
+	var resp {{ .CRD.GetOutputShapeGoType .CRD.Ops.Replace }}; _ = resp;
+	resp, err = rm.sdkapi.{{ .CRD.Ops.Replace.ExportedName }}(ctx, input)
+{{- if $hookCode := Hook .CRD (printf "%s_post_request" $hookPrefix) }}
+{{ $hookCode }}
+{{- end }}
+	rm.metrics.RecordAPICall("REPLACE", "{{ .CRD.Ops.Replace.ExportedName }}", err)
+	if err != nil {
+		return nil, err
+	}
+	// Merge in the information we read from the API call above to the copy of
+	// the original Kubernetes object we passed to the function
+	ko := desired.ko.DeepCopy()
+{{- if $hookCode := Hook .CRD (printf "%s_pre_set_output" $hookPrefix) }}
+{{ $hookCode }}
+{{- end }}
+{{ GoCodeSetReplaceOutput .CRD "resp" "ko" 1 }}
+	rm.setStatusDefaults(ko)
+{{- GoCodeSetAsyncOperationID .CRD .Op .CRD.Ops.Replace "resp" "ko" 1 }}
+{{- if .CRD.ARNTemplate }}
+{{ GoCodeSetResourceARNFromTemplate .CRD "ko" 1 }}
+{{- end }}
+{{- if $setOutputCustomMethodName := .CRD.SetOutputCustomMethodName .CRD.Ops.Replace }}
+	// custom set output from response
+	ko, err = rm.{{ $setOutputCustomMethodName }}(ctx, desired, resp, ko)
+	if err != nil {
+		return nil, err
+	}
+{{- end }}
+{{- if .CRD.ReplaceReadsAfterWrite }}
+	// The {{ .CRD.Ops.Replace.ExportedName }} API returns nothing about the
+	// resource, so read it back to populate its Status fields. A resource that
+	// is not readable yet is returned as written and refreshed by the next
+	// reconciliation.
+	observed, err := rm.sdkFind(ctx, &resource{ko})
+	if err != nil {
+		if err != ackerr.NotFound {
+			return nil, err
+		}
+		err = nil
+	} else {
+		ko = observed.ko
+	}
+{{- end }}
+{{- if $hookCode := Hook .CRD (printf "%s_post_set_output" $hookPrefix) }}
+{{ $hookCode }}
+{{- end }}
+{{- end -}}
@@ -0,0 +1,56 @@
+{{- define "sdk_tag_sync" -}}
+{{- $ts := .CRD.TagSync }}
+{{- $fieldName := $ts.Field.Names.Camel }}
+
+// {{ $ts.Field.CustomSyncMethodName }} removes the tags of the latest resource
+// that are no longer desired with the {{ $ts.UntagOp.ExportedName }} API call,
+// and adds the desired tags that are missing or changed with the
+// {{ $ts.TagOp.ExportedName }} API call
+func (rm *resourceManager) {{ $ts.Field.CustomSyncMethodName }}(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.{{ $ts.Field.CustomSyncMethodName }}")
+	defer func() {
+		exit(err)
+	}()
+{{ GoCodeTagSyncUpdate .CRD "desired" "latest" 1 -}}
+}
+{{- if $ts.ListOp }}
+
+// read{{ $fieldName }} sets the Spec.{{ $fieldName }} field of the supplied
+// resource from the {{ $ts.ListOp.ExportedName }} API call
+func (rm *resourceManager) read{{ $fieldName }}(
+	ctx context.Context,
+	ko *svcapitypes.{{ .CRD.Names.Camel }},
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.read{{ $fieldName }}")
+	defer func() {
+		exit(err)
+	}()
+{{ GoCodeTagSyncRead .CRD "ko" 1 -}}
+}
+{{- end }}
+{{- end -}}
+
+{{- define "sdk_update_tag_sync" -}}
+func (rm *resourceManager) sdkUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkUpdate")
+	defer func() {
+		exit(err)
+	}()
+{{- GoCodeResourceIsUpdateable .CRD "latest" 1 }}
+{{- GoCodeCustomSyncUpdate .CRD "desired" "latest" "delta" 1 }}
+	// The resource has no Update operation for the fields outside of its tags
+	return nil, ackerr.NewTerminalError(ackerr.NotImplemented)
+}
+{{- end -}}
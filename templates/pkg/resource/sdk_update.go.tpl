@@ -46,6 +46,7 @@ func (rm *resourceManager) sdkUpdate(
 {{- end }}
 {{ GoCodeSetUpdateOutput .CRD "resp" "ko" 1 }}
 	rm.setStatusDefaults(ko)
+{{- GoCodeSetAsyncOperationID .CRD "Update" .CRD.Ops.Update "resp" "ko" 1 }}
 {{- if $setOutputCustomMethodName := .CRD.SetOutputCustomMethodName .CRD.Ops.Update }}
 	// custom set output from response
 	ko, err = rm.{{ $setOutputCustomMethodName }}(ctx, desired, resp, ko)
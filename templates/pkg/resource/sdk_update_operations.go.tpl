@@ -0,0 +1,85 @@
+{{- define "sdk_update_operations" -}}
+func (rm *resourceManager) sdkUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkUpdate")
+	defer func() {
+		exit(err)
+	}()
+{{- if $hookCode := Hook .CRD "sdk_update_pre_build_request" }}
+{{ $hookCode }}
+{{- end }}
+{{- GoCodeResourceIsUpdateable .CRD "latest" 1 }}
+{{- GoCodeCustomSyncUpdate .CRD "desired" "latest" "delta" 1 }}
+	// Merge in the information we read from the API calls below to the copy
+	// of the original Kubernetes object we passed to the function
+	ko := desired.ko.DeepCopy()
+{{ GoCodeUpdateOperations .CRD "desired" "delta" "ko" 1 }}
+{{- if $hookCode := Hook .CRD "sdk_update_pre_set_output" }}
+{{ $hookCode }}
+{{- end }}
+	rm.setStatusDefaults(ko)
+{{- if $hookCode := Hook .CRD "sdk_update_post_set_output" }}
+{{ $hookCode }}
+{{- end }}
+	return &resource{ko}, nil
+}
+{{- range $updateOp := .CRD.UpdateOperations }}
+{{- $opName := $updateOp.Operation.ExportedName }}
+
+// update{{ $opName }} calls the {{ $opName }} API to update the fields of the
+// supplied resource owned by that operation, and sets the supplied object from
+// the operation's response
+func (rm *resourceManager) update{{ $opName }}(
+	ctx context.Context,
+	desired *resource,
+	delta *ackcompare.Delta,
+	ko *svcapitypes.{{ $.CRD.Names.Camel }},
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.update{{ $opName }}")
+	defer func() {
+		exit(err)
+	}()
+{{- if $hookCode := Hook $.CRD (printf "sdk_update_%s_pre_build_request" $updateOp.Names.Snake) }}
+{{ $hookCode }}
+{{- end }}
+	input, err := rm.new{{ $opName }}RequestPayload(ctx, desired, delta)
+	if err != nil {
+		return err
+	}
+{{- if $hookCode := Hook $.CRD (printf "sdk_update_%s_post_build_request" $updateOp.Names.Snake) }}
+{{ $hookCode }}
+{{- end }}
+
+	var resp {{ $.CRD.GetOutputShapeGoType $updateOp.Operation }}; _ = resp;
+	resp, err = rm.sdkapi.{{ $opName }}(ctx, input)
+{{- if $hookCode := Hook $.CRD (printf "sdk_update_%s_post_request" $updateOp.Names.Snake) }}
+{{ $hookCode }}
+{{- end }}
+	rm.metrics.RecordAPICall("UPDATE", "{{ $opName }}", err)
+	if err != nil {
+		return err
+	}
+{{ GoCodeSetUpdateOperationOutput $.CRD $updateOp.Operation "resp" "ko" 1 }}
+{{- GoCodeSetAsyncOperationID $.CRD "Update" $updateOp.Operation "resp" "ko" 1 }}
+	return nil
+}
+
+// new{{ $opName }}RequestPayload returns an SDK-specific struct for the HTTP
+// request payload of the {{ $opName }} API call for the resource
+func (rm *resourceManager) new{{ $opName }}RequestPayload(
+	ctx context.Context,
+	r *resource,
+	delta *ackcompare.Delta,
+) (*svcsdk.{{ $updateOp.Operation.InputRef.Shape.ShapeName }}, error) {
+	res := &svcsdk.{{ $updateOp.Operation.InputRef.Shape.ShapeName }}{}
+{{ GoCodeSetUpdateOperationInput $.CRD $updateOp.Operation "r.ko" "res" 1 }}
+	return res, nil
+}
+{{- end }}
+{{- end -}}
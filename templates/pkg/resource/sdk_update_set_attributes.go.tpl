@@ -41,8 +41,8 @@ func (rm *resourceManager) sdkUpdate(
 {{- end }}
 	rm.metrics.RecordAPICall("SET_ATTRIBUTES", "{{ .CRD.Ops.SetAttributes.ExportedName }}", respErr)
 	if respErr != nil {
-		var awsErr smithy.APIError
-		if errors.As(err, &awsErr) && awsErr.ErrorCode() == "{{ ResourceExceptionCode .CRD 404 }}" {{ GoCodeSetExceptionMessageCheck .CRD 404 }} {
+		var awsErr {{ GoCodeExceptionGoType .CRD .CRD.Ops.SetAttributes (ResourceExceptionCode .CRD 404) }}
+		if errors.As(err, &awsErr) {{ GoCodeExceptionCodeCheck .CRD .CRD.Ops.SetAttributes (ResourceExceptionCode .CRD 404) "awsErr" }}{{ GoCodeSetExceptionMessageCheck .CRD 404 }}{
 			// Technically, this means someone deleted the backend resource in
 			// between the time we got a result back from sdkFind() and here...
 			return nil, ackerr.NotFound
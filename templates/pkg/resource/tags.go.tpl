@@ -5,7 +5,7 @@ package {{ .CRD.Names.Snake }}
 import(
     acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
 
-    svcapitypes "github.com/aws-controllers-k8s/{{ .ControllerName }}-controller/apis/{{ .APIVersion }}"
+    svcapitypes "github.com/aws-controllers-k8s/{{ .ControllerName }}-controller/apis/{{ .APIVersion }}{{ with .CRD.APIGroupPackage }}/{{ . }}{{ end }}"
 )
 
 var (